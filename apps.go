@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks
+
+// DLC describes a DLC of the app, as returned by DLCs.
+type DLC struct {
+	AppID AppId_t
+	Name  string
+
+	// Available is whether the DLC is on sale in the Steam store.
+	Available bool
+	// Owned is whether the user owns the DLC.
+	Owned bool
+	// Installed is whether the DLC is installed. A DLC can be owned and not installed.
+	Installed bool
+}
+
+// DLCs returns the DLC of the app with whether the user owns and has installed each.
+func (s steamApps) DLCs() []DLC {
+	n := int(s.GetDLCCount())
+	if n <= 0 {
+		return nil
+	}
+	dlcs := make([]DLC, 0, n)
+	for i := 0; i < n; i++ {
+		appID, available, name, ok := s.BGetDLCDataByIndex(i)
+		if !ok {
+			continue
+		}
+		dlcs = append(dlcs, DLC{
+			AppID:     appID,
+			Name:      name,
+			Available: available,
+			Owned:     s.BIsSubscribedApp(appID),
+			Installed: s.BIsDlcInstalled(appID),
+		})
+	}
+	return dlcs
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/TaiJiYu/go-steamworks"
+)
+
+func TestApps(t *testing.T) {
+	fake := steamworks.NewFake()
+	fake.AvailableLanguages = []string{"english", "japanese", "schinese"}
+	fake.BetaName = "public_test"
+	fake.BuildID = 1234
+	fake.TrialAllowed = time.Hour
+	fake.TrialPlayed = 10 * time.Minute
+	fake.AddDLC(1000, "DLC", false)
+	fake.Launch("+connect 1.2.3.4", map[string]string{"map": "canyon"})
+	startFake(t, fake)
+	apps := steamworks.SteamApps()
+
+	if !apps.BIsSubscribedApp(1000) || apps.BIsSubscribedApp(5) {
+		t.Errorf("BIsSubscribedApp(1000), BIsSubscribedApp(5) = %v, %v, want true, false", apps.BIsSubscribedApp(1000), apps.BIsSubscribedApp(5))
+	}
+	if langs := apps.GetAvailableGameLanguages(); !slices.Equal(langs, fake.AvailableLanguages) {
+		t.Errorf("GetAvailableGameLanguages() = %q", langs)
+	}
+	if name, ok := apps.GetCurrentBetaName(); !ok || name != "public_test" {
+		t.Errorf("GetCurrentBetaName() = %q, %v", name, ok)
+	}
+	if id := apps.GetAppBuildId(); id != 1234 {
+		t.Errorf("GetAppBuildId() = %d, want 1234", id)
+	}
+	if owner := apps.GetAppOwner(); owner != fake.SteamID {
+		t.Errorf("GetAppOwner() = %d, want the user", owner)
+	}
+	if allowed, played, ok := apps.BIsTimedTrial(); !ok || allowed != time.Hour || played != 10*time.Minute {
+		t.Errorf("BIsTimedTrial() = %v, %v, %v", allowed, played, ok)
+	}
+	if cmd := apps.GetLaunchCommandLine(); cmd != "+connect 1.2.3.4" {
+		t.Errorf("GetLaunchCommandLine() = %q", cmd)
+	}
+	if v, missing := apps.GetLaunchQueryParam("map"), apps.GetLaunchQueryParam("x"); v != "canyon" || missing != "" {
+		t.Errorf("GetLaunchQueryParam() = %q, %q, want canyon and nothing", v, missing)
+	}
+}
+
+func TestDLCs(t *testing.T) {
+	fake := steamworks.NewFake()
+	fake.AddDLC(1001, "Owned", false)
+	fake.AddDLC(1002, "Installed", true)
+	fake.AddUnownedDLC(1003, "Store")
+	startFake(t, fake)
+	apps := steamworks.SteamApps()
+
+	want := []steamworks.DLC{
+		{AppID: 1001, Name: "Owned", Available: true, Owned: true},
+		{AppID: 1002, Name: "Installed", Available: true, Owned: true, Installed: true},
+		{AppID: 1003, Name: "Store", Available: true},
+	}
+	if got := apps.DLCs(); !slices.Equal(got, want) {
+		t.Errorf("DLCs() = %+v, want %+v", got, want)
+	}
+	apps.UninstallDLC(1002)
+	if apps.BIsDlcInstalled(1002) {
+		t.Error("DLC 1002 still installed after UninstallDLC")
+	}
+}
+
+func TestDLCDownload(t *testing.T) {
+	fake := steamworks.NewFake()
+	fake.Latency = 50 * time.Millisecond
+	fake.AddDLC(1001, "Owned", false)
+	fake.AddUnownedDLC(1002, "Store")
+	startFake(t, fake)
+	installed := make(chan steamworks.DlcInstalled_t, 2)
+	defer steamworks.Subscribe(func(ev steamworks.DlcInstalled_t) { installed <- ev })()
+
+	apps := steamworks.SteamApps()
+	apps.InstallDLC(1001)
+	apps.InstallDLC(1002)
+	if ev := receive(t, installed); ev.AppID != 1001 {
+		t.Errorf("DlcInstalled_t.AppID = %d, want 1001", ev.AppID)
+	}
+	if !apps.BIsDlcInstalled(1001) || apps.BIsDlcInstalled(1002) {
+		t.Errorf("installed = %v, %v, want true, false", apps.BIsDlcInstalled(1001), apps.BIsDlcInstalled(1002))
+	}
+}
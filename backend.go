@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"unsafe"
+)
+
+// Backend carries out calls into the Steam flat API, e.g. "SteamAPI_ISteamUserStats_StoreStats".
+//
+// Arguments are passed the way the native library takes them: handles and integers by value,
+// bools as 0 or 1, float32 and float64 values as their IEEE 754 bits, and pointers as addresses that stay
+// valid until Call returns. Each is widened to a uint64, so that 64-bit IDs and handles arrive whole
+// on 32-bit platforms too. Bool results are read from the low byte of the result.
+type Backend interface {
+	Call(name string, args ...uint64) (uint64, error)
+}
+
+var theBackend atomic.Pointer[Backend]
+
+// SetBackend sends every Steam API call to b instead of the Steam library.
+// Passing nil goes back to the library.
+//
+// It is meant for tests and headless builds, together with NewFake, and should be called before Init.
+// The default dispatcher starts over: calls pending on the previous backend fail, and its subscriptions
+// and manual dispatch mode end.
+func SetBackend(b Backend) {
+	DefaultDispatcher().reset(func() {
+		// Handles from the previous backend mean nothing to b.
+		forgetLeaderboards()
+		if b == nil {
+			theBackend.Store(nil)
+			return
+		}
+		theBackend.Store(&b)
+	})
+}
+
+func currentBackend() Backend {
+	if b := theBackend.Load(); b != nil {
+		return *b
+	}
+	return nil
+}
+
+// callArg converts an argument of a flat API call to a uint64 and reports whether the function takes it
+// as a 64-bit integer. Arguments are uintptr values, pointers as unsafe.Pointer, and the 64-bit IDs and
+// handles such as CSteamID as they are, because a uintptr cannot hold them on 32-bit platforms.
+func callArg(arg any) (v uint64, wide bool) {
+	switch arg := arg.(type) {
+	case uintptr:
+		return uint64(arg), false
+	case unsafe.Pointer:
+		return uint64(uintptr(arg)), false
+	}
+	switch v := reflect.ValueOf(arg); v.Kind() {
+	case reflect.Uint64:
+		return v.Uint(), true
+	case reflect.Int64:
+		return uint64(v.Int()), true
+	case reflect.Uintptr, reflect.Uint, reflect.Uint32, reflect.Uint16, reflect.Uint8:
+		return v.Uint(), false
+	case reflect.Int, reflect.Int32, reflect.Int16, reflect.Int8:
+		return uint64(v.Int()), false
+	}
+	panic(fmt.Sprintf("steamworks: unsupported argument type %T", arg))
+}
+
+// callArgs converts the arguments of a flat API call for Backend.Call.
+func callArgs(args []any) []uint64 {
+	words := make([]uint64, len(args))
+	for i, arg := range args {
+		words[i], _ = callArg(arg)
+	}
+	return words
+}
+
+// callWords converts the arguments of a flat API call to the words a function is called with. If split is
+// set, as on 32-bit Windows, a 64-bit integer takes two words, the low one first.
+func callWords(args []any, split bool) []uintptr {
+	words := make([]uintptr, 0, len(args))
+	for _, arg := range args {
+		v, wide := callArg(arg)
+		if wide && split {
+			words = append(words, uintptr(uint32(v)), uintptr(v>>32))
+			continue
+		}
+		words = append(words, uintptr(v))
+	}
+	return words
+}
+
+// checkInterface returns an error for a method call on a null interface, as the accessors such as SteamApps
+// return when the library fails or Steam is not running, rather than let the library crash the process.
+func checkInterface(name string, args []any) error {
+	if !strings.HasPrefix(name, "SteamAPI_ISteam") {
+		return nil
+	}
+	if len(args) > 0 {
+		if self, _ := callArg(args[0]); self != 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("steamworks: %s called on a null interface; is Steam running and initialized?", name)
+}
+
+// escapeSink.enabled is never set. The compiler cannot tell, so what escape is given moves to the heap.
+var escapeSink struct {
+	enabled bool
+	args    []any
+}
+
+// escape moves what the pointers in args point to onto the heap, where it stays in place while its address
+// is passed on as an integer.
+func escape(args []any) {
+	if escapeSink.enabled {
+		escapeSink.args = args
+	}
+}
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"slices"
+	"testing"
+	"unsafe"
+)
+
+func TestCallArg(t *testing.T) {
+	var x int32
+	for _, c := range []struct {
+		arg  any
+		v    uint64
+		wide bool
+	}{
+		{uintptr(7), 7, false},
+		{unsafe.Pointer(&x), uint64(uintptr(unsafe.Pointer(&x))), false},
+		{int32(-2), 0xfffffffffffffffe, false},
+		{uint32(0xffffffff), 0xffffffff, false},
+		{CSteamID(76561197960287930), 76561197960287930, true},
+		{SteamAPICall_t(1<<40 | 3), 1<<40 | 3, true},
+		{int64(-2), 0xfffffffffffffffe, true},
+	} {
+		if v, wide := callArg(c.arg); v != c.v || wide != c.wide {
+			t.Errorf("callArg(%T(%v)) = %#x, %v, want %#x, %v", c.arg, c.arg, v, wide, c.v, c.wide)
+		}
+	}
+}
+
+func TestCallWords(t *testing.T) {
+	id := CSteamID(0x0110000100000002)
+	args := []any{uintptr(1), id, uintptr(3)}
+	if got, want := callWords(args, false), []uintptr{1, uintptr(id), 3}; !slices.Equal(got, want) {
+		t.Errorf("callWords(%v, false) = %#x, want %#x", args, got, want)
+	}
+	// 32-bit Windows takes the ID as two words, the low one first.
+	if got, want := callWords(args, true), []uintptr{1, 2, 0x01100001, 3}; !slices.Equal(got, want) {
+		t.Errorf("callWords(%v, true) = %#x, want %#x", args, got, want)
+	}
+}
@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TaiJiYu/go-steamworks"
+)
+
+func TestSetBackendFailsPendingCalls(t *testing.T) {
+	fake := steamworks.NewFake()
+	fake.Latency = time.Hour
+	startFake(t, fake)
+
+	c := steamworks.NewCallResult[steamworks.UserStatsReceived_t](context.Background(), steamworks.SteamUserStats().RequestCurrentStats())
+	steamworks.SetBackend(steamworks.NewFake())
+	receive(t, c.Done())
+	var callErr *steamworks.CallResultError
+	if _, err := c.Result(); !errors.As(err, &callErr) || callErr.Reason != steamworks.ESteamAPICallFailure_SteamGone {
+		t.Fatalf("err = %v, want Steam gone", err)
+	}
+}
+
+func TestSetBackendEndsManualDispatch(t *testing.T) {
+	startFake(t, steamworks.NewFake())
+	steamworks.DefaultDispatcher().InitManualDispatch()
+
+	startFake(t, steamworks.NewFake())
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := steamworks.Await[steamworks.UserStatsReceived_t](ctx, steamworks.SteamUserStats().RequestCurrentStats()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,6 +1,7 @@
 package steamworks
 
 import (
+	"context"
 	"sync"
 	"time"
 )
@@ -11,22 +12,67 @@ var timeout time.Duration = 10 * time.Second
 type iCallbackExpected int
 
 const (
-	iCallbackExpected_LeaderboardFindResult_t       iCallbackExpected = 1104
-	iCallbackExpected_LeaderboardScoresDownloaded_t iCallbackExpected = 1105
-	iCallbackExpected_LeaderboardScoreUploaded_t    iCallbackExpected = 1106
-	iCallbackExpected_UserStatsReceived_t           iCallbackExpected = 1101
-	iCallbackExpected_GlobalStatsReceived_t         iCallbackExpected = 1112
+	iCallbackExpected_LeaderboardFindResult_t                       iCallbackExpected = 1104
+	iCallbackExpected_LeaderboardScoresDownloaded_t                 iCallbackExpected = 1105
+	iCallbackExpected_LeaderboardScoreUploaded_t                    iCallbackExpected = 1106
+	iCallbackExpected_LeaderboardUGCSet_t                           iCallbackExpected = 1111
+	iCallbackExpected_UserStatsReceived_t                           iCallbackExpected = 1101
+	iCallbackExpected_UserStatsStored_t                             iCallbackExpected = 1102
+	iCallbackExpected_UserAchievementIconFetched_t                  iCallbackExpected = 1109
+	iCallbackExpected_GlobalAchievementPercentagesReady_t           iCallbackExpected = 1110
+	iCallbackExpected_GlobalStatsReceived_t                         iCallbackExpected = 1112
+	iCallbackExpected_RemoteStorageFileShareResult_t                iCallbackExpected = 1307
+	iCallbackExpected_RemoteStorageDownloadUGCResult_t              iCallbackExpected = 1317
+	iCallbackExpected_RemoteStorageFileWriteAsyncComplete_t         iCallbackExpected = 1331
+	iCallbackExpected_RemoteStorageFileReadAsyncComplete_t          iCallbackExpected = 1332
+	iCallbackExpected_RemoteStorageSubscribePublishedFileResult_t   iCallbackExpected = 1313
+	iCallbackExpected_RemoteStorageUnsubscribePublishedFileResult_t iCallbackExpected = 1315
+	iCallbackExpected_SteamUGCQueryCompleted_t                      iCallbackExpected = 3401
+	iCallbackExpected_CreateItemResult_t                            iCallbackExpected = 3403
+	iCallbackExpected_SubmitItemUpdateResult_t                      iCallbackExpected = 3404
+	iCallbackExpected_ItemInstalled_t                               iCallbackExpected = 3405
+	iCallbackExpected_DownloadItemResult_t                          iCallbackExpected = 3406
+	iCallbackExpected_SteamAPICallCompleted_t                       iCallbackExpected = 703
+	iCallbackExpected_PersonaStateChange_t                          iCallbackExpected = 304
+	iCallbackExpected_GameOverlayActivated_t                        iCallbackExpected = 331
+	iCallbackExpected_AvatarImageLoaded_t                           iCallbackExpected = 334
+	iCallbackExpected_SteamShutdown_t                               iCallbackExpected = 704
+	iCallbackExpected_GamepadTextInputDismissed_t                   iCallbackExpected = 714
+	iCallbackExpected_DlcInstalled_t                                iCallbackExpected = 1005
+	iCallbackExpected_NewUrlLaunchParameters_t                      iCallbackExpected = 1014
+	iCallbackExpected_AppProofOfPurchaseKeyResponse_t               iCallbackExpected = 1021
 )
 
-type callbackClient struct {
-	timeout          time.Duration
-	interval         time.Duration
-	intervalTimer    *time.Timer
-	steamUtils       ISteamUtils
-	callbackArgsWait []*CallbackArgs
-	callbackArgsChan chan *CallbackArgs
-	closeSignal      chan bool
+// Dispatcher runs Steam callbacks and delivers the results of pending API calls.
+//
+// It pulls both through Steam's manual dispatch, which it enables once Steam is initialized.
+// A background goroutine starts automatically when the first call result is requested or a handler
+// is subscribed, and runs while either is waiting. Stop pauses it, and Shutdown stops it and shuts
+// the Steam API down until the next Init.
+//
+// After InitManualDispatch the dispatcher no longer starts on its own;
+// the game calls RunFrame from its main loop instead of SteamAPI_RunCallbacks.
+type Dispatcher struct {
+	timeout  time.Duration
+	interval time.Duration
+
+	mu       sync.Mutex
+	pending  []*CallbackArgs
+	stop     chan struct{}
+	stopped  chan struct{}
+	wake     chan struct{}
+	shutdown bool
+
+	// manual is set when the game runs frames. pipe is set once Steam's manual dispatch is enabled.
+	manual   bool
+	pipe     HSteamPipe
+	results  map[SteamAPICall_t]completedCall
+	handlers map[iCallbackExpected][]*callbackHandler
+
+	// frameMu serializes frames so that each pending call is polled by one goroutine at a time.
+	frameMu sync.Mutex
 }
+
 type CallbackArgs struct {
 	CallbackAPI      SteamAPICall_t
 	CallbackExpected iCallbackExpected
@@ -34,84 +80,518 @@ type CallbackArgs struct {
 	SuccessFunc      callbackSuccessFunc
 	TimeoutFunc      callbackTimeoutFunc
 
+	// FailureFunc is called when Steam reports the call as failed.
+	// If it is nil, TimeoutFunc is called instead.
+	FailureFunc callbackFailureFunc
+
+	// ErrorFunc is called when the call cannot be polled because calling into Steam fails,
+	// e.g. because the library is missing an export. If it is nil, FailureFunc is called with
+	// ESteamAPICallFailure_SteamGone.
+	ErrorFunc func(err error)
+
+	// Context bounds the wait. When it is done, the call is dropped and TimeoutFunc is called.
+	// If Context has a deadline, it replaces the client's default timeout.
+	Context context.Context
+
 	beginTime time.Time
 }
 
+type callbackHandler struct {
+	fn func(b []byte)
+}
+
+// completedCall is a call result pulled by manual dispatch that no pending call has claimed yet.
+type completedCall struct {
+	callback iCallbackExpected
+	data     []byte
+	failed   bool
+	time     time.Time
+}
+
 type callbackSuccessFunc func(ret []byte)
 type callbackTimeoutFunc func(callbackTime time.Time, callbackSpend time.Duration)
+type callbackFailureFunc func(reason ESteamAPICallFailure)
 
-var callbackCli *callbackClient
-var callbackOnce sync.Once
-
-func defaultCallbackCli() *callbackClient {
-	callbackOnce.Do(func() {
-		callbackCli = &callbackClient{
-			timeout:          timeout,
-			interval:         interval,
-			intervalTimer:    time.NewTimer(interval),
-			steamUtils:       SteamUtils(),
-			callbackArgsWait: make([]*CallbackArgs, 0),
-			callbackArgsChan: make(chan *CallbackArgs, 10),
-			closeSignal:      make(chan bool, 1),
+var defaultDispatcher *Dispatcher
+var defaultDispatcherOnce sync.Once
+
+// DefaultDispatcher returns the dispatcher used by the package's asynchronous APIs.
+func DefaultDispatcher() *Dispatcher {
+	defaultDispatcherOnce.Do(func() {
+		defaultDispatcher = &Dispatcher{
+			timeout:  timeout,
+			interval: interval,
+			wake:     make(chan struct{}, 1),
+			results:  map[SteamAPICall_t]completedCall{},
+			handlers: map[iCallbackExpected][]*callbackHandler{},
 		}
 	})
-	go callbackCli.run()
-	return callbackCli
+	return defaultDispatcher
+}
+
+// InitManualDispatch stops the background goroutine and leaves running frames to the game.
+// It must be called after Init and before any asynchronous API is used.
+//
+// In manual mode callbacks and call results are pulled only when RunFrame is called,
+// on the caller's goroutine, so the game's main loop stays in control of when they run.
+func (d *Dispatcher) InitManualDispatch() {
+	d.mu.Lock()
+	if d.manual {
+		d.mu.Unlock()
+		return
+	}
+	d.manual = true
+	_, err := d.initPipeLocked()
+	d.mu.Unlock()
+
+	d.Stop()
+	if err != nil {
+		handleError(err)
+	}
+}
+
+// initPipeLocked enables Steam's manual dispatch unless it already is.
+// It returns false if Steam is not initialized yet.
+func (d *Dispatcher) initPipeLocked() (bool, error) {
+	if d.pipe != 0 {
+		return true, nil
+	}
+	pipe, err := getHSteamPipe()
+	if err != nil || pipe == 0 {
+		return false, err
+	}
+	if err := manualDispatchInit(); err != nil {
+		return false, err
+	}
+	d.pipe = pipe
+	return true, nil
+}
+
+// Start starts polling in a background goroutine. Calling Start on a running dispatcher does nothing.
+func (d *Dispatcher) Start() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.startLocked()
+}
+
+func (d *Dispatcher) startLocked() {
+	if d.stop != nil || d.shutdown {
+		return
+	}
+	d.stop = make(chan struct{})
+	d.stopped = make(chan struct{})
+	go d.run(d.stop, d.stopped)
+}
+
+// Stop stops the background goroutine and waits for it to exit.
+// Pending calls are kept and resume polling on the next Start.
+func (d *Dispatcher) Stop() {
+	d.mu.Lock()
+	stop, stopped := d.stop, d.stopped
+	d.stop, d.stopped = nil, nil
+	d.mu.Unlock()
+
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-stopped
+}
+
+// Shutdown stops the dispatcher, calls the timeout or failure path of every pending call,
+// and shuts the Steam API down. New calls fail until Init succeeds again, which starts
+// the dispatcher afresh in automatic mode.
+func (d *Dispatcher) Shutdown() {
+	d.mu.Lock()
+	if d.shutdown {
+		d.mu.Unlock()
+		return
+	}
+	d.shutdown = true
+	d.mu.Unlock()
+
+	d.Stop()
+
+	// Wait for a frame running on another goroutine, which could put its waiting calls back.
+	// Later frames find the dispatcher shut down and do nothing.
+	d.frameMu.Lock()
+	d.mu.Lock()
+	pending := d.pending
+	d.pending = nil
+	clear(d.results)
+	d.mu.Unlock()
+	d.frameMu.Unlock()
+
+	for _, a := range pending {
+		a.fail(ESteamAPICallFailure_SteamGone, time.Since(a.beginTime))
+	}
+	shutdown()
+}
+
+// restart makes a dispatcher that was shut down usable again after Init.
+// Subscriptions are kept, but manual dispatch has to be initialized again.
+func (d *Dispatcher) restart() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.shutdown {
+		return
+	}
+	d.shutdown = false
+	d.manual = false
+	d.pipe = 0
+}
+
+// RunCallbacks runs a frame of the default dispatcher. The background goroutine makes calling it optional,
+// and games that call it from their main loop can switch to InitManualDispatch and RunFrame.
+//
+// It no longer calls SteamAPI_RunCallbacks: the dispatcher turns on Steam's manual dispatch, so callbacks
+// registered natively with Steam, outside this package, stop firing. Use Subscribe for them instead.
+func RunCallbacks() {
+	DefaultDispatcher().RunFrame()
+}
+
+// Shutdown shuts the Steam API down through the default dispatcher, so that its goroutine stops
+// and pending calls fail with ESteamAPICallFailure_SteamGone.
+func Shutdown() {
+	DefaultDispatcher().Shutdown()
+}
+
+// reset stops the dispatcher, calls swap while no frame can run, and starts over as if newly created.
+// The calls that were pending fail with ESteamAPICallFailure_SteamGone.
+func (d *Dispatcher) reset(swap func()) {
+	d.Stop()
+
+	d.frameMu.Lock()
+	swap()
+	d.mu.Lock()
+	pending := d.pending
+	d.pending = nil
+	d.shutdown = false
+	d.manual = false
+	d.pipe = 0
+	clear(d.results)
+	clear(d.handlers)
+	// A goroutine started meanwhile by a new call is stopped too.
+	stop, stopped := d.stop, d.stopped
+	d.stop, d.stopped = nil, nil
+	d.mu.Unlock()
+	d.frameMu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-stopped
+	}
+	for _, a := range pending {
+		a.fail(ESteamAPICallFailure_SteamGone, time.Since(a.beginTime))
+	}
 }
 
-func (c *callbackClient) setCallback(callbackArgs *CallbackArgs) {
+func (d *Dispatcher) setCallback(callbackArgs *CallbackArgs) {
 	callbackArgs.beginTime = time.Now()
-	c.callbackArgsChan <- callbackArgs
+
+	d.mu.Lock()
+	if d.shutdown {
+		d.mu.Unlock()
+		callbackArgs.fail(ESteamAPICallFailure_SteamGone, 0)
+		return
+	}
+	d.pending = append(d.pending, callbackArgs)
+	if !d.manual {
+		d.startLocked()
+	}
+	d.mu.Unlock()
+
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
 }
 
-func (c *callbackClient) run() {
-	defer close(c.callbackArgsChan)
-	defer close(c.closeSignal)
+func (d *Dispatcher) run(stop <-chan struct{}, stopped chan<- struct{}) {
+	defer close(stopped)
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
 	for {
-		<-c.intervalTimer.C
+		// Sleep until there is something to wait for, so that an idle game does not run callbacks for us.
+		d.mu.Lock()
+		idle := len(d.pending) == 0 && len(d.handlers) == 0 && !d.manual
+		d.mu.Unlock()
+		if idle {
+			select {
+			case <-stop:
+				return
+			case <-d.wake:
+			}
+		}
+
 		select {
-		case arg := <-c.callbackArgsChan:
-			c.callbackArgsWait = append(c.callbackArgsWait, arg)
-		default:
-			if len(c.callbackArgsWait) == 0 {
-				c.intervalTimer.Stop()
-				if !c.waitArgs() {
-					return
-				}
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		// Rather than report the error every frame, exit until the next call or subscription starts
+		// the goroutine again.
+		if err := d.runFrame(); err != nil {
+			handleError(err)
+			d.mu.Lock()
+			if d.stop == stop {
+				d.stop, d.stopped = nil, nil
 			}
-			RunCallbacks()
-			for i := 0; i < len(c.callbackArgsWait); {
-				a := c.callbackArgsWait[i]
-				spend := time.Since(a.beginTime)
-				if spend > c.timeout {
-					a.TimeoutFunc(a.beginTime, spend)
-					c.callbackArgsWait = append(c.callbackArgsWait[:i], c.callbackArgsWait[i+1:]...)
-					continue
-				}
-				call, ok, fail := c.steamUtils.GetAPICallResult(a.CallbackAPI, a.CallbackExpected, a.CallbaseSize)
-				if ok && !fail {
-					a.SuccessFunc(call)
-					c.callbackArgsWait = append(c.callbackArgsWait[:i], c.callbackArgsWait[i+1:]...)
-					continue
-				}
-				i++
+			d.mu.Unlock()
+			return
+		}
+	}
+}
+
+// RunFrame runs one frame of callbacks and delivers any call results that have completed.
+// It is called by the background goroutine, or by the game's main loop in manual mode.
+//
+// If calling into Steam fails, the pending calls fail with the error, and it is passed to handleError
+// (see SetErrorHandler).
+func (d *Dispatcher) RunFrame() {
+	if err := d.runFrame(); err != nil {
+		handleError(err)
+	}
+}
+
+func (d *Dispatcher) runFrame() error {
+	d.frameMu.Lock()
+	defer d.frameMu.Unlock()
+
+	d.mu.Lock()
+	if d.shutdown {
+		d.mu.Unlock()
+		return nil
+	}
+	ready, err := d.initPipeLocked()
+	pipe := d.pipe
+	d.mu.Unlock()
+
+	// Until Steam is initialized there is nothing to pull, but pending calls can still time out.
+	if ready {
+		err = d.pullCallbacks(pipe)
+	}
+
+	d.mu.Lock()
+	pending := d.pending
+	d.pending = nil
+	d.mu.Unlock()
+
+	if err != nil {
+		abortAll(pending, err)
+		return err
+	}
+	if len(pending) == 0 {
+		d.dropStaleResults()
+		return nil
+	}
+
+	steamUtils, err := getSteamUtils()
+	if err != nil {
+		abortAll(pending, err)
+		return err
+	}
+	var frameErr error
+	var waiting []*CallbackArgs
+	for _, a := range pending {
+		spend := time.Since(a.beginTime)
+		if a.expired(spend, d.timeout) {
+			a.TimeoutFunc(a.beginTime, spend)
+			continue
+		}
+		call, ok, fail := d.takeResult(a)
+		if fail {
+			reason, err := steamUtils.apiCallFailureReason(a.CallbackAPI)
+			if err != nil {
+				a.abort(err)
+				frameErr = err
+				continue
 			}
+			a.fail(reason, spend)
+			continue
+		}
+		if ok {
+			a.SuccessFunc(call)
+			continue
 		}
-		c.intervalTimer.Reset(c.interval)
+		waiting = append(waiting, a)
 	}
 
+	// Callbacks may have queued follow-up calls in the meantime.
+	d.mu.Lock()
+	d.pending = append(waiting, d.pending...)
+	d.mu.Unlock()
+
+	d.dropStaleResults()
+	return frameErr
 }
 
-func (c *callbackClient) waitArgs() bool {
+// pullCallbacks drains the manual dispatch queue of pipe. Completed call results are kept
+// until a pending call claims them, and every other callback goes to the registered handlers.
+func (d *Dispatcher) pullCallbacks(pipe HSteamPipe) error {
+	if err := manualDispatchRunFrame(pipe); err != nil {
+		return err
+	}
+	for {
+		msg, ok, err := manualDispatchGetNextCallback(pipe)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		if msg.ID == iCallbackExpected_SteamAPICallCompleted_t {
+			completed := SteamAPICallCompleted_t{}.FromByte(msg.Param)
+			data, ok, failed, err := manualDispatchGetAPICallResult(pipe, completed.AsyncCall, completed.Callback, int(completed.ParamSize))
+			if err != nil {
+				return err
+			}
+			d.mu.Lock()
+			d.results[completed.AsyncCall] = completedCall{
+				callback: completed.Callback,
+				data:     data,
+				failed:   !ok || failed,
+				time:     time.Now(),
+			}
+			d.mu.Unlock()
+		} else {
+			d.dispatch(msg.ID, msg.Param)
+		}
+		if err := manualDispatchFreeLastCallback(pipe); err != nil {
+			return err
+		}
+	}
+}
+
+// takeResult returns the result pulled for a, if it has arrived.
+// The failure reason of a failed call is still available from ISteamUtils.
+func (d *Dispatcher) takeResult(a *CallbackArgs) (call []byte, success bool, pbFailed bool) {
+	d.mu.Lock()
+	r, ok := d.results[a.CallbackAPI]
+	delete(d.results, a.CallbackAPI)
+	d.mu.Unlock()
+
+	if !ok {
+		return nil, false, false
+	}
+	if r.failed || r.callback != a.CallbackExpected {
+		return nil, true, true
+	}
+	if len(r.data) < a.CallbaseSize {
+		r.data = append(r.data, make([]byte, a.CallbaseSize-len(r.data))...)
+	}
+	return r.data, true, false
+}
+
+// dropStaleResults forgets results nobody has asked for within the timeout.
+func (d *Dispatcher) dropStaleResults() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for call, r := range d.results {
+		if time.Since(r.time) > d.timeout {
+			delete(d.results, call)
+		}
+	}
+}
+
+// Subscribe calls handler with every broadcast callback of type T, e.g. GameOverlayActivated_t.
+// The returned function unsubscribes it.
+//
+// Handlers run on the dispatcher's background goroutine, or in manual dispatch mode
+// (see Dispatcher.InitManualDispatch) on the goroutine that calls RunFrame.
+//
+//	unsubscribe := steamworks.Subscribe(func(ev steamworks.GameOverlayActivated_t) {
+//		paused = ev.Active
+//	})
+func Subscribe[T Callback](handler func(T)) (unsubscribe func()) {
+	var zero T
+	size := int(zero.Size())
+	return DefaultDispatcher().handle(zero.callbackExpected(), func(b []byte) {
+		if len(b) < size {
+			b = append(b, make([]byte, size-len(b))...)
+		}
+		handler(zero.decode(b).(T))
+	})
+}
+
+// handle registers fn for the callback id. The returned function removes it again.
+func (d *Dispatcher) handle(id iCallbackExpected, fn func(b []byte)) (remove func()) {
+	h := &callbackHandler{fn: fn}
+
+	d.mu.Lock()
+	d.handlers[id] = append(d.handlers[id], h)
+	if !d.manual {
+		d.startLocked()
+	}
+	d.mu.Unlock()
+
 	select {
-	case arg := <-c.callbackArgsChan:
-		c.callbackArgsWait = append(c.callbackArgsWait, arg)
-		return true
-	case <-c.closeSignal:
-		return false
+	case d.wake <- struct{}{}:
+	default:
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			hs := d.handlers[id]
+			for i, v := range hs {
+				if v == h {
+					d.handlers[id] = append(hs[:i:i], hs[i+1:]...)
+					break
+				}
+			}
+			if len(d.handlers[id]) == 0 {
+				delete(d.handlers, id)
+			}
+		})
 	}
 }
 
-func (c *callbackClient) close() {
-	c.closeSignal <- true
+func (d *Dispatcher) dispatch(id iCallbackExpected, b []byte) {
+	d.mu.Lock()
+	hs := d.handlers[id]
+	d.mu.Unlock()
+
+	for _, h := range hs {
+		h.fn(b)
+	}
+}
+
+func (a *CallbackArgs) expired(spend, timeout time.Duration) bool {
+	if a.Context != nil {
+		if a.Context.Err() != nil {
+			return true
+		}
+		if _, ok := a.Context.Deadline(); ok {
+			return false
+		}
+	}
+	return spend > timeout
+}
+
+func (a *CallbackArgs) fail(reason ESteamAPICallFailure, spend time.Duration) {
+	if a.FailureFunc != nil {
+		a.FailureFunc(reason)
+		return
+	}
+	a.TimeoutFunc(a.beginTime, spend)
+}
+
+// abort ends a with an error calling into Steam.
+func (a *CallbackArgs) abort(err error) {
+	if a.ErrorFunc != nil {
+		a.ErrorFunc(err)
+		return
+	}
+	a.fail(ESteamAPICallFailure_SteamGone, time.Since(a.beginTime))
+}
+
+func abortAll(pending []*CallbackArgs, err error) {
+	for _, a := range pending {
+		a.abort(err)
+	}
 }
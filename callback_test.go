@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TaiJiYu/go-steamworks"
+)
+
+func TestShutdown(t *testing.T) {
+	fake := steamworks.NewFake()
+	fake.Latency = time.Hour
+	startFake(t, fake)
+
+	c := steamworks.NewCallResult[steamworks.UserStatsReceived_t](context.Background(), steamworks.SteamUserStats().RequestCurrentStats())
+	steamworks.Shutdown()
+	receive(t, c.Done())
+	var callErr *steamworks.CallResultError
+	if _, err := c.Result(); !errors.As(err, &callErr) || callErr.Reason != steamworks.ESteamAPICallFailure_SteamGone {
+		t.Fatalf("err = %v, want Steam gone", err)
+	}
+}
+
+func TestShutdownAndInit(t *testing.T) {
+	startFake(t, steamworks.NewFake())
+
+	for i := 0; i < 2; i++ {
+		if _, err := steamworks.Await[steamworks.UserStatsReceived_t](context.Background(), steamworks.SteamUserStats().RequestCurrentStats()); err != nil {
+			t.Fatal(i, err)
+		}
+		steamworks.Shutdown()
+		if err := steamworks.Init(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestManualDispatch(t *testing.T) {
+	fake := steamworks.NewFake()
+	startFake(t, fake)
+	d := steamworks.DefaultDispatcher()
+	d.InitManualDispatch()
+
+	var got []steamworks.DlcInstalled_t
+	defer steamworks.Subscribe(func(ev steamworks.DlcInstalled_t) { got = append(got, ev) })()
+	c := steamworks.NewCallResult[steamworks.UserStatsReceived_t](context.Background(), steamworks.SteamUserStats().RequestCurrentStats())
+	fake.InstallDLC(99)
+	time.Sleep(10 * time.Millisecond)
+	select {
+	case <-c.Done():
+		t.Fatal("call result completed without RunFrame")
+	default:
+	}
+
+	d.RunFrame()
+	select {
+	case <-c.Done():
+	default:
+		t.Fatal("call result still pending after RunFrame")
+	}
+	if _, err := c.Result(); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].AppID != 99 {
+		t.Errorf("got %+v, want DLC 99 installed", got)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	fake := steamworks.NewFake()
+	fake.OverlayEnabled = true
+	startFake(t, fake)
+
+	got := make(chan steamworks.GameOverlayActivated_t, 1)
+	unsubscribe := steamworks.Subscribe(func(ev steamworks.GameOverlayActivated_t) { got <- ev })
+	steamworks.SteamFriends().ActivateGameOverlay(steamworks.OverlayDialog_Achievements)
+	if ev := receive(t, got); !ev.Active {
+		t.Errorf("GameOverlayActivated_t = %+v, want active", ev)
+	}
+
+	unsubscribe()
+	fake.Post(steamworks.GameOverlayActivated_t{})
+	steamworks.RunCallbacks()
+	select {
+	case ev := <-got:
+		t.Errorf("got %+v after unsubscribing", ev)
+	default:
+	}
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Callback is implemented by the callback structs in struct.go, e.g. LeaderboardFindResult_t.
+type Callback interface {
+	Size() uintptr
+	callbackExpected() iCallbackExpected
+	decode(b []byte) Callback
+}
+
+// ErrCallResultTimeout is returned when a call result does not arrive within the default timeout
+// and the context has no deadline of its own.
+var ErrCallResultTimeout = errors.New("steamworks: call result timed out")
+
+// CallResultError is returned when Steam reports an asynchronous call as failed.
+type CallResultError struct {
+	Call   SteamAPICall_t
+	Reason ESteamAPICallFailure
+}
+
+func (e *CallResultError) Error() string {
+	return fmt.Sprintf("steamworks: call %d failed: %s", e.Call, e.Reason)
+}
+
+// CallResult is the pending result of an asynchronous Steam API call.
+type CallResult[T Callback] struct {
+	call SteamAPICall_t
+	done chan struct{}
+	once sync.Once
+	stop func() bool
+
+	result T
+	err    error
+}
+
+// NewCallResult starts waiting for the result of call.
+// The wait ends when the result arrives, Steam reports a failure, or ctx is done.
+func NewCallResult[T Callback](ctx context.Context, call SteamAPICall_t) *CallResult[T] {
+	c := &CallResult[T]{
+		call: call,
+		done: make(chan struct{}),
+	}
+	if call == 0 {
+		c.complete(*new(T), &CallResultError{Call: call, Reason: ESteamAPICallFailure_InvalidHandle})
+		return c
+	}
+	if err := ctx.Err(); err != nil {
+		c.complete(*new(T), err)
+		return c
+	}
+	c.stop = context.AfterFunc(ctx, func() {
+		c.complete(*new(T), ctx.Err())
+	})
+
+	var zero T
+	DefaultDispatcher().setCallback(&CallbackArgs{
+		CallbackAPI:      call,
+		CallbackExpected: zero.callbackExpected(),
+		CallbaseSize:     int(zero.Size()),
+		Context:          ctx,
+		SuccessFunc: func(ret []byte) {
+			c.stop()
+			c.complete(zero.decode(ret).(T), nil)
+		},
+		FailureFunc: func(reason ESteamAPICallFailure) {
+			c.stop()
+			c.complete(zero, &CallResultError{Call: call, Reason: reason})
+		},
+		ErrorFunc: func(err error) {
+			c.stop()
+			c.complete(zero, err)
+		},
+		TimeoutFunc: func(callbackTime time.Time, callbackSpend time.Duration) {
+			c.stop()
+			if err := ctx.Err(); err != nil {
+				c.complete(zero, err)
+				return
+			}
+			c.complete(zero, ErrCallResultTimeout)
+		},
+	})
+	return c
+}
+
+func (c *CallResult[T]) complete(result T, err error) {
+	c.once.Do(func() {
+		c.result = result
+		c.err = err
+		close(c.done)
+	})
+}
+
+// Call returns the API call handle being waited for.
+func (c *CallResult[T]) Call() SteamAPICall_t {
+	return c.call
+}
+
+// Done returns a channel that is closed once the result is available.
+func (c *CallResult[T]) Done() <-chan struct{} {
+	return c.done
+}
+
+// Result blocks until the call completes and returns its result.
+func (c *CallResult[T]) Result() (T, error) {
+	<-c.done
+	return c.result, c.err
+}
+
+// Await waits for the result of call and decodes it as T.
+//
+//	found, err := steamworks.Await[steamworks.LeaderboardFindResult_t](ctx, call)
+func Await[T Callback](ctx context.Context, call SteamAPICall_t) (T, error) {
+	return NewCallResult[T](ctx, call).Result()
+}
@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TaiJiYu/go-steamworks"
+)
+
+func TestAwait(t *testing.T) {
+	fake := steamworks.NewFake()
+	fake.Latency = 10 * time.Millisecond
+	startFake(t, fake)
+
+	got, err := steamworks.Await[steamworks.UserStatsReceived_t](context.Background(), steamworks.SteamUserStats().RequestCurrentStats())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.GameID != int(fake.AppID) || got.SteamID != fake.SteamID || got.Result != steamworks.EResult_OK {
+		t.Errorf("UserStatsReceived_t = %+v", got)
+	}
+}
+
+func TestAwaitFailure(t *testing.T) {
+	fake := steamworks.NewFake()
+	fake.FailCallResults("SteamAPI_ISteamUserStats_RequestUserStats", steamworks.ESteamAPICallFailure_NetworkFailure)
+	startFake(t, fake)
+
+	_, err := steamworks.Await[steamworks.UserStatsReceived_t](context.Background(), steamworks.SteamUserStats().RequestCurrentStats())
+	var callErr *steamworks.CallResultError
+	if !errors.As(err, &callErr) || callErr.Reason != steamworks.ESteamAPICallFailure_NetworkFailure {
+		t.Fatalf("err = %v, want a network failure", err)
+	}
+}
+
+func TestAwaitContext(t *testing.T) {
+	fake := steamworks.NewFake()
+	fake.Latency = time.Hour
+	startFake(t, fake)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := steamworks.Await[steamworks.UserStatsReceived_t](ctx, steamworks.SteamUserStats().RequestCurrentStats())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
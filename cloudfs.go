@@ -0,0 +1,271 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CloudFS is the user's Steam Cloud files as a file system.
+//
+// Steam Cloud has no directories, but file names may contain slashes. CloudFS presents the parts before
+// the last slash as directories, which exist as long as a file is in them.
+//
+//	var save Save
+//	f, err := steamworks.NewCloudFS().Open("saves/slot1.json")
+//	if err == nil {
+//		defer f.Close()
+//		err = json.NewDecoder(f).Decode(&save)
+//	}
+type CloudFS struct {
+	rs ISteamRemoteStorage
+}
+
+var (
+	_ fs.ReadDirFS  = (*CloudFS)(nil)
+	_ fs.ReadFileFS = (*CloudFS)(nil)
+	_ fs.StatFS     = (*CloudFS)(nil)
+)
+
+// NewCloudFS returns the Steam Cloud of the current user. Init must have succeeded.
+func NewCloudFS() *CloudFS {
+	return &CloudFS{rs: SteamRemoteStorage()}
+}
+
+// Open opens the file or directory name for reading. A file's content is read when it is opened.
+func (c *CloudFS) Open(name string) (fs.File, error) {
+	info, err := c.stat("open", name)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		entries, err := c.readDir("open", name)
+		if err != nil {
+			return nil, err
+		}
+		return &cloudDir{info: info, entries: entries}, nil
+	}
+	data, err := c.readFile("open", name, info.size)
+	if err != nil {
+		return nil, err
+	}
+	return &cloudFile{info: info, r: bytes.NewReader(data)}, nil
+}
+
+// ReadFile returns the content of the file name.
+func (c *CloudFS) ReadFile(name string) ([]byte, error) {
+	info, err := c.stat("readfile", name)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: errors.New("is a directory")}
+	}
+	return c.readFile("readfile", name, info.size)
+}
+
+// ReadDir returns the files and directories in the directory name, sorted by name.
+func (c *CloudFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return c.readDir("readdir", name)
+}
+
+// Stat returns the size and timestamp of the file name.
+func (c *CloudFS) Stat(name string) (fs.FileInfo, error) {
+	info, err := c.stat("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// WriteFile writes data to the file name, replacing it if it exists.
+func (c *CloudFS) WriteFile(name string, data []byte) error {
+	if !fs.ValidPath(name) || name == "." {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrInvalid}
+	}
+	if !c.rs.FileWrite(name, data) {
+		return &fs.PathError{Op: "write", Path: name, Err: errors.New("steamworks: FileWrite failed")}
+	}
+	return nil
+}
+
+// Create returns a stream that replaces the file name when it is closed.
+// Until then, the file keeps its previous content.
+func (c *CloudFS) Create(name string) (*FileWriteStream, error) {
+	if !fs.ValidPath(name) || name == "." {
+		return nil, &fs.PathError{Op: "create", Path: name, Err: fs.ErrInvalid}
+	}
+	return c.rs.OpenFileWriteStream(name)
+}
+
+// Remove deletes the file name from Steam Cloud and from this computer.
+func (c *CloudFS) Remove(name string) error {
+	if !fs.ValidPath(name) {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrInvalid}
+	}
+	if !c.rs.FileExists(name) {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	if !c.rs.FileDelete(name) {
+		return &fs.PathError{Op: "remove", Path: name, Err: errors.New("steamworks: FileDelete failed")}
+	}
+	return nil
+}
+
+// files returns the names and sizes of all the files.
+func (c *CloudFS) files() map[string]int64 {
+	files := map[string]int64{}
+	n := c.rs.GetFileCount()
+	for i := int32(0); i < n; i++ {
+		name, size := c.rs.GetFileNameAndSize(i)
+		if name == "" {
+			continue
+		}
+		files[name] = int64(size)
+	}
+	return files
+}
+
+func (c *CloudFS) stat(op, name string) (*cloudFileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	if name == "." {
+		return &cloudFileInfo{name: ".", dir: true}, nil
+	}
+	if c.rs.FileExists(name) {
+		return &cloudFileInfo{
+			name:    path.Base(name),
+			size:    int64(c.rs.GetFileSize(name)),
+			modTime: c.rs.GetFileTimestamp(name),
+		}, nil
+	}
+	prefix := name + "/"
+	for file := range c.files() {
+		if strings.HasPrefix(file, prefix) {
+			return &cloudFileInfo{name: path.Base(name), dir: true}, nil
+		}
+	}
+	return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+}
+
+func (c *CloudFS) readFile(op, name string, size int64) ([]byte, error) {
+	data := make([]byte, size)
+	if size == 0 {
+		return data, nil
+	}
+	if n := c.rs.FileRead(name, data); int64(n) != size {
+		return nil, &fs.PathError{Op: op, Path: name, Err: errors.New("steamworks: FileRead failed")}
+	}
+	return data, nil
+}
+
+func (c *CloudFS) readDir(op, name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	prefix := ""
+	if name != "." {
+		prefix = name + "/"
+	}
+
+	children := map[string]*cloudFileInfo{}
+	for file, size := range c.files() {
+		if !strings.HasPrefix(file, prefix) || !fs.ValidPath(file) {
+			continue
+		}
+		rest := strings.TrimPrefix(file, prefix)
+		if i := strings.IndexByte(rest, '/'); i >= 0 {
+			children[rest[:i]] = &cloudFileInfo{name: rest[:i], dir: true}
+			continue
+		}
+		children[rest] = &cloudFileInfo{name: rest, size: size, modTime: c.rs.GetFileTimestamp(file)}
+	}
+	if len(children) == 0 && name != "." {
+		if c.rs.FileExists(name) {
+			return nil, &fs.PathError{Op: op, Path: name, Err: errors.New("not a directory")}
+		}
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+
+	entries := make([]fs.DirEntry, 0, len(children))
+	for _, info := range children {
+		entries = append(entries, fs.FileInfoToDirEntry(info))
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+	return entries, nil
+}
+
+type cloudFileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	dir     bool
+}
+
+func (i *cloudFileInfo) Name() string       { return i.name }
+func (i *cloudFileInfo) Size() int64        { return i.size }
+func (i *cloudFileInfo) ModTime() time.Time { return i.modTime }
+func (i *cloudFileInfo) IsDir() bool        { return i.dir }
+func (i *cloudFileInfo) Sys() any           { return nil }
+
+func (i *cloudFileInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0o555
+	}
+	return 0o444
+}
+
+type cloudFile struct {
+	info *cloudFileInfo
+	r    *bytes.Reader
+}
+
+func (f *cloudFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *cloudFile) Read(b []byte) (int, error) { return f.r.Read(b) }
+func (f *cloudFile) Close() error               { return nil }
+
+func (f *cloudFile) ReadAt(b []byte, off int64) (int, error) {
+	return f.r.ReadAt(b, off)
+}
+
+func (f *cloudFile) Seek(offset int64, whence int) (int64, error) {
+	return f.r.Seek(offset, whence)
+}
+
+type cloudDir struct {
+	info    *cloudFileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *cloudDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *cloudDir) Close() error               { return nil }
+
+func (d *cloudDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: errors.New("is a directory")}
+}
+
+func (d *cloudDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return rest, nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	n = min(n, len(rest))
+	d.offset += n
+	return rest[:n], nil
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks_test
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/TaiJiYu/go-steamworks"
+)
+
+func TestCloudFS(t *testing.T) {
+	fake := steamworks.NewFake()
+	fake.CloudEnabledForAccount = true
+	startFake(t, fake)
+	cloud := steamworks.NewCloudFS()
+
+	files := map[string]string{
+		"settings.ini":           "volume=3",
+		"saves/slot1.json":       `{"level":1}`,
+		"saves/slot2.json":       `{"level":2}`,
+		"saves/replays/1.replay": "replay",
+	}
+	for name, data := range files {
+		if err := cloud.WriteFile(name, []byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := fstest.TestFS(cloud, "settings.ini", "saves/slot1.json", "saves/slot2.json", "saves/replays/1.replay"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := fs.ReadFile(cloud, "saves/replays/1.replay")
+	if err != nil || string(data) != "replay" {
+		t.Errorf("ReadFile() = %q, %v", data, err)
+	}
+	if err := cloud.Remove("saves/replays/1.replay"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fs.Stat(cloud, "saves/replays"); err == nil {
+		t.Error("saves/replays still exists without files in it")
+	}
+}
@@ -0,0 +1,2152 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"io/fs"
+	"maps"
+	"math"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// Fake is a Backend that simulates a Steam client in process, so that code using this package
+// can run without Steam, e.g. in tests on a CI machine.
+//
+// It keeps stats, achievements, leaderboards, Steam Cloud files, Workshop items and DLC in memory
+// and completes call results after Latency. Install it with SetBackend:
+//
+//	fake := steamworks.NewFake()
+//	fake.SetStat("NumGames", 3)
+//	steamworks.SetBackend(fake)
+//	defer steamworks.SetBackend(nil)
+//
+// The exported fields must not be changed once the fake is installed.
+type Fake struct {
+	AppID       AppId_t
+	SteamID     CSteamID
+	PersonaName string
+	Language    string
+	InstallDir  string
+	SteamDeck   bool
+
+	// UILanguage is the Steam client's language. Empty means Language.
+	UILanguage string
+
+	// OverlayEnabled is whether the overlay is turned on. Without it the ActivateGameOverlay functions do nothing.
+	OverlayEnabled bool
+
+	// AvailableLanguages are the API language codes the app supports. Without any, only Language is.
+	AvailableLanguages []string
+	// BetaName is the beta branch the app runs on. Empty means the default branch.
+	BetaName string
+	BuildID  int32
+	// Owner is the owner of the app under Family Sharing. Zero means the user owns it.
+	Owner CSteamID
+	// PurchaseTime is when the user bought the app.
+	PurchaseTime time.Time
+	// TrialAllowed is the length of a timed trial, and TrialPlayed how long it has been played.
+	// The app is not a timed trial if TrialAllowed is zero.
+	TrialAllowed, TrialPlayed time.Duration
+
+	LowViolence bool
+	Cybercafe   bool
+	VACBanned   bool
+	FreeWeekend bool
+
+	// InitResult is returned by Init. Anything other than ESteamAPIInitResult_OK makes Init fail.
+	InitResult ESteamAPIInitResult
+
+	// Latency is how long call results take to complete.
+	Latency time.Duration
+
+	// CloudEnabledForAccount is whether the user has Steam Cloud turned on.
+	CloudEnabledForAccount bool
+	// CloudQuota is the user's Steam Cloud space in bytes. Writes that do not fit fail.
+	CloudQuota uint64
+
+	mu sync.Mutex
+
+	errs         map[string]error
+	callFailures map[string]ESteamAPICallFailure
+
+	nextCall SteamAPICall_t
+	calls    map[SteamAPICall_t]*fakeCall
+	failures map[SteamAPICall_t]ESteamAPICallFailure
+
+	queue   []callbackMsg
+	current *callbackMsg
+
+	stats        map[string]int32
+	floatStats   map[string]float32
+	avgRates     map[string][2]float64
+	globalStats  map[string]int64
+	achievements []*fakeAchievement
+
+	leaderboards    []*fakeLeaderboard
+	nextEntries     SteamLeaderboardEntries_t
+	downloadEntries map[SteamLeaderboardEntries_t][]fakeEntry
+
+	files        map[string]*fakeFile
+	cloudForApp  bool
+	ugcs         map[UGCHandle_t]*fakeUGC
+	streams      map[UGCFileWriteStreamHandle_t]*fakeStream
+	nextStream   UGCFileWriteStreamHandle_t
+	asyncReads   map[SteamAPICall_t][]byte
+	inBatch      bool
+	dlcs         []*fakeDLC
+	richPresence map[string]string
+	friends      []*fakeFriend
+	overlay      string
+	launchQuery  map[string]string
+	launchLine   string
+
+	workshop   []*fakeWorkshopItem
+	ugcQueries map[UGCQueryHandle_t]*fakeUGCQuery
+	nextQuery  UGCQueryHandle_t
+	ugcUpdates map[UGCUpdateHandle_t]*fakeItemUpdate
+	nextUpdate UGCUpdateHandle_t
+
+	images   []image.Image
+	cStrings map[string][]byte
+}
+
+type fakeCall struct {
+	id        iCallbackExpected
+	data      []byte
+	ready     time.Time
+	failure   ESteamAPICallFailure
+	announced bool
+}
+
+// FakeAchievement describes an achievement of the fake's app.
+type FakeAchievement struct {
+	APIName     string
+	Name        string
+	Description string
+	Hidden      bool
+
+	// Percent is the share of players who have the achievement.
+	Percent float32
+
+	// MinProgress and MaxProgress are the progress limits of an achievement unlocked by a stat.
+	MinProgress, MaxProgress int32
+
+	// Icon is returned through GetAchievementIcon. Without one the achievement has no icon.
+	Icon image.Image
+}
+
+type fakeAchievement struct {
+	FakeAchievement
+	achieved   bool
+	unlockTime time.Time
+	icon       int32
+}
+
+type fakeLeaderboard struct {
+	handle      SteamLeaderboard_t
+	name        string
+	sortMethod  ELeaderboardSortMethod
+	displayType ELeaderboardDisplayType
+	entries     []fakeEntry
+}
+
+type fakeEntry struct {
+	steamID CSteamID
+	rank    int
+	score   int32
+	details []int32
+	ugc     UGCHandle_t
+}
+
+type fakeFile struct {
+	data      []byte
+	modTime   time.Time
+	persisted bool
+	platforms ERemoteStoragePlatform
+}
+
+type fakeStream struct {
+	name string
+	data []byte
+}
+
+type fakeUGC struct {
+	name  string
+	data  []byte
+	owner CSteamID
+}
+
+// FakeWorkshopItem describes a Workshop item of the fake's app.
+type FakeWorkshopItem struct {
+	// ID is assigned by AddWorkshopItem if it is 0.
+	ID          PublishedFileId_t
+	Title       string
+	Description string
+	Owner       CSteamID
+	Tags        []string
+	Visibility  ERemoteStoragePublishedFileVisibility
+	Metadata    string
+	PreviewURL  string
+	VotesUp     uint32
+	VotesDown   uint32
+	// Created and Updated default to the time the item is added.
+	Created time.Time
+	Updated time.Time
+	// Size is the size in bytes of the item's content once installed.
+	Size uint64
+	// Content and Preview are the content folder and preview file of the last update.
+	Content string
+	Preview string
+}
+
+type fakeWorkshopItem struct {
+	FakeWorkshopItem
+	subscribed    bool
+	subscriptions uint64
+	installed     bool
+}
+
+type fakeUGCQuery struct {
+	user            AccountID_t
+	list            EUserUGCList
+	ids             []PublishedFileId_t
+	page            uint32
+	required        []string
+	excluded        []string
+	matchAnyTag     bool
+	searchText      string
+	longDescription bool
+	results         []*fakeWorkshopItem
+}
+
+type fakeItemUpdate struct {
+	id      PublishedFileId_t
+	changes []func(item *fakeWorkshopItem)
+	content string
+	call    SteamAPICall_t
+	size    uint64
+}
+
+// FakeFriend describes a user the fake's user has a relationship with.
+type FakeFriend struct {
+	SteamID CSteamID
+	Name    string
+	State   EPersonaState
+	// Relationship is EFriendRelationship_Friend if it is zero.
+	Relationship EFriendRelationship
+	// Game is the game the friend is playing. A zero GameID means they are not in a game.
+	Game FriendGameInfo_t
+	// Avatar is returned through the avatar functions. Without one the friend has no avatar.
+	Avatar image.Image
+}
+
+type fakeFriend struct {
+	FakeFriend
+	avatar int32
+}
+
+type fakeDLC struct {
+	appID     AppId_t
+	name      string
+	owned     bool
+	installed bool
+	// download is when InstallDLC started downloading the DLC, or zero.
+	download time.Time
+}
+
+// fakeDLCSize is the download size of every DLC of the fake.
+const fakeDLCSize = 64 << 20
+
+// callbackEncoder is implemented by the callback structs the fake can hand out.
+type callbackEncoder interface {
+	Callback
+	encode() []byte
+}
+
+// fakeInterface is the handle the fake returns for every ISteam* interface.
+const fakeInterface = 1
+
+// NewFake returns a fake Steam client for Spacewar (app 480) with no stats, files or DLC.
+func NewFake() *Fake {
+	return &Fake{
+		AppID:       480,
+		SteamID:     76561197960265729,
+		PersonaName: "Player",
+		Language:    "english",
+		InitResult:  ESteamAPIInitResult_OK,
+
+		CloudEnabledForAccount: true,
+		OverlayEnabled:         true,
+		CloudQuota:             100 << 20,
+
+		errs:            map[string]error{},
+		callFailures:    map[string]ESteamAPICallFailure{},
+		calls:           map[SteamAPICall_t]*fakeCall{},
+		failures:        map[SteamAPICall_t]ESteamAPICallFailure{},
+		stats:           map[string]int32{},
+		floatStats:      map[string]float32{},
+		avgRates:        map[string][2]float64{},
+		globalStats:     map[string]int64{},
+		downloadEntries: map[SteamLeaderboardEntries_t][]fakeEntry{},
+		files:           map[string]*fakeFile{},
+		cloudForApp:     true,
+		ugcs:            map[UGCHandle_t]*fakeUGC{},
+		streams:         map[UGCFileWriteStreamHandle_t]*fakeStream{},
+		asyncReads:      map[SteamAPICall_t][]byte{},
+		richPresence:    map[string]string{},
+		launchQuery:     map[string]string{},
+		ugcQueries:      map[UGCQueryHandle_t]*fakeUGCQuery{},
+		ugcUpdates:      map[UGCUpdateHandle_t]*fakeItemUpdate{},
+		cStrings:        map[string][]byte{},
+	}
+}
+
+// FailCalls makes every call of the flat API function name return err, as a missing export would.
+// A nil err clears it.
+func (f *Fake) FailCalls(name string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err == nil {
+		delete(f.errs, name)
+		return
+	}
+	f.errs[name] = err
+}
+
+// FailCallResults makes the call results started by the flat API function name fail with reason,
+// e.g. ESteamAPICallFailure_NetworkFailure. ESteamAPICallFailure_None clears it.
+func (f *Fake) FailCallResults(name string, reason ESteamAPICallFailure) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if reason == ESteamAPICallFailure_None {
+		delete(f.callFailures, name)
+		return
+	}
+	f.callFailures[name] = reason
+}
+
+// Stat returns the value of the user's stat name.
+func (f *Fake) Stat(name string) (int32, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	v, ok := f.stats[name]
+	return v, ok
+}
+
+// SetStat sets the user's stat name.
+func (f *Fake) SetStat(name string, value int32) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stats[name] = value
+}
+
+// StatFloat returns the value of the user's float stat name.
+func (f *Fake) StatFloat(name string) (float32, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	v, ok := f.floatStats[name]
+	return v, ok
+}
+
+// SetStatFloat sets the user's float stat name.
+func (f *Fake) SetStatFloat(name string, value float32) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.floatStats[name] = value
+}
+
+// SetGlobalStat sets the global stat name.
+func (f *Fake) SetGlobalStat(name string, value int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.globalStats[name] = value
+}
+
+// DefineAchievement adds an achievement the user does not have yet, or updates its description.
+func (f *Fake) DefineAchievement(a FakeAchievement) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fa := f.defineAchievement(a.APIName)
+	fa.FakeAchievement = a
+	fa.icon = 0
+	if a.Icon != nil {
+		fa.icon = f.addImage(a.Icon)
+	}
+}
+
+// Achievement reports whether the user has achievement name.
+func (f *Fake) Achievement(name string) (achieved, ok bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	a := f.achievement(name)
+	if a == nil {
+		return false, false
+	}
+	return a.achieved, true
+}
+
+// SetAchievement defines achievement name if needed and sets whether the user has it.
+func (f *Fake) SetAchievement(name string, achieved bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.defineAchievement(name).set(achieved)
+}
+
+// SetLeaderboardScore sets the score of user on the leaderboard name, creating a descending numeric leaderboard if needed.
+func (f *Fake) SetLeaderboardScore(name string, user CSteamID, score int32, details ...int32) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	l := f.leaderboard(name)
+	if l == nil {
+		l = f.createLeaderboard(name, ELeaderboardSortMethod_Descending, ELeaderboardDisplayType_Numeric)
+	}
+	l.set(user, score, details)
+}
+
+// AddUGC shares a file of owner, as FileShare would, and returns its handle.
+func (f *Fake) AddUGC(name string, data []byte, owner CSteamID) UGCHandle_t {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.addUGC(name, data, owner)
+}
+
+// SetLeaderboardUGC attaches ugc to the entry of user on the leaderboard name, if it has one.
+func (f *Fake) SetLeaderboardUGC(name string, user CSteamID, ugc UGCHandle_t) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if l := f.leaderboard(name); l != nil {
+		if i := l.indexOf(user); i >= 0 {
+			l.entries[i].ugc = ugc
+		}
+	}
+}
+
+// File returns the content of the Steam Cloud file name.
+func (f *Fake) File(name string) ([]byte, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	file, ok := f.files[name]
+	if !ok {
+		return nil, false
+	}
+	return append([]byte(nil), file.data...), true
+}
+
+// SetFile sets the content of the Steam Cloud file name, as if it had been written now.
+func (f *Fake) SetFile(name string, data []byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.writeFile(name, data)
+}
+
+// AddDLC adds a DLC the user owns.
+func (f *Fake) AddDLC(appID AppId_t, name string, installed bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.dlcs = append(f.dlcs, &fakeDLC{appID: appID, name: name, owned: true, installed: installed})
+}
+
+// AddUnownedDLC adds a DLC the user can buy but does not own.
+func (f *Fake) AddUnownedDLC(appID AppId_t, name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.dlcs = append(f.dlcs, &fakeDLC{appID: appID, name: name})
+}
+
+// InstallDLC marks the DLC appID as installed and posts DlcInstalled_t.
+func (f *Fake) InstallDLC(appID AppId_t) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if d := f.dlc(appID); d != nil {
+		d.installed = true
+		d.download = time.Time{}
+	}
+	f.post(DlcInstalled_t{AppID: appID})
+}
+
+// Launch sets the parameters of a steam://run launch, as if the app were launched through one, and posts NewUrlLaunchParameters_t.
+func (f *Fake) Launch(commandLine string, query map[string]string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.launchLine = commandLine
+	f.launchQuery = maps.Clone(query)
+	f.post(NewUrlLaunchParameters_t{})
+}
+
+// SetFriend adds friend, or replaces the friend with the same SteamID, and posts PersonaStateChange_t for what changed.
+func (f *Fake) SetFriend(friend FakeFriend) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if friend.Relationship == EFriendRelationship_None {
+		friend.Relationship = EFriendRelationship_Friend
+	}
+	ff := &fakeFriend{FakeFriend: friend}
+	if friend.Avatar != nil {
+		ff.avatar = f.addImage(friend.Avatar)
+	}
+
+	old := f.friend(friend.SteamID)
+	if old == nil {
+		f.friends = append(f.friends, ff)
+		f.post(PersonaStateChange_t{SteamID: friend.SteamID, ChangeFlags: EPersonaChange_Name | EPersonaChange_RelationshipChanged})
+		return
+	}
+	var changes EPersonaChange
+	if old.Name != friend.Name {
+		changes |= EPersonaChange_Name
+	}
+	if old.State != friend.State {
+		changes |= EPersonaChange_Status
+		if old.State == EPersonaState_Offline {
+			changes |= EPersonaChange_ComeOnline
+		}
+		if friend.State == EPersonaState_Offline {
+			changes |= EPersonaChange_GoneOffline
+		}
+	}
+	if old.Game != friend.Game {
+		changes |= EPersonaChange_GamePlayed
+	}
+	if old.Avatar != friend.Avatar {
+		changes |= EPersonaChange_Avatar
+	}
+	if old.Relationship != friend.Relationship {
+		changes |= EPersonaChange_RelationshipChanged
+	}
+	*old = *ff
+	if changes != 0 {
+		f.post(PersonaStateChange_t{SteamID: friend.SteamID, ChangeFlags: changes})
+	}
+}
+
+// Overlay returns what the overlay shows, or "" if it is closed: a dialog like "friends", a dialog about a user
+// like "steamid/76561197960265729", a web page's URL, "invite/<lobby>" or "store/<app ID>/<EOverlayToStoreFlag>".
+func (f *Fake) Overlay() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.overlay
+}
+
+// CloseOverlay closes the overlay as the user would, and posts GameOverlayActivated_t.
+func (f *Fake) CloseOverlay() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.overlay == "" {
+		return
+	}
+	f.overlay = ""
+	f.post(GameOverlayActivated_t{Active: false, UserInitiated: true, AppID: f.AppID})
+}
+
+// AddWorkshopItem publishes a Workshop item and returns its ID.
+func (f *Fake) AddWorkshopItem(item FakeWorkshopItem) PublishedFileId_t {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.addWorkshopItem(item).ID
+}
+
+// WorkshopItem returns the Workshop item id, e.g. to check what an update published.
+func (f *Fake) WorkshopItem(id PublishedFileId_t) (FakeWorkshopItem, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	item := f.workshopItem(id)
+	if item == nil {
+		return FakeWorkshopItem{}, false
+	}
+	ret := item.FakeWorkshopItem
+	ret.Tags = slices.Clone(ret.Tags)
+	return ret, true
+}
+
+// RichPresence returns the rich presence value set for key.
+func (f *Fake) RichPresence(key string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.richPresence[key]
+}
+
+// Post queues a broadcast callback such as GameOverlayActivated_t for the dispatcher to pull.
+func (f *Fake) Post(cb Callback) error {
+	e, ok := cb.(callbackEncoder)
+	if !ok {
+		return fmt.Errorf("steamworks: fake backend cannot post %T", cb)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.post(e)
+	return nil
+}
+
+func (f *Fake) post(cb callbackEncoder) {
+	f.queue = append(f.queue, callbackMsg{ID: cb.callbackExpected(), Param: cb.encode()})
+}
+
+// Call implements Backend.
+func (f *Fake) Call(name string, args ...uint64) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs[name]; err != nil {
+		return 0, err
+	}
+
+	switch name {
+	case flatAPI_RestartAppIfNecessary:
+		return 0, nil
+	case flatAPI_InitFlat:
+		if f.InitResult != ESteamAPIInitResult_OK {
+			copy(fakeBytes(args[0], len(steamErrMsg{})), "fake Steam client failed to initialize\x00")
+		}
+		return uint64(f.InitResult), nil
+	case flatAPI_RunCallbacks, flatAPI_Shutdown:
+		return 0, nil
+
+	case flatAPI_GetHSteamPipe:
+		return 1, nil
+	case flatAPI_ManualDispatch_Init:
+		return 0, nil
+	case flatAPI_ManualDispatch_RunFrame:
+		f.announceCalls()
+		f.finishDownloads()
+		return 0, nil
+	case flatAPI_ManualDispatch_GetNextCallback:
+		if len(f.queue) == 0 {
+			return 0, nil
+		}
+		msg := f.queue[0]
+		f.queue = f.queue[1:]
+		f.current = &msg
+		msg.put(fakePtr(args[1]))
+		return 1, nil
+	case flatAPI_ManualDispatch_FreeLastCallback:
+		f.current = nil
+		return 0, nil
+	case flatAPI_ManualDispatch_GetAPICallResult:
+		return f.callResult(SteamAPICall_t(args[1]), args[2], int(int32(args[3])), iCallbackExpected(int32(args[4])), args[5]), nil
+
+	case flatAPI_SteamApps, flagAPI_SteamFriends, flatAPI_SteamInput, flatAPI_SteamRemoteStorage, flatAPI_SteamUGC, flatAPI_SteamUser, flatAPI_SteamUserStats, flatAPI_SteamUtils:
+		return fakeInterface, nil
+
+	case flatAPI_ISteamApps_BGetDLCDataByIndex:
+		i := int(int32(args[1]))
+		if i < 0 || i >= len(f.dlcs) {
+			return 0, nil
+		}
+		d := f.dlcs[i]
+		*(*AppId_t)(fakePtr(args[2])) = d.appID
+		*(*bool)(fakePtr(args[3])) = true
+		fakePutString(args[4], int(int32(args[5])), d.name)
+		return 1, nil
+	case flatAPI_ISteamApps_InstallDLC:
+		if d := f.dlc(AppId_t(args[1])); d != nil && d.owned && !d.installed && d.download.IsZero() {
+			d.download = time.Now()
+		}
+		return 0, nil
+	case flatAPI_ISteamApps_UninstallDLC:
+		if d := f.dlc(AppId_t(args[1])); d != nil {
+			d.installed = false
+			d.download = time.Time{}
+		}
+		return 0, nil
+	case flatAPI_ISteamApps_GetDlcDownloadProgress:
+		f.finishDownloads()
+		d := f.dlc(AppId_t(args[1]))
+		if d == nil || d.download.IsZero() {
+			return 0, nil
+		}
+		downloaded := uint64(fakeDLCSize * (float64(time.Since(d.download)) / float64(f.Latency)))
+		*(*uint64)(fakePtr(args[2])) = downloaded
+		*(*uint64)(fakePtr(args[3])) = fakeDLCSize
+		return 1, nil
+	case flatAPI_ISteamApps_RequestAppProofOfPurchaseKey:
+		appID := AppId_t(args[1])
+		if d := f.dlc(appID); appID != f.AppID && (d == nil || !d.owned) {
+			f.post(AppProofOfPurchaseKeyResponse_t{Result: EResult_Fail, AppID: appID})
+			return 0, nil
+		}
+		f.post(AppProofOfPurchaseKeyResponse_t{Result: EResult_OK, AppID: appID, Key: fmt.Sprintf("FAKE-%d-%d", appID, f.SteamID.AccountID())})
+		return 0, nil
+	case flatAPI_ISteamApps_BIsDlcInstalled:
+		f.finishDownloads()
+		d := f.dlc(AppId_t(args[1]))
+		return fakeBool(d != nil && d.installed), nil
+	case flatAPI_ISteamApps_GetAppInstallDir:
+		return uint64(fakePutString(args[2], int(int32(args[3])), f.InstallDir)), nil
+	case flatAPI_ISteamApps_GetCurrentGameLanguage:
+		return f.cString(f.Language), nil
+	case flatAPI_ISteamApps_GetDLCCount:
+		return uint64(len(f.dlcs)), nil
+	case flatAPI_ISteamApps_BIsSubscribed:
+		return 1, nil
+	case flatAPI_ISteamApps_BIsSubscribedApp:
+		appID := AppId_t(args[1])
+		d := f.dlc(appID)
+		return fakeBool(appID == f.AppID || d != nil && d.owned), nil
+	case flatAPI_ISteamApps_BIsLowViolence:
+		return fakeBool(f.LowViolence), nil
+	case flatAPI_ISteamApps_BIsCybercafe:
+		return fakeBool(f.Cybercafe), nil
+	case flatAPI_ISteamApps_BIsVACBanned:
+		return fakeBool(f.VACBanned), nil
+	case flatAPI_ISteamApps_BIsSubscribedFromFreeWeekend:
+		return fakeBool(f.FreeWeekend), nil
+	case flatAPI_ISteamApps_BIsTimedTrial:
+		if f.TrialAllowed == 0 {
+			return 0, nil
+		}
+		*(*uint32)(fakePtr(args[1])) = uint32(f.TrialAllowed / time.Second)
+		*(*uint32)(fakePtr(args[2])) = uint32(f.TrialPlayed / time.Second)
+		return 1, nil
+	case flatAPI_ISteamApps_GetAvailableGameLanguages:
+		languages := f.AvailableLanguages
+		if len(languages) == 0 {
+			languages = []string{f.Language}
+		}
+		return f.cString(strings.Join(languages, ",")), nil
+	case flatAPI_ISteamApps_GetEarliestPurchaseUnixTime:
+		if d := f.dlc(AppId_t(args[1])); AppId_t(args[1]) != f.AppID && (d == nil || !d.owned) {
+			return 0, nil
+		}
+		return uint64(unixSeconds(f.PurchaseTime)), nil
+	case flatAPI_ISteamApps_GetCurrentBetaName:
+		if f.BetaName == "" {
+			return 0, nil
+		}
+		fakePutString(args[1], int(int32(args[2])), f.BetaName)
+		return 1, nil
+	case flatAPI_ISteamApps_GetAppBuildId:
+		return uint64(f.BuildID), nil
+	case flatAPI_ISteamApps_GetAppOwner:
+		if f.Owner != 0 {
+			return uint64(f.Owner), nil
+		}
+		return uint64(f.SteamID), nil
+	case flatAPI_ISteamApps_GetLaunchQueryParam:
+		return f.cString(f.launchQuery[fakeString(args[1])]), nil
+	case flatAPI_ISteamApps_GetLaunchCommandLine:
+		return uint64(fakePutString(args[1], int(int32(args[2])), f.launchLine)), nil
+
+	case flatAPI_ISteamFriends_GetPersonaName:
+		return f.cString(f.PersonaName), nil
+	case flatAPI_ISteamFriends_SetRichPresence:
+		key, value := fakeString(args[1]), fakeString(args[2])
+		if value == "" {
+			delete(f.richPresence, key)
+		} else {
+			f.richPresence[key] = value
+		}
+		return 1, nil
+	case flatAPI_ISteamFriends_ActivateGameOverlayToStore:
+		f.openOverlay(fmt.Sprintf("store/%d/%d", uint32(args[1]), EOverlayToStoreFlag(args[2])))
+		return 0, nil
+	case flatAPI_ISteamFriends_ActivateGameOverlay:
+		f.openOverlay(fakeString(args[1]))
+		return 0, nil
+	case flatAPI_ISteamFriends_ActivateGameOverlayToUser:
+		f.openOverlay(fmt.Sprintf("%s/%d", fakeString(args[1]), CSteamID(args[2])))
+		return 0, nil
+	case flatAPI_ISteamFriends_ActivateGameOverlayToWebPage:
+		f.openOverlay(fakeString(args[1]))
+		return 0, nil
+	case flatAPI_ISteamFriends_ActivateGameOverlayInviteDialog:
+		f.openOverlay(fmt.Sprintf("invite/%d", CSteamID(args[1])))
+		return 0, nil
+	case flatAPI_ISteamFriends_GetFriendCount:
+		return uint64(len(f.friendsWith(EFriendFlags(args[1])))), nil
+	case flatAPI_ISteamFriends_GetFriendByIndex:
+		friends := f.friendsWith(EFriendFlags(args[2]))
+		i := int(int32(args[1]))
+		if i < 0 || i >= len(friends) {
+			return 0, nil
+		}
+		return uint64(friends[i].SteamID), nil
+	case flatAPI_ISteamFriends_GetFriendPersonaName:
+		if CSteamID(args[1]) == f.SteamID {
+			return f.cString(f.PersonaName), nil
+		}
+		if ff := f.friend(CSteamID(args[1])); ff != nil {
+			return f.cString(ff.Name), nil
+		}
+		return f.cString(""), nil
+	case flatAPI_ISteamFriends_GetFriendPersonaState:
+		if CSteamID(args[1]) == f.SteamID {
+			return uint64(EPersonaState_Online), nil
+		}
+		if ff := f.friend(CSteamID(args[1])); ff != nil {
+			return uint64(ff.State), nil
+		}
+		return uint64(EPersonaState_Offline), nil
+	case flatAPI_ISteamFriends_GetFriendGamePlayed:
+		ff := f.friend(CSteamID(args[1]))
+		if ff == nil || ff.Game.GameID == 0 {
+			return 0, nil
+		}
+		ff.Game.put(fakePtr(args[2]))
+		return 1, nil
+	case flatAPI_ISteamFriends_GetFriendRelationship:
+		if ff := f.friend(CSteamID(args[1])); ff != nil {
+			return uint64(ff.Relationship), nil
+		}
+		return uint64(EFriendRelationship_None), nil
+	case flatAPI_ISteamFriends_GetSmallFriendAvatar, flatAPI_ISteamFriends_GetMediumFriendAvatar, flatAPI_ISteamFriends_GetLargeFriendAvatar:
+		// The fake has one image of every avatar and loads none.
+		if ff := f.friend(CSteamID(args[1])); ff != nil {
+			return uint64(ff.avatar), nil
+		}
+		return 0, nil
+	case flatAPI_ISteamFriends_RequestUserInformation:
+		// The fake knows everything about the users it knows.
+		return 0, nil
+
+	case flatAPI_ISteamInput_GetConnectedControllers, flatAPI_ISteamInput_GetInputTypeForHandle, flatAPI_ISteamInput_RunFrame:
+		return 0, nil
+	case flatAPI_ISteamInput_Init:
+		return 1, nil
+
+	case flatAPI_ISteamRemoteStorage_FileWrite:
+		file := fakeString(args[1])
+		data := fakeBytes(args[2], int(int32(args[3])))
+		if !f.fits(file, data) {
+			return 0, nil
+		}
+		f.writeFile(file, data)
+		return 1, nil
+	case flatAPI_ISteamRemoteStorage_FileRead:
+		file, ok := f.files[fakeString(args[1])]
+		if !ok {
+			return 0, nil
+		}
+		return uint64(copy(fakeBytes(args[2], int(int32(args[3]))), file.data)), nil
+	case flatAPI_ISteamRemoteStorage_FileDelete:
+		file := fakeString(args[1])
+		_, ok := f.files[file]
+		delete(f.files, file)
+		return fakeBool(ok), nil
+	case flatAPI_ISteamRemoteStorage_GetFileSize:
+		return uint64(len(f.fileData(fakeString(args[1])))), nil
+	case flatAPI_ISteamRemoteStorage_FileExists:
+		_, ok := f.files[fakeString(args[1])]
+		return fakeBool(ok), nil
+	case flatAPI_ISteamRemoteStorage_FilePersisted:
+		file, ok := f.files[fakeString(args[1])]
+		return fakeBool(ok && file.persisted), nil
+	case flatAPI_ISteamRemoteStorage_GetFileTimestamp:
+		file, ok := f.files[fakeString(args[1])]
+		if !ok {
+			return 0, nil
+		}
+		return uint64(file.modTime.Unix()), nil
+	case flatAPI_ISteamRemoteStorage_FileForget:
+		file, ok := f.files[fakeString(args[1])]
+		if !ok {
+			return 0, nil
+		}
+		file.persisted = false
+		return 1, nil
+	case flatAPI_ISteamRemoteStorage_SetSyncPlatforms:
+		file, ok := f.files[fakeString(args[1])]
+		if !ok {
+			return 0, nil
+		}
+		file.platforms = ERemoteStoragePlatform(args[2])
+		return 1, nil
+	case flatAPI_ISteamRemoteStorage_GetFileCount:
+		return uint64(len(f.files)), nil
+	case flatAPI_ISteamRemoteStorage_GetFileNameAndSize:
+		names := f.fileNames()
+		i := int(int32(args[1]))
+		if i < 0 || i >= len(names) {
+			return 0, nil
+		}
+		*(*int32)(fakePtr(args[2])) = int32(len(f.files[names[i]].data))
+		return f.cString(names[i]), nil
+	case flatAPI_ISteamRemoteStorage_GetQuota:
+		total, available := f.quota()
+		*(*uint64)(fakePtr(args[1])) = total
+		*(*uint64)(fakePtr(args[2])) = available
+		return 1, nil
+	case flatAPI_ISteamRemoteStorage_IsCloudEnabledForAccount:
+		return fakeBool(f.CloudEnabledForAccount), nil
+	case flatAPI_ISteamRemoteStorage_IsCloudEnabledForApp:
+		return fakeBool(f.cloudForApp), nil
+	case flatAPI_ISteamRemoteStorage_SetCloudEnabledForApp:
+		f.cloudForApp = args[1]&0xff != 0
+		return 0, nil
+
+	case flatAPI_ISteamRemoteStorage_BeginFileWriteBatch:
+		if f.inBatch {
+			return 0, nil
+		}
+		f.inBatch = true
+		return 1, nil
+	case flatAPI_ISteamRemoteStorage_EndFileWriteBatch:
+		if !f.inBatch {
+			return 0, nil
+		}
+		f.inBatch = false
+		return 1, nil
+	case flatAPI_ISteamRemoteStorage_FileWriteStreamOpen:
+		f.nextStream++
+		f.streams[f.nextStream] = &fakeStream{name: fakeString(args[1])}
+		return uint64(f.nextStream), nil
+	case flatAPI_ISteamRemoteStorage_FileWriteStreamWriteChunk:
+		stream, ok := f.streams[UGCFileWriteStreamHandle_t(args[1])]
+		if !ok {
+			return 0, nil
+		}
+		stream.data = append(stream.data, fakeBytes(args[2], int(int32(args[3])))...)
+		return 1, nil
+	case flatAPI_ISteamRemoteStorage_FileWriteStreamClose:
+		stream, ok := f.streams[UGCFileWriteStreamHandle_t(args[1])]
+		if !ok {
+			return 0, nil
+		}
+		delete(f.streams, UGCFileWriteStreamHandle_t(args[1]))
+		if !f.fits(stream.name, stream.data) {
+			return 0, nil
+		}
+		f.writeFile(stream.name, stream.data)
+		return 1, nil
+	case flatAPI_ISteamRemoteStorage_FileWriteStreamCancel:
+		_, ok := f.streams[UGCFileWriteStreamHandle_t(args[1])]
+		delete(f.streams, UGCFileWriteStreamHandle_t(args[1]))
+		return fakeBool(ok), nil
+	case flatAPI_ISteamRemoteStorage_FileReadAsync:
+		file, ok := f.files[fakeString(args[1])]
+		offset, size := int(uint32(args[2])), int(uint32(args[3]))
+		if !ok || offset > len(file.data) {
+			return f.startCall(name, RemoteStorageFileReadAsyncComplete_t{Result: EResult_FileNotFound}), nil
+		}
+		data := append([]byte(nil), file.data[offset:min(len(file.data), offset+size)]...)
+		call := f.startCall(name, RemoteStorageFileReadAsyncComplete_t{})
+		// The result names its own call, which is only known once the call has started.
+		f.calls[SteamAPICall_t(call)].data = RemoteStorageFileReadAsyncComplete_t{
+			FileReadAsync: SteamAPICall_t(call),
+			Result:        EResult_OK,
+			Offset:        uint32(offset),
+			Read:          uint32(len(data)),
+		}.encode()
+		f.asyncReads[SteamAPICall_t(call)] = data
+		return call, nil
+	case flatAPI_ISteamRemoteStorage_FileReadAsyncComplete:
+		data, ok := f.asyncReads[SteamAPICall_t(args[1])]
+		if !ok || int(int32(args[3])) < len(data) {
+			return 0, nil
+		}
+		delete(f.asyncReads, SteamAPICall_t(args[1]))
+		copy(fakeBytes(args[2], int(int32(args[3]))), data)
+		return 1, nil
+	case flatAPI_ISteamRemoteStorage_FileWriteAsync:
+		file := fakeString(args[1])
+		data := fakeBytes(args[2], int(int32(args[3])))
+		if !f.fits(file, data) {
+			return f.startCall(name, RemoteStorageFileWriteAsyncComplete_t{Result: EResult_LimitExceeded}), nil
+		}
+		f.writeFile(file, data)
+		return f.startCall(name, RemoteStorageFileWriteAsyncComplete_t{Result: EResult_OK}), nil
+
+	case flatAPI_ISteamRemoteStorage_FileShare:
+		file := fakeString(args[1])
+		b, ok := f.files[file]
+		if !ok {
+			return f.startCall(name, RemoteStorageFileShareResult_t{Result: EResult_FileNotFound, Filename: file}), nil
+		}
+		ugc := f.addUGC(file, b.data, f.SteamID)
+		return f.startCall(name, RemoteStorageFileShareResult_t{Result: EResult_OK, File: ugc, Filename: file}), nil
+	case flatAPI_ISteamRemoteStorage_UGCDownload:
+		ugc, ok := f.ugcs[UGCHandle_t(args[1])]
+		if !ok {
+			return f.startCall(name, RemoteStorageDownloadUGCResult_t{Result: EResult_FileNotFound, File: UGCHandle_t(args[1])}), nil
+		}
+		return f.startCall(name, RemoteStorageDownloadUGCResult_t{
+			Result:       EResult_OK,
+			File:         UGCHandle_t(args[1]),
+			AppID:        f.AppID,
+			SizeInBytes:  int32(len(ugc.data)),
+			FileName:     ugc.name,
+			SteamIDOwner: ugc.owner,
+		}), nil
+	case flatAPI_ISteamRemoteStorage_UGCRead:
+		ugc, ok := f.ugcs[UGCHandle_t(args[1])]
+		offset := int(uint32(args[4]))
+		if !ok || offset > len(ugc.data) {
+			return 0, nil
+		}
+		return uint64(copy(fakeBytes(args[2], int(int32(args[3]))), ugc.data[offset:])), nil
+
+	case flatAPI_ISteamUGC_CreateQueryUserUGCRequest:
+		return uint64(f.addQuery(&fakeUGCQuery{user: AccountID_t(args[1]), list: EUserUGCList(args[2]), page: uint32(args[7])})), nil
+	case flatAPI_ISteamUGC_CreateQueryAllUGCRequest:
+		return uint64(f.addQuery(&fakeUGCQuery{page: uint32(args[5])})), nil
+	case flatAPI_ISteamUGC_CreateQueryUGCDetailsRequest:
+		ids := make([]PublishedFileId_t, int(uint32(args[2])))
+		for i := range ids {
+			ids[i] = *(*PublishedFileId_t)(unsafe.Add(fakePtr(args[1]), uintptr(i)*unsafe.Sizeof(PublishedFileId_t(0))))
+		}
+		return uint64(f.addQuery(&fakeUGCQuery{ids: ids})), nil
+	case flatAPI_ISteamUGC_AddRequiredTag, flatAPI_ISteamUGC_AddExcludedTag, flatAPI_ISteamUGC_SetMatchAnyTag, flatAPI_ISteamUGC_SetSearchText,
+		flatAPI_ISteamUGC_SetReturnLongDescription:
+		q, ok := f.ugcQueries[UGCQueryHandle_t(args[1])]
+		if !ok {
+			return 0, nil
+		}
+		switch name {
+		case flatAPI_ISteamUGC_AddRequiredTag:
+			q.required = append(q.required, fakeString(args[2]))
+		case flatAPI_ISteamUGC_AddExcludedTag:
+			q.excluded = append(q.excluded, fakeString(args[2]))
+		case flatAPI_ISteamUGC_SetMatchAnyTag:
+			q.matchAnyTag = args[2]&0xff != 0
+		case flatAPI_ISteamUGC_SetSearchText:
+			q.searchText = fakeString(args[2])
+		case flatAPI_ISteamUGC_SetReturnLongDescription:
+			q.longDescription = args[2]&0xff != 0
+		}
+		return 1, nil
+	case flatAPI_ISteamUGC_SetRankedByTrendDays, flatAPI_ISteamUGC_SetReturnMetadata, flatAPI_ISteamUGC_SetLanguage, flatAPI_ISteamUGC_SetAllowCachedResponse:
+		_, ok := f.ugcQueries[UGCQueryHandle_t(args[1])]
+		return fakeBool(ok), nil
+	case flatAPI_ISteamUGC_SendQueryUGCRequest:
+		q, ok := f.ugcQueries[UGCQueryHandle_t(args[1])]
+		if !ok {
+			return 0, nil
+		}
+		matches := f.queryWorkshop(q)
+		if q.ids == nil {
+			page := int(max(q.page, 1))
+			q.results = matches[min(len(matches), (page-1)*NumUGCResultsPerPage):min(len(matches), page*NumUGCResultsPerPage)]
+		} else {
+			q.results = matches
+		}
+		return f.startCall(name, SteamUGCQueryCompleted_t{
+			Handle:               UGCQueryHandle_t(args[1]),
+			Result:               EResult_OK,
+			NumResultsReturned:   uint32(len(q.results)),
+			TotalMatchingResults: uint32(len(matches)),
+		}), nil
+	case flatAPI_ISteamUGC_GetQueryUGCResult:
+		item, q := f.queryResult(args[1], args[2])
+		if item == nil {
+			return 0, nil
+		}
+		description := item.Description
+		if !q.longDescription && len(description) > 255 {
+			description = description[:255]
+		}
+		var score float32
+		if votes := item.VotesUp + item.VotesDown; votes > 0 {
+			score = float32(item.VotesUp) / float32(votes)
+		}
+		SteamUGCDetails_t{
+			PublishedFileID: item.ID,
+			Result:          EResult_OK,
+			FileType:        EWorkshopFileType_Community,
+			CreatorAppID:    f.AppID,
+			ConsumerAppID:   f.AppID,
+			Title:           item.Title,
+			Description:     description,
+			SteamIDOwner:    item.Owner,
+			TimeCreated:     item.Created,
+			TimeUpdated:     item.Updated,
+			Visibility:      item.Visibility,
+			Tags:            strings.Join(item.Tags, ","),
+			FileSize:        int32(min(item.Size, math.MaxInt32)),
+			VotesUp:         item.VotesUp,
+			VotesDown:       item.VotesDown,
+			Score:           score,
+			TotalFilesSize:  item.Size,
+		}.put(fakePtr(args[3]))
+		return 1, nil
+	case flatAPI_ISteamUGC_GetQueryUGCPreviewURL, flatAPI_ISteamUGC_GetQueryUGCMetadata:
+		item, _ := f.queryResult(args[1], args[2])
+		if item == nil {
+			return 0, nil
+		}
+		value := item.PreviewURL
+		if name == flatAPI_ISteamUGC_GetQueryUGCMetadata {
+			value = item.Metadata
+		}
+		fakePutString(args[3], int(uint32(args[4])), value)
+		return 1, nil
+	case flatAPI_ISteamUGC_GetQueryUGCStatistic:
+		item, _ := f.queryResult(args[1], args[2])
+		if item == nil || EItemStatistic(args[3]) != EItemStatistic_NumSubscriptions {
+			return 0, nil
+		}
+		*(*uint64)(fakePtr(args[4])) = item.subscriptions
+		return 1, nil
+	case flatAPI_ISteamUGC_ReleaseQueryUGCRequest:
+		_, ok := f.ugcQueries[UGCQueryHandle_t(args[1])]
+		delete(f.ugcQueries, UGCQueryHandle_t(args[1]))
+		return fakeBool(ok), nil
+
+	case flatAPI_ISteamUGC_SubscribeItem:
+		id := PublishedFileId_t(args[1])
+		item := f.workshopItem(id)
+		if item == nil {
+			return f.startCall(name, RemoteStorageSubscribePublishedFileResult_t{Result: EResult_FileNotFound, PublishedFileID: id}), nil
+		}
+		if !item.subscribed {
+			item.subscribed = true
+			item.subscriptions++
+		}
+		if !item.installed {
+			item.installed = true
+			f.post(ItemInstalled_t{AppID: f.AppID, PublishedFileID: id})
+		}
+		return f.startCall(name, RemoteStorageSubscribePublishedFileResult_t{Result: EResult_OK, PublishedFileID: id}), nil
+	case flatAPI_ISteamUGC_UnsubscribeItem:
+		id := PublishedFileId_t(args[1])
+		item := f.workshopItem(id)
+		if item == nil || !item.subscribed {
+			return f.startCall(name, RemoteStorageUnsubscribePublishedFileResult_t{Result: EResult_Fail, PublishedFileID: id}), nil
+		}
+		// Like Steam, the fake leaves the item installed until the game exits.
+		item.subscribed = false
+		item.subscriptions--
+		return f.startCall(name, RemoteStorageUnsubscribePublishedFileResult_t{Result: EResult_OK, PublishedFileID: id}), nil
+	case flatAPI_ISteamUGC_GetNumSubscribedItems:
+		return uint64(len(f.subscribedItems())), nil
+	case flatAPI_ISteamUGC_GetSubscribedItems:
+		items := f.subscribedItems()
+		n := min(len(items), int(uint32(args[2])))
+		if n > 0 {
+			ids := unsafe.Slice((*PublishedFileId_t)(fakePtr(args[1])), n)
+			for i := range ids {
+				ids[i] = items[i].ID
+			}
+		}
+		return uint64(n), nil
+	case flatAPI_ISteamUGC_GetItemState:
+		item := f.workshopItem(PublishedFileId_t(args[1]))
+		if item == nil {
+			return uint64(EItemState_None), nil
+		}
+		var state EItemState
+		if item.subscribed {
+			state |= EItemState_Subscribed
+		}
+		if item.installed {
+			state |= EItemState_Installed
+		}
+		return uint64(state), nil
+	case flatAPI_ISteamUGC_GetItemInstallInfo:
+		item := f.workshopItem(PublishedFileId_t(args[1]))
+		if item == nil || !item.installed {
+			return 0, nil
+		}
+		*(*uint64)(fakePtr(args[2])) = item.Size
+		fakePutString(args[3], int(uint32(args[4])), filepath.Join(f.InstallDir, "workshop", strconv.FormatUint(uint64(item.ID), 10)))
+		*(*uint32)(fakePtr(args[5])) = uint32(item.Updated.Unix())
+		return 1, nil
+	case flatAPI_ISteamUGC_GetItemDownloadInfo:
+		item := f.workshopItem(PublishedFileId_t(args[1]))
+		if item == nil || !item.installed {
+			return 0, nil
+		}
+		*(*uint64)(fakePtr(args[2])) = item.Size
+		*(*uint64)(fakePtr(args[3])) = item.Size
+		return 1, nil
+	case flatAPI_ISteamUGC_CreateItem:
+		item := f.addWorkshopItem(FakeWorkshopItem{Owner: f.SteamID, Visibility: ERemoteStoragePublishedFileVisibility_Private})
+		return f.startCall(name, CreateItemResult_t{Result: EResult_OK, PublishedFileID: item.ID}), nil
+	case flatAPI_ISteamUGC_StartItemUpdate:
+		item := f.workshopItem(PublishedFileId_t(args[2]))
+		if item == nil || item.Owner != f.SteamID {
+			return uint64(ugcUpdateHandleInvalid), nil
+		}
+		f.nextUpdate++
+		f.ugcUpdates[f.nextUpdate] = &fakeItemUpdate{id: item.ID}
+		return uint64(f.nextUpdate), nil
+	case flatAPI_ISteamUGC_SetItemTitle, flatAPI_ISteamUGC_SetItemDescription, flatAPI_ISteamUGC_SetItemTags, flatAPI_ISteamUGC_SetItemVisibility,
+		flatAPI_ISteamUGC_SetItemContent, flatAPI_ISteamUGC_SetItemPreview:
+		u, ok := f.ugcUpdates[UGCUpdateHandle_t(args[1])]
+		if !ok || u.call != 0 {
+			return 0, nil
+		}
+		switch name {
+		case flatAPI_ISteamUGC_SetItemTitle:
+			title := fakeString(args[2])
+			u.changes = append(u.changes, func(item *fakeWorkshopItem) { item.Title = title })
+		case flatAPI_ISteamUGC_SetItemDescription:
+			description := fakeString(args[2])
+			u.changes = append(u.changes, func(item *fakeWorkshopItem) { item.Description = description })
+		case flatAPI_ISteamUGC_SetItemTags:
+			array := (*steamParamStringArray)(fakePtr(args[2]))
+			var tags []string
+			if array.num > 0 {
+				for _, p := range unsafe.Slice((*uintptr)(fakePtr(uint64(array.strings))), array.num) {
+					tags = append(tags, fakeString(uint64(p)))
+				}
+			}
+			u.changes = append(u.changes, func(item *fakeWorkshopItem) { item.Tags = tags })
+		case flatAPI_ISteamUGC_SetItemVisibility:
+			visibility := ERemoteStoragePublishedFileVisibility(args[2])
+			u.changes = append(u.changes, func(item *fakeWorkshopItem) { item.Visibility = visibility })
+		case flatAPI_ISteamUGC_SetItemContent:
+			u.content = fakeString(args[2])
+		case flatAPI_ISteamUGC_SetItemPreview:
+			preview := fakeString(args[2])
+			u.changes = append(u.changes, func(item *fakeWorkshopItem) { item.Preview = preview })
+		}
+		return 1, nil
+	case flatAPI_ISteamUGC_SubmitItemUpdate:
+		u, ok := f.ugcUpdates[UGCUpdateHandle_t(args[1])]
+		if !ok || u.call != 0 {
+			return 0, nil
+		}
+		item := f.workshopItem(u.id)
+		if u.content != "" {
+			size, err := dirSize(u.content)
+			if err != nil {
+				u.call = SteamAPICall_t(f.startCall(name, SubmitItemUpdateResult_t{Result: EResult_FileNotFound, PublishedFileID: u.id}))
+				return uint64(u.call), nil
+			}
+			u.size = size
+			u.changes = append(u.changes, func(item *fakeWorkshopItem) {
+				item.Content = u.content
+				item.Size = size
+			})
+		}
+		for _, change := range u.changes {
+			change(item)
+		}
+		item.Updated = time.Now()
+		u.call = SteamAPICall_t(f.startCall(name, SubmitItemUpdateResult_t{Result: EResult_OK, PublishedFileID: u.id}))
+		return uint64(u.call), nil
+	case flatAPI_ISteamUGC_GetItemUpdateProgress:
+		u, ok := f.ugcUpdates[UGCUpdateHandle_t(args[1])]
+		if !ok {
+			return uint64(EItemUpdateStatus_Invalid), nil
+		}
+		c, ok := f.calls[u.call]
+		if !ok {
+			return uint64(EItemUpdateStatus_Invalid), nil
+		}
+		// The upload is as far along as the call is towards completing.
+		processed := u.size
+		if remaining := time.Until(c.ready); remaining > 0 && f.Latency > 0 {
+			processed = uint64(float64(u.size) * (1 - float64(remaining)/float64(f.Latency)))
+		}
+		*(*uint64)(fakePtr(args[2])) = processed
+		*(*uint64)(fakePtr(args[3])) = u.size
+		return uint64(EItemUpdateStatus_UploadingContent), nil
+	case flatAPI_ISteamUGC_DownloadItem:
+		item := f.workshopItem(PublishedFileId_t(args[1]))
+		if item == nil {
+			return 0, nil
+		}
+		item.installed = true
+		f.post(DownloadItemResult_t{AppID: f.AppID, PublishedFileID: item.ID, Result: EResult_OK})
+		return 1, nil
+
+	case flatAPI_ISteamUser_GetSteamID:
+		return uint64(f.SteamID), nil
+
+	case flatAPI_ISteamUserStats_RequestUserStats:
+		return f.startCall(name, UserStatsReceived_t{GameID: int(f.AppID), Result: EResult_OK, SteamID: CSteamID(args[1])}), nil
+	case flatAPI_ISteamUserStats_RequestGlobalStats:
+		return f.startCall(name, GlobalStatsReceived_t{GameID: int(f.AppID), Result: EResult_OK}), nil
+	case flatAPI_ISteamUserStats_GetGlobalStatInt:
+		v, ok := f.globalStats[fakeString(args[1])]
+		if !ok {
+			return 0, nil
+		}
+		*(*int64)(fakePtr(args[2])) = v
+		return 1, nil
+	case flatAPI_ISteamUserStats_GetStatInt32:
+		v, ok := f.stats[fakeString(args[1])]
+		if !ok {
+			return 0, nil
+		}
+		*(*int32)(fakePtr(args[2])) = v
+		return 1, nil
+	case flatAPI_ISteamUserStats_GetStatFloat:
+		v, ok := f.floatStats[fakeString(args[1])]
+		if !ok {
+			return 0, nil
+		}
+		*(*float32)(fakePtr(args[2])) = v
+		return 1, nil
+	case flatAPI_ISteamUserStats_SetStatInt32:
+		f.stats[fakeString(args[1])] = int32(args[2])
+		return 1, nil
+	case flatAPI_ISteamUserStats_SetStatFloat:
+		f.floatStats[fakeString(args[1])] = math.Float32frombits(uint32(args[2]))
+		return 1, nil
+	case flatAPI_ISteamUserStats_UpdateAvgRateStat:
+		stat := fakeString(args[1])
+		r := f.avgRates[stat]
+		r[0] += float64(math.Float32frombits(uint32(args[2])))
+		r[1] += math.Float64frombits(uint64(args[3]))
+		f.avgRates[stat] = r
+		if r[1] > 0 {
+			f.floatStats[stat] = float32(r[0] / r[1])
+		}
+		return 1, nil
+	case flatAPI_ISteamUserStats_GetUserStatInt32:
+		v, ok := f.stats[fakeString(args[2])]
+		if !ok || CSteamID(args[1]) != f.SteamID {
+			return 0, nil
+		}
+		*(*int32)(fakePtr(args[3])) = v
+		return 1, nil
+	case flatAPI_ISteamUserStats_GetUserStatFloat:
+		v, ok := f.floatStats[fakeString(args[2])]
+		if !ok || CSteamID(args[1]) != f.SteamID {
+			return 0, nil
+		}
+		*(*float32)(fakePtr(args[3])) = v
+		return 1, nil
+	case flatAPI_ISteamUserStats_GetAchievement:
+		a := f.achievement(fakeString(args[1]))
+		if a == nil {
+			return 0, nil
+		}
+		*(*bool)(fakePtr(args[2])) = a.achieved
+		return 1, nil
+	case flatAPI_ISteamUserStats_GetUserAchievement:
+		a := f.achievement(fakeString(args[2]))
+		if a == nil || CSteamID(args[1]) != f.SteamID {
+			return 0, nil
+		}
+		*(*bool)(fakePtr(args[3])) = a.achieved
+		return 1, nil
+	case flatAPI_ISteamUserStats_SetAchievement:
+		f.defineAchievement(fakeString(args[1])).set(true)
+		return 1, nil
+	case flatAPI_ISteamUserStats_ClearAchievement:
+		f.defineAchievement(fakeString(args[1])).set(false)
+		return 1, nil
+	case flatAPI_ISteamUserStats_GetNumAchievements:
+		return uint64(len(f.achievements)), nil
+	case flatAPI_ISteamUserStats_GetAchievementName:
+		i := int(uint32(args[1]))
+		if i >= len(f.achievements) {
+			return 0, nil
+		}
+		return f.cString(f.achievements[i].APIName), nil
+	case flatAPI_ISteamUserStats_GetAchievementDisplayAttribute:
+		a := f.achievement(fakeString(args[1]))
+		if a == nil {
+			return f.cString(""), nil
+		}
+		switch fakeString(args[2]) {
+		case "name":
+			return f.cString(a.Name), nil
+		case "desc":
+			return f.cString(a.Description), nil
+		case "hidden":
+			if a.Hidden {
+				return f.cString("1"), nil
+			}
+			return f.cString("0"), nil
+		}
+		return f.cString(""), nil
+	case flatAPI_ISteamUserStats_GetAchievementAndUnlockTime:
+		a := f.achievement(fakeString(args[1]))
+		if a == nil {
+			return 0, nil
+		}
+		*(*bool)(fakePtr(args[2])) = a.achieved
+		var t uint32
+		if a.achieved {
+			t = uint32(a.unlockTime.Unix())
+		}
+		*(*uint32)(fakePtr(args[3])) = t
+		return 1, nil
+	case flatAPI_ISteamUserStats_IndicateAchievementProgress:
+		a := f.achievement(fakeString(args[1]))
+		return fakeBool(a != nil && !a.achieved && uint32(args[2]) < uint32(args[3])), nil
+	case flatAPI_ISteamUserStats_GetAchievementProgressLimitsInt32:
+		a := f.achievement(fakeString(args[1]))
+		if a == nil || a.MaxProgress == 0 {
+			return 0, nil
+		}
+		*(*int32)(fakePtr(args[2])) = a.MinProgress
+		*(*int32)(fakePtr(args[3])) = a.MaxProgress
+		return 1, nil
+	case flatAPI_ISteamUserStats_GetAchievementProgressLimitsFloat:
+		a := f.achievement(fakeString(args[1]))
+		if a == nil || a.MaxProgress == 0 {
+			return 0, nil
+		}
+		*(*float32)(fakePtr(args[2])) = float32(a.MinProgress)
+		*(*float32)(fakePtr(args[3])) = float32(a.MaxProgress)
+		return 1, nil
+	case flatAPI_ISteamUserStats_RequestGlobalAchievementPercentages:
+		return f.startCall(name, GlobalAchievementPercentagesReady_t{GameID: int(f.AppID), Result: EResult_OK}), nil
+	case flatAPI_ISteamUserStats_GetAchievementAchievedPercent:
+		a := f.achievement(fakeString(args[1]))
+		if a == nil {
+			return 0, nil
+		}
+		*(*float32)(fakePtr(args[2])) = a.Percent
+		return 1, nil
+	case flatAPI_ISteamUserStats_GetMostAchievedAchievementInfo:
+		return f.mostAchieved(0, args[1], int(int32(args[2])), args[3], args[4]), nil
+	case flatAPI_ISteamUserStats_GetNextMostAchievedAchievementInfo:
+		return f.mostAchieved(int(int32(args[1]))+1, args[2], int(int32(args[3])), args[4], args[5]), nil
+	case flatAPI_ISteamUserStats_GetAchievementIcon:
+		a := f.achievement(fakeString(args[1]))
+		if a == nil {
+			return 0, nil
+		}
+		return uint64(uint32(a.icon)), nil
+	case flatAPI_ISteamUserStats_ResetAllStats:
+		for stat := range f.stats {
+			f.stats[stat] = 0
+		}
+		for stat := range f.floatStats {
+			f.floatStats[stat] = 0
+		}
+		clear(f.avgRates)
+		if args[1] != 0 {
+			for _, a := range f.achievements {
+				a.set(false)
+			}
+		}
+		return 1, nil
+	case flatAPI_ISteamUserStats_StoreStats:
+		f.post(UserStatsStored_t{GameID: int(f.AppID), Result: EResult_OK})
+		return 1, nil
+
+	case flatAPI_ISteamUserStats_FindLeaderboard:
+		l := f.leaderboard(fakeString(args[1]))
+		if l == nil {
+			return f.startCall(name, LeaderboardFindResult_t{}), nil
+		}
+		return f.startCall(name, LeaderboardFindResult_t{SteamLeaderboard: l.handle, LeaderboardFound: true}), nil
+	case flatAPI_ISteamUserStats_FindOrCreateLeaderboard:
+		board := fakeString(args[1])
+		l := f.leaderboard(board)
+		if l == nil {
+			l = f.createLeaderboard(board, ELeaderboardSortMethod(args[2]), ELeaderboardDisplayType(args[3]))
+		}
+		return f.startCall(name, LeaderboardFindResult_t{SteamLeaderboard: l.handle, LeaderboardFound: true}), nil
+	case flatAPI_ISteamUserStats_GetLeaderboardName:
+		l := f.leaderboardByHandle(SteamLeaderboard_t(args[1]))
+		if l == nil {
+			return f.cString(""), nil
+		}
+		return f.cString(l.name), nil
+	case flatAPI_ISteamUserStats_GetLeaderboardEntryCount:
+		l := f.leaderboardByHandle(SteamLeaderboard_t(args[1]))
+		if l == nil {
+			return 0, nil
+		}
+		return uint64(len(l.entries)), nil
+	case flatAPI_ISteamUserStats_GetLeaderboardSortMethod:
+		l := f.leaderboardByHandle(SteamLeaderboard_t(args[1]))
+		if l == nil {
+			return 0, nil
+		}
+		return uint64(l.sortMethod), nil
+	case flatAPI_ISteamUserStats_GetLeaderboardDisplayType:
+		l := f.leaderboardByHandle(SteamLeaderboard_t(args[1]))
+		if l == nil {
+			return 0, nil
+		}
+		return uint64(l.displayType), nil
+	case flatAPI_ISteamUserStats_AttachLeaderboardUGC:
+		l := f.leaderboardByHandle(SteamLeaderboard_t(args[1]))
+		if l == nil {
+			return 0, nil
+		}
+		i := l.indexOf(f.SteamID)
+		if i < 0 {
+			return f.startCall(name, LeaderboardUGCSet_t{Result: EResult_Fail, SteamLeaderboard: l.handle}), nil
+		}
+		l.entries[i].ugc = UGCHandle_t(args[2])
+		return f.startCall(name, LeaderboardUGCSet_t{Result: EResult_OK, SteamLeaderboard: l.handle}), nil
+	case flatAPI_ISteamUserStats_DownloadLeaderboardEntries:
+		l := f.leaderboardByHandle(SteamLeaderboard_t(args[1]))
+		if l == nil {
+			return 0, nil
+		}
+		entries := l.download(ELeaderboardDataRequest(args[2]), int(int32(args[3])), int(int32(args[4])), f.SteamID)
+		return f.startDownload(name, l, entries), nil
+	case flatAPI_ISteamUserStats_DownloadLeaderboardEntriesForUsers:
+		l := f.leaderboardByHandle(SteamLeaderboard_t(args[1]))
+		if l == nil {
+			return 0, nil
+		}
+		users := make([]CSteamID, int(int32(args[3])))
+		for i := range users {
+			users[i] = *(*CSteamID)(unsafe.Add(fakePtr(args[2]), uintptr(i)*unsafe.Sizeof(CSteamID(0))))
+		}
+		return f.startDownload(name, l, l.forUsers(users)), nil
+	case flatAPI_ISteamUserStats_GetDownloadedLeaderboardEntry:
+		entries := f.downloadEntries[SteamLeaderboardEntries_t(args[1])]
+		i := int(int32(args[2]))
+		if i < 0 || i >= len(entries) {
+			return 0, nil
+		}
+		e := entries[i]
+		LeaderboardEntry_t{
+			SteamIDUser: e.steamID,
+			GlobalRank:  e.rank,
+			Score:       int(e.score),
+			Details:     len(e.details),
+			UGC:         e.ugc,
+		}.put(fakePtr(args[3]))
+		if n := int(int32(args[5])); n > 0 && args[4] != 0 {
+			copy(unsafe.Slice((*int32)(fakePtr(args[4])), n), e.details)
+		}
+		return 1, nil
+	case flatAPI_ISteamUserStats_UploadLeaderboardScore:
+		l := f.leaderboardByHandle(SteamLeaderboard_t(args[1]))
+		if l == nil {
+			return 0, nil
+		}
+		var details []int32
+		if n := int(int32(args[5])); n > 0 && args[4] != 0 {
+			details = append(details, unsafe.Slice((*int32)(fakePtr(args[4])), n)...)
+		}
+		return f.startCall(name, l.upload(f.SteamID, ELeaderboardUploadScoreMethod(args[2]), int32(args[3]), details)), nil
+
+	case flatAPI_ISteamUtils_IsOverlayEnabled:
+		return fakeBool(f.OverlayEnabled), nil
+	case flatAPI_ISteamUtils_BOverlayNeedsPresent:
+		return 0, nil
+	case flatAPI_ISteamUtils_SetOverlayNotificationPosition, flatAPI_ISteamUtils_SetOverlayNotificationInset:
+		// The fake shows no notifications.
+		return 0, nil
+	case flatAPI_ISteamUtils_GetSteamUILanguage:
+		if f.UILanguage != "" {
+			return f.cString(f.UILanguage), nil
+		}
+		return f.cString(f.Language), nil
+	case flatAPI_ISteamUtils_IsSteamRunningOnSteamDeck:
+		return fakeBool(f.SteamDeck), nil
+	case flatAPI_ISteamUtils_ShowFloatingGamepadTextInput:
+		return 0, nil
+	case flatAPI_ISteamUtils_GetAPICallResult:
+		return f.callResult(SteamAPICall_t(args[1]), args[2], int(int32(args[3])), iCallbackExpected(int32(args[4])), args[5]), nil
+	case flatAPI_ISteamUtils_GetImageSize:
+		img := f.image(int32(args[1]))
+		if img == nil {
+			return 0, nil
+		}
+		*(*uint32)(fakePtr(args[2])) = uint32(img.Bounds().Dx())
+		*(*uint32)(fakePtr(args[3])) = uint32(img.Bounds().Dy())
+		return 1, nil
+	case flatAPI_ISteamUtils_GetImageRGBA:
+		img := f.image(int32(args[1]))
+		if img == nil {
+			return 0, nil
+		}
+		b := img.Bounds()
+		dest := fakeBytes(args[2], int(int32(args[3])))
+		if len(dest) < 4*b.Dx()*b.Dy() {
+			return 0, nil
+		}
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+				i := 4 * ((y-b.Min.Y)*b.Dx() + x - b.Min.X)
+				dest[i], dest[i+1], dest[i+2], dest[i+3] = c.R, c.G, c.B, c.A
+			}
+		}
+		return 1, nil
+	case flatAPI_ISteamUtils_GetAPICallFailureReason:
+		call := SteamAPICall_t(args[1])
+		reason, ok := f.failures[call]
+		if !ok {
+			reason = ESteamAPICallFailure_InvalidHandle
+			if _, ok := f.calls[call]; ok {
+				reason = ESteamAPICallFailure_None
+			}
+		}
+		return uint64(uint32(reason)), nil
+	}
+
+	return 0, fmt.Errorf("steamworks: fake backend does not implement %s", name)
+}
+
+// startCall starts an asynchronous call of the function name that completes with result after Latency.
+func (f *Fake) startCall(name string, result callbackEncoder) uint64 {
+	failure, ok := f.callFailures[name]
+	if !ok {
+		failure = ESteamAPICallFailure_None
+	}
+	f.nextCall++
+	f.calls[f.nextCall] = &fakeCall{
+		id:      result.callbackExpected(),
+		data:    result.encode(),
+		ready:   time.Now().Add(f.Latency),
+		failure: failure,
+	}
+	return uint64(f.nextCall)
+}
+
+func (f *Fake) startDownload(name string, l *fakeLeaderboard, entries []fakeEntry) uint64 {
+	f.nextEntries++
+	f.downloadEntries[f.nextEntries] = entries
+	return f.startCall(name, LeaderboardScoresDownloaded_t{
+		SteamLeaderboard:        l.handle,
+		SteamLeaderboardEntries: f.nextEntries,
+		EntryCount:              len(entries),
+	})
+}
+
+// callResult implements GetAPICallResult: it copies a completed result to the buffer at p.
+func (f *Fake) callResult(call SteamAPICall_t, p uint64, size int, expected iCallbackExpected, failed uint64) uint64 {
+	pFailed := (*bool)(fakePtr(failed))
+
+	c, ok := f.calls[call]
+	if !ok {
+		*pFailed = true
+		if _, ok := f.failures[call]; !ok {
+			f.failures[call] = ESteamAPICallFailure_InvalidHandle
+		}
+		return 0
+	}
+	if time.Now().Before(c.ready) {
+		*pFailed = false
+		return 0
+	}
+
+	delete(f.calls, call)
+	switch {
+	case c.failure != ESteamAPICallFailure_None:
+		f.failures[call] = c.failure
+	case c.id != expected:
+		f.failures[call] = ESteamAPICallFailure_MismatchedCallback
+	default:
+		copy(fakeBytes(p, size), c.data)
+		*pFailed = false
+		return 1
+	}
+	*pFailed = true
+	return 1
+}
+
+// announceCalls queues SteamAPICallCompleted_t for the calls that have completed, as ManualDispatch_RunFrame does.
+func (f *Fake) announceCalls() {
+	var ready []SteamAPICall_t
+	now := time.Now()
+	for call, c := range f.calls {
+		if !c.announced && !now.Before(c.ready) {
+			ready = append(ready, call)
+		}
+	}
+	sort.Slice(ready, func(i, j int) bool {
+		return ready[i] < ready[j]
+	})
+	for _, call := range ready {
+		c := f.calls[call]
+		c.announced = true
+		f.post(SteamAPICallCompleted_t{
+			AsyncCall: call,
+			Callback:  c.id,
+			ParamSize: uint32(len(c.data)),
+		})
+	}
+}
+
+func (f *Fake) achievement(name string) *fakeAchievement {
+	for _, a := range f.achievements {
+		if a.APIName == name {
+			return a
+		}
+	}
+	return nil
+}
+
+func (f *Fake) defineAchievement(name string) *fakeAchievement {
+	if a := f.achievement(name); a != nil {
+		return a
+	}
+	a := &fakeAchievement{FakeAchievement: FakeAchievement{APIName: name, Name: name}}
+	f.achievements = append(f.achievements, a)
+	return a
+}
+
+func (a *fakeAchievement) set(achieved bool) {
+	if achieved && !a.achieved {
+		a.unlockTime = time.Now()
+	}
+	a.achieved = achieved
+}
+
+// mostAchieved writes the achievement at position i in order of Percent, for GetMostAchievedAchievementInfo.
+func (f *Fake) mostAchieved(i int, name uint64, size int, percent, achieved uint64) uint64 {
+	sorted := append([]*fakeAchievement(nil), f.achievements...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Percent > sorted[j].Percent
+	})
+	if i < 0 || i >= len(sorted) {
+		return uint64(uint32(0xffffffff))
+	}
+	a := sorted[i]
+	fakePutString(name, size, a.APIName)
+	*(*float32)(fakePtr(percent)) = a.Percent
+	*(*bool)(fakePtr(achieved)) = a.achieved
+	return uint64(i)
+}
+
+func (f *Fake) writeFile(name string, data []byte) {
+	f.files[name] = &fakeFile{
+		data:      append([]byte(nil), data...),
+		modTime:   time.Now(),
+		persisted: f.CloudEnabledForAccount && f.cloudForApp,
+		platforms: ERemoteStoragePlatform_All,
+	}
+}
+
+func (f *Fake) fileData(name string) []byte {
+	if file, ok := f.files[name]; ok {
+		return file.data
+	}
+	return nil
+}
+
+// fits reports whether data can replace the file name within the quota.
+func (f *Fake) fits(name string, data []byte) bool {
+	_, available := f.quota()
+	return uint64(len(data)) <= available+uint64(len(f.fileData(name)))
+}
+
+// fileNames returns the names of the files in a stable order, for GetFileNameAndSize.
+func (f *Fake) fileNames() []string {
+	names := make([]string, 0, len(f.files))
+	for name := range f.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (f *Fake) quota() (total, available uint64) {
+	var used uint64
+	for _, file := range f.files {
+		if file.persisted {
+			used += uint64(len(file.data))
+		}
+	}
+	if used > f.CloudQuota {
+		return f.CloudQuota, 0
+	}
+	return f.CloudQuota, f.CloudQuota - used
+}
+
+func (f *Fake) addUGC(name string, data []byte, owner CSteamID) UGCHandle_t {
+	ugc := UGCHandle_t(len(f.ugcs) + 1)
+	f.ugcs[ugc] = &fakeUGC{name: name, data: append([]byte(nil), data...), owner: owner}
+	return ugc
+}
+
+// addImage returns a new image handle for img. Handles start at 1, since 0 means no image.
+func (f *Fake) addImage(img image.Image) int32 {
+	f.images = append(f.images, img)
+	return int32(len(f.images))
+}
+
+func (f *Fake) image(handle int32) image.Image {
+	if handle <= 0 || int(handle) > len(f.images) {
+		return nil
+	}
+	return f.images[handle-1]
+}
+
+// openOverlay opens the overlay at what, and posts GameOverlayActivated_t if it was closed.
+func (f *Fake) openOverlay(what string) {
+	if !f.OverlayEnabled {
+		return
+	}
+	opened := f.overlay == ""
+	f.overlay = what
+	if opened {
+		f.post(GameOverlayActivated_t{Active: true, AppID: f.AppID})
+	}
+}
+
+func (f *Fake) friend(id CSteamID) *fakeFriend {
+	for _, ff := range f.friends {
+		if ff.SteamID == id {
+			return ff
+		}
+	}
+	return nil
+}
+
+// friendsWith returns the friends whose relationship is in flags, as GetFriendByIndex indexes them.
+func (f *Fake) friendsWith(flags EFriendFlags) []*fakeFriend {
+	var friends []*fakeFriend
+	for _, ff := range f.friends {
+		var flag EFriendFlags
+		switch ff.Relationship {
+		case EFriendRelationship_Blocked:
+			flag = EFriendFlag_Blocked
+		case EFriendRelationship_RequestRecipient:
+			flag = EFriendFlag_FriendshipRequested
+		case EFriendRelationship_Friend:
+			flag = EFriendFlag_Immediate
+		case EFriendRelationship_RequestInitiator:
+			flag = EFriendFlag_RequestingFriendship
+		case EFriendRelationship_Ignored:
+			flag = EFriendFlag_Ignored
+		case EFriendRelationship_IgnoredFriend:
+			flag = EFriendFlag_IgnoredFriend
+		}
+		if flags&flag != 0 {
+			friends = append(friends, ff)
+		}
+	}
+	return friends
+}
+
+// finishDownloads installs the DLC InstallDLC has downloaded for Latency, and posts DlcInstalled_t for them.
+func (f *Fake) finishDownloads() {
+	for _, d := range f.dlcs {
+		if d.download.IsZero() || time.Since(d.download) < f.Latency {
+			continue
+		}
+		d.installed = true
+		d.download = time.Time{}
+		f.post(DlcInstalled_t{AppID: d.appID})
+	}
+}
+
+func (f *Fake) dlc(appID AppId_t) *fakeDLC {
+	for _, d := range f.dlcs {
+		if d.appID == appID {
+			return d
+		}
+	}
+	return nil
+}
+
+func (f *Fake) workshopItem(id PublishedFileId_t) *fakeWorkshopItem {
+	for _, item := range f.workshop {
+		if item.ID == id {
+			return item
+		}
+	}
+	return nil
+}
+
+func (f *Fake) addWorkshopItem(item FakeWorkshopItem) *fakeWorkshopItem {
+	if item.ID == 0 {
+		item.ID = PublishedFileId_t(len(f.workshop) + 1)
+		for f.workshopItem(item.ID) != nil {
+			item.ID++
+		}
+	}
+	if item.Created.IsZero() {
+		item.Created = time.Now()
+	}
+	if item.Updated.IsZero() {
+		item.Updated = item.Created
+	}
+	w := &fakeWorkshopItem{FakeWorkshopItem: item}
+	f.workshop = append(f.workshop, w)
+	return w
+}
+
+// dirSize returns the total size of the files in the folder dir, the way SetItemContent uploads it.
+func dirSize(dir string) (uint64, error) {
+	var size uint64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += uint64(info.Size())
+		return nil
+	})
+	return size, err
+}
+
+func (f *Fake) subscribedItems() []*fakeWorkshopItem {
+	var items []*fakeWorkshopItem
+	for _, item := range f.workshop {
+		if item.subscribed {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (f *Fake) addQuery(q *fakeUGCQuery) UGCQueryHandle_t {
+	f.nextQuery++
+	f.ugcQueries[f.nextQuery] = q
+	return f.nextQuery
+}
+
+// queryResult returns result index of the query, for the GetQueryUGC functions.
+func (f *Fake) queryResult(query, index uint64) (*fakeWorkshopItem, *fakeUGCQuery) {
+	q, ok := f.ugcQueries[UGCQueryHandle_t(query)]
+	if !ok {
+		return nil, nil
+	}
+	i := int(uint32(index))
+	if i >= len(q.results) {
+		return nil, q
+	}
+	return q.results[i], q
+}
+
+// queryWorkshop returns the items matching q in the order they were added.
+func (f *Fake) queryWorkshop(q *fakeUGCQuery) []*fakeWorkshopItem {
+	if q.ids != nil {
+		var items []*fakeWorkshopItem
+		for _, id := range q.ids {
+			if item := f.workshopItem(id); item != nil {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+
+	var items []*fakeWorkshopItem
+	for _, item := range f.workshop {
+		if q.user != 0 {
+			switch q.list {
+			case EUserUGCList_Published:
+				if item.Owner.AccountID() != q.user {
+					continue
+				}
+			case EUserUGCList_Subscribed:
+				if !item.subscribed || f.SteamID.AccountID() != q.user {
+					continue
+				}
+			default:
+				continue
+			}
+		} else if item.Visibility != ERemoteStoragePublishedFileVisibility_Public && item.Owner != f.SteamID {
+			continue
+		}
+		if !q.matches(item) {
+			continue
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func (q *fakeUGCQuery) matches(item *fakeWorkshopItem) bool {
+	if len(q.required) > 0 {
+		n := 0
+		for _, tag := range q.required {
+			if slices.Contains(item.Tags, tag) {
+				n++
+			}
+		}
+		if n == 0 || !q.matchAnyTag && n < len(q.required) {
+			return false
+		}
+	}
+	for _, tag := range q.excluded {
+		if slices.Contains(item.Tags, tag) {
+			return false
+		}
+	}
+	if q.searchText != "" {
+		text := strings.ToLower(q.searchText)
+		if !strings.Contains(strings.ToLower(item.Title), text) && !strings.Contains(strings.ToLower(item.Description), text) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *Fake) leaderboard(name string) *fakeLeaderboard {
+	for _, l := range f.leaderboards {
+		if l.name == name {
+			return l
+		}
+	}
+	return nil
+}
+
+func (f *Fake) leaderboardByHandle(handle SteamLeaderboard_t) *fakeLeaderboard {
+	for _, l := range f.leaderboards {
+		if l.handle == handle {
+			return l
+		}
+	}
+	return nil
+}
+
+func (f *Fake) createLeaderboard(name string, sortMethod ELeaderboardSortMethod, displayType ELeaderboardDisplayType) *fakeLeaderboard {
+	l := &fakeLeaderboard{
+		handle:      SteamLeaderboard_t(len(f.leaderboards) + 1),
+		name:        name,
+		sortMethod:  sortMethod,
+		displayType: displayType,
+	}
+	f.leaderboards = append(f.leaderboards, l)
+	return l
+}
+
+// cString returns a C string with the content s that stays valid as long as the fake does.
+func (f *Fake) cString(s string) uint64 {
+	b, ok := f.cStrings[s]
+	if !ok {
+		b = append([]byte(s), 0)
+		f.cStrings[s] = b
+	}
+	return uint64(uintptr(unsafe.Pointer(&b[0])))
+}
+
+func (l *fakeLeaderboard) set(user CSteamID, score int32, details []int32) {
+	for i := range l.entries {
+		if l.entries[i].steamID == user {
+			l.entries[i].score = score
+			l.entries[i].details = details
+			l.rank()
+			return
+		}
+	}
+	l.entries = append(l.entries, fakeEntry{steamID: user, score: score, details: details})
+	l.rank()
+}
+
+// rank sorts the entries and numbers them from 1.
+func (l *fakeLeaderboard) rank() {
+	sort.SliceStable(l.entries, func(i, j int) bool {
+		a, b := l.entries[i], l.entries[j]
+		if a.score != b.score {
+			if l.sortMethod == ELeaderboardSortMethod_Ascending {
+				return a.score < b.score
+			}
+			return a.score > b.score
+		}
+		return a.steamID < b.steamID
+	})
+	for i := range l.entries {
+		l.entries[i].rank = i + 1
+	}
+}
+
+func (l *fakeLeaderboard) indexOf(user CSteamID) int {
+	for i, e := range l.entries {
+		if e.steamID == user {
+			return i
+		}
+	}
+	return -1
+}
+
+func (l *fakeLeaderboard) download(request ELeaderboardDataRequest, start, end int, user CSteamID) []fakeEntry {
+	var from, to int
+	switch request {
+	case ELeaderboardDataRequestGlobal:
+		from, to = start-1, end
+	case ELeaderboardDataRequestGlobalAroundUser:
+		i := l.indexOf(user)
+		if i < 0 {
+			return nil
+		}
+		from, to = i+start, i+end+1
+	case ELeaderboardDataRequestFriends:
+		// The fake user has no friends, and Steam always includes the user.
+		return l.forUsers([]CSteamID{user})
+	default:
+		return nil
+	}
+	from = max(from, 0)
+	to = min(to, len(l.entries))
+	if from >= to {
+		return nil
+	}
+	return append([]fakeEntry(nil), l.entries[from:to]...)
+}
+
+func (l *fakeLeaderboard) forUsers(users []CSteamID) []fakeEntry {
+	var entries []fakeEntry
+	for _, e := range l.entries {
+		for _, u := range users {
+			if e.steamID == u {
+				entries = append(entries, e)
+				break
+			}
+		}
+	}
+	return entries
+}
+
+func (l *fakeLeaderboard) upload(user CSteamID, method ELeaderboardUploadScoreMethod, score int32, details []int32) LeaderboardScoreUploaded_t {
+	ret := LeaderboardScoreUploaded_t{
+		Success:          true,
+		SteamLeaderboard: l.handle,
+		Score:            int(score),
+	}
+	if i := l.indexOf(user); i >= 0 {
+		e := l.entries[i]
+		ret.GlobalRankPrevious = e.rank
+		better := score > e.score
+		if l.sortMethod == ELeaderboardSortMethod_Ascending {
+			better = score < e.score
+		}
+		if method == ELeaderboardUploadScoreMethod_KeepBest && !better {
+			ret.GlobalRankNew = e.rank
+			return ret
+		}
+	}
+	l.set(user, score, details)
+	ret.ScoreChanged = true
+	ret.GlobalRankNew = l.entries[l.indexOf(user)].rank
+	return ret
+}
+
+// fakePtr turns an argument back into the pointer the caller converted it from.
+func fakePtr(p uint64) unsafe.Pointer {
+	q := uintptr(p)
+	return *(*unsafe.Pointer)(unsafe.Pointer(&q))
+}
+
+func fakeBytes(p uint64, n int) []byte {
+	if p == 0 || n <= 0 {
+		return nil
+	}
+	return unsafe.Slice((*byte)(fakePtr(p)), n)
+}
+
+func fakeString(p uint64) string {
+	if p == 0 {
+		return ""
+	}
+	var b []byte
+	for ptr := fakePtr(p); *(*byte)(ptr) != 0; ptr = unsafe.Add(ptr, 1) {
+		b = append(b, *(*byte)(ptr))
+	}
+	return string(b)
+}
+
+// fakePutString copies s to the buffer of size bytes at p as a C string and returns the bytes written, including the NUL.
+func fakePutString(p uint64, size int, s string) int {
+	b := fakeBytes(p, size)
+	if len(b) == 0 {
+		return 0
+	}
+	n := copy(b[:len(b)-1], s)
+	b[n] = 0
+	return n + 1
+}
+
+func fakeBool(x bool) uint64 {
+	if x {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TaiJiYu/go-steamworks"
+)
+
+// startFake installs fake and initializes the API against it. The backend is reset when the test ends.
+func startFake(t *testing.T, fake *steamworks.Fake) {
+	t.Helper()
+	steamworks.SetBackend(fake)
+	t.Cleanup(func() { steamworks.SetBackend(nil) })
+	if err := steamworks.Init(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// receive waits for a value from ch, failing the test if none arrives.
+func receive[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out")
+		panic("unreachable")
+	}
+}
+
+func TestFakeInitResult(t *testing.T) {
+	fake := steamworks.NewFake()
+	fake.InitResult = steamworks.ESteamAPIInitResult_NoSteamClient
+	steamworks.SetBackend(fake)
+	defer steamworks.SetBackend(nil)
+	if err := steamworks.Init(); err == nil {
+		t.Fatal("Init succeeded")
+	}
+}
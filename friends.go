@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks
+
+// Friend describes a user in the friends list, as returned by Friends.
+type Friend struct {
+	SteamID      CSteamID
+	Name         string
+	State        EPersonaState
+	Relationship EFriendRelationship
+
+	// Game is the game the friend is playing, if InGame is set.
+	Game   FriendGameInfo_t
+	InGame bool
+}
+
+// Friends returns the users the user has a relationship in flags with, skipping any Steam no longer
+// lists by the time it is asked about them. It returns nil if the user has none or is not logged on.
+func (s steamFriends) Friends(flags EFriendFlags) []Friend {
+	n := s.GetFriendCount(flags)
+	if n <= 0 {
+		// GetFriendCount returns -1 when the user is not logged on.
+		return nil
+	}
+	friends := make([]Friend, 0, n)
+	for i := 0; i < n; i++ {
+		id := s.GetFriendByIndex(i, flags)
+		if id == 0 {
+			continue
+		}
+		game, inGame := s.GetFriendGamePlayed(id)
+		friends = append(friends, Friend{
+			SteamID:      id,
+			Name:         s.GetFriendPersonaName(id),
+			State:        s.GetFriendPersonaState(id),
+			Relationship: s.GetFriendRelationship(id),
+			Game:         game,
+			InGame:       inGame,
+		})
+	}
+	return friends
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks_test
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/TaiJiYu/go-steamworks"
+)
+
+func TestFriends(t *testing.T) {
+	fake := steamworks.NewFake()
+	avatar := image.NewRGBA(image.Rect(0, 0, 184, 184))
+	avatar.SetRGBA(1, 1, color.RGBA{0xff, 0, 0, 0xff})
+	fake.SetFriend(steamworks.FakeFriend{SteamID: 100, Name: "Alice", State: steamworks.EPersonaState_Online, Avatar: avatar,
+		Game: steamworks.FriendGameInfo_t{GameID: 480, SteamIDLobby: 109775240000000001}})
+	fake.SetFriend(steamworks.FakeFriend{SteamID: 101, Name: "Bob"})
+	fake.SetFriend(steamworks.FakeFriend{SteamID: 102, Name: "Eve", Relationship: steamworks.EFriendRelationship_Blocked})
+	startFake(t, fake)
+	friends := steamworks.SteamFriends()
+
+	got := friends.Friends(steamworks.EFriendFlag_Immediate)
+	if len(got) != 2 {
+		t.Fatalf("Friends() = %+v, want Alice and Bob", got)
+	}
+	if alice := got[0]; alice.SteamID != 100 || alice.Name != "Alice" || alice.State != steamworks.EPersonaState_Online ||
+		!alice.InGame || alice.Game.AppID() != 480 || alice.Game.SteamIDLobby != 109775240000000001 {
+		t.Errorf("Friends()[0] = %+v, want Alice in app 480", alice)
+	}
+	if bob := got[1]; bob.Name != "Bob" || bob.InGame || bob.Relationship != steamworks.EFriendRelationship_Friend {
+		t.Errorf("Friends()[1] = %+v, want Bob not in a game", bob)
+	}
+	if n := friends.GetFriendCount(steamworks.EFriendFlag_All); n != 3 {
+		t.Errorf("GetFriendCount(All) = %d, want 3", n)
+	}
+
+	img, err := steamworks.ImageRGBA(friends.GetLargeFriendAvatar(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds() != avatar.Bounds() || img.RGBAAt(1, 1) != avatar.RGBAAt(1, 1) {
+		t.Errorf("avatar is %v with %v at (1, 1), want %v with %v", img.Bounds(), img.RGBAAt(1, 1), avatar.Bounds(), avatar.RGBAAt(1, 1))
+	}
+	if _, err := steamworks.ImageRGBA(friends.GetLargeFriendAvatar(101)); err == nil {
+		t.Error("ImageRGBA succeeded for a friend without an avatar")
+	}
+
+	// Requesting avatars posts changes too, which only carry the name and avatar.
+	changes := make(chan steamworks.PersonaStateChange_t, 1)
+	defer steamworks.Subscribe(func(ev steamworks.PersonaStateChange_t) {
+		if ev.ChangeFlags&steamworks.EPersonaChange_Status != 0 {
+			changes <- ev
+		}
+	})()
+	fake.SetFriend(steamworks.FakeFriend{SteamID: 101, Name: "Bobby", State: steamworks.EPersonaState_Away})
+	want := steamworks.EPersonaChange_Name | steamworks.EPersonaChange_Status | steamworks.EPersonaChange_ComeOnline
+	if ev := receive(t, changes); ev.SteamID != 101 || ev.ChangeFlags != want {
+		t.Errorf("PersonaStateChange_t = %+v, want flags %v for 101", ev, want)
+	}
+}
+
+// loggedOff is a backend whose user is not logged on, so GetFriendCount returns -1.
+type loggedOff struct {
+	*steamworks.Fake
+}
+
+func (b loggedOff) Call(name string, args ...uint64) (uint64, error) {
+	if name == "SteamAPI_ISteamFriends_GetFriendCount" {
+		return math.MaxUint64, nil
+	}
+	return b.Fake.Call(name, args...)
+}
+
+func TestFriendsLoggedOff(t *testing.T) {
+	steamworks.SetBackend(loggedOff{steamworks.NewFake()})
+	t.Cleanup(func() { steamworks.SetBackend(nil) })
+	if err := steamworks.Init(); err != nil {
+		t.Fatal(err)
+	}
+	if got := steamworks.SteamFriends().Friends(steamworks.EFriendFlag_Immediate); got != nil {
+		t.Errorf("Friends() = %+v, want nil", got)
+	}
+}
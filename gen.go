@@ -7,13 +7,25 @@ package main
 
 import (
 	"archive/zip"
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
 	"io"
+	"maps"
+	"math"
 	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 )
 
-const version = "161"
-
 func main() {
 	if err := run(); err != nil {
 		panic(err)
@@ -27,31 +39,41 @@ func run() error {
 	}
 	defer os.RemoveAll(dir)
 
-	if err := processZip(dir); err != nil {
+	hw, err := packageDecls(".")
+	if err != nil {
 		return err
 	}
+	// SDKVersion in load.go is the only place the SDK version is written down.
+	version, ok := stringValue(hw.values["SDKVersion"])
+	if !ok {
+		return fmt.Errorf("gen: SDKVersion is not a string constant")
+	}
 
-	return nil
+	api, err := processZip(dir, version)
+	if err != nil {
+		return err
+	}
+	return generate(api, version, hw)
 }
 
-func processZip(dir string) error {
+func processZip(dir, version string) (*steamAPI, error) {
 	zipfile, err := os.Open(fmt.Sprintf("steamworks_sdk_%s.zip", version))
 	if err != nil {
 		if os.IsNotExist(err) {
-			const sdkURL = "https://partner.steamgames.com/downloads/steamworks_sdk_" + version + ".zip"
-			return fmt.Errorf("steamworks_sdk.zip must exist; download it from %s with your Steamworks account", sdkURL)
+			sdkURL := "https://partner.steamgames.com/downloads/steamworks_sdk_" + version + ".zip"
+			return nil, fmt.Errorf("steamworks_sdk_%s.zip must exist; download it from %s with your Steamworks account, or change SDKVersion in load.go", version, sdkURL)
 		}
-		return err
+		return nil, err
 	}
 	defer zipfile.Close()
 
 	stat, err := zipfile.Stat()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	r, err := zip.NewReader(zipfile, stat.Size())
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for path, filename := range map[string]string{
@@ -63,20 +85,1221 @@ func processZip(dir string) error {
 	} {
 		f, err := r.Open(path)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		defer f.Close()
 
 		var out io.Writer
 		out, err = os.Create(filename)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		if _, err := io.Copy(out, f); err != nil {
-			return err
+			return nil, err
+		}
+	}
+
+	f, err := r.Open("sdk/public/steam/steam_api.json")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var api steamAPI
+	if err := json.NewDecoder(f).Decode(&api); err != nil {
+		return nil, err
+	}
+	return &api, nil
+}
+
+// steamAPI is the subset of the SDK's steam_api.json used by the generator.
+type steamAPI struct {
+	CallbackStructs []apiStruct    `json:"callback_structs"`
+	Enums           []apiEnum      `json:"enums"`
+	Interfaces      []apiInterface `json:"interfaces"`
+	Typedefs        []apiTypedef   `json:"typedefs"`
+}
+
+type apiStruct struct {
+	CallbackID int        `json:"callback_id"`
+	Name       string     `json:"struct"`
+	Fields     []apiField `json:"fields"`
+}
+
+type apiField struct {
+	Name string `json:"fieldname"`
+	Type string `json:"fieldtype"`
+}
+
+type apiEnum struct {
+	Name   string         `json:"enumname"`
+	Values []apiEnumValue `json:"values"`
+}
+
+type apiEnumValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type apiInterface struct {
+	ClassName string        `json:"classname"`
+	Accessors []apiAccessor `json:"accessors"`
+	Methods   []apiMethod   `json:"methods"`
+}
+
+type apiAccessor struct {
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	NameFlat string `json:"name_flat"`
+}
+
+type apiMethod struct {
+	Name       string     `json:"methodname"`
+	NameFlat   string     `json:"methodname_flat"`
+	Params     []apiParam `json:"params"`
+	ReturnType string     `json:"returntype"`
+}
+
+type apiParam struct {
+	Name string `json:"paramname"`
+	Type string `json:"paramtype"`
+}
+
+type apiTypedef struct {
+	Name string `json:"typedef"`
+	Type string `json:"type"`
+}
+
+// generate writes the declarations found in steam_api.json that are not yet hand-written in the package,
+// and then checks the hand-written ones against it. Hand-written declarations always win, so the generated
+// files only fill the gaps.
+func generate(api *steamAPI, version string, hw *handWritten) error {
+	g := &generator{
+		api:       api,
+		version:   version,
+		hw:        hw,
+		decls:     maps.Clone(hw.decls),
+		flatNames: map[string]bool{},
+		typedefs:  map[string]string{},
+		enums:     map[string]bool{},
+	}
+	for _, v := range hw.values {
+		if s, ok := stringValue(v); ok && strings.HasPrefix(s, "SteamAPI_") {
+			g.flatNames[s] = true
+		}
+	}
+	for _, t := range api.Typedefs {
+		g.typedefs[t.Name] = t.Type
+	}
+	for _, e := range api.Enums {
+		// Enums nested in a class, such as ISteamHTMLSurface::EHTMLMouseButton, are passed as plain integers.
+		if !strings.Contains(e.Name, "::") {
+			g.enums[e.Name] = true
+		}
+	}
+	g.genClasses()
+
+	if err := writeGoFile("steamworks_gen.go", g.genDecls()); err != nil {
+		return err
+	}
+	if err := writeGoFile("steamworks_gen_unix.go", g.genBackend(false)); err != nil {
+		return err
+	}
+	if err := writeGoFile("steamworks_gen_windows.go", g.genBackend(true)); err != nil {
+		return err
+	}
+	if err := writeGoFile("struct_gen.go", g.genStructs()); err != nil {
+		return err
+	}
+	return g.check()
+}
+
+// handWritten holds the declarations of the package's hand-written files.
+type handWritten struct {
+	// decls holds the top-level identifiers.
+	decls map[string]bool
+	// values and types hold the values and the types of the constants, e.g. EFriendFlags for EFriendFlag_None.
+	values map[string]ast.Expr
+	types  map[string]string
+	// underlying holds the types declared as another named type, e.g. uint32 for AppId_t.
+	underlying map[string]string
+	// methods holds the method names of each receiver type, e.g. GetSteamID of steamUser.
+	methods map[string]map[string]bool
+}
+
+func packageDecls(dir string) (*handWritten, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		name := fi.Name()
+		return name != "gen.go" && !isGenerated(name) && !strings.HasSuffix(name, "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	hw := &handWritten{
+		decls:      map[string]bool{},
+		values:     map[string]ast.Expr{},
+		types:      map[string]string{},
+		underlying: map[string]string{},
+		methods:    map[string]map[string]bool{},
+	}
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			for _, d := range f.Decls {
+				if fd, ok := d.(*ast.FuncDecl); ok {
+					if fd.Recv == nil {
+						continue
+					}
+					recv := fd.Recv.List[0].Type
+					if star, ok := recv.(*ast.StarExpr); ok {
+						recv = star.X
+					}
+					if hw.methods[identName(recv)] == nil {
+						hw.methods[identName(recv)] = map[string]bool{}
+					}
+					hw.methods[identName(recv)][fd.Name.Name] = true
+					continue
+				}
+				gd, ok := d.(*ast.GenDecl)
+				if !ok {
+					continue
+				}
+				// A constant without a type or a value repeats the type of the one before it.
+				var typ string
+				for _, spec := range gd.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						hw.decls[spec.Name.Name] = true
+						if u := identName(spec.Type); u != "" {
+							hw.underlying[spec.Name.Name] = u
+						}
+					case *ast.ValueSpec:
+						if gd.Tok == token.CONST {
+							switch {
+							case spec.Type != nil:
+								typ = identName(spec.Type)
+							case len(spec.Values) > 0:
+								typ = conversionType(spec.Values[0])
+							}
+						}
+						for i, n := range spec.Names {
+							hw.decls[n.Name] = true
+							if gd.Tok != token.CONST {
+								continue
+							}
+							if i < len(spec.Values) {
+								hw.values[n.Name] = spec.Values[i]
+							}
+							if typ != "" {
+								hw.types[n.Name] = typ
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return hw, nil
+}
+
+// isGenerated reports whether the file name is one of those gen.go writes, such as steamworks_gen_unix.go.
+func isGenerated(name string) bool {
+	return strings.HasSuffix(name, "_gen.go") || strings.Contains(name, "_gen_")
+}
+
+func identName(e ast.Expr) string {
+	if id, ok := e.(*ast.Ident); ok {
+		return id.Name
+	}
+	return ""
+}
+
+// conversionType returns T for a constant declared as T(value), e.g. iCallbackExpected(1101).
+func conversionType(e ast.Expr) string {
+	if call, ok := e.(*ast.CallExpr); ok && len(call.Args) == 1 {
+		return identName(call.Fun)
+	}
+	return ""
+}
+
+// stringValue returns the value of a constant declared as a string literal.
+func stringValue(e ast.Expr) (string, bool) {
+	lit, ok := e.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	return s, err == nil
+}
+
+func writeGoFile(filename string, src []byte) error {
+	formatted, err := format.Source(src)
+	if err != nil {
+		return fmt.Errorf("gen: formatting %s: %w", filename, err)
+	}
+	return os.WriteFile(filepath.Clean(filename), formatted, 0644)
+}
+
+type generator struct {
+	api     *steamAPI
+	version string
+	hw      *handWritten
+	// decls holds the hand-written declarations and, as generation goes on, the generated ones.
+	decls map[string]bool
+	// flatNames holds the flat API functions that already have a hand-written name, e.g. flatAPI_SteamFriends.
+	flatNames map[string]bool
+	typedefs  map[string]string
+	enums     map[string]bool
+	classes   []genClass
+}
+
+func (g *generator) header(buf *bytes.Buffer) {
+	fmt.Fprintf(buf, "// Code generated by gen.go from steamworks_sdk_%s.zip; DO NOT EDIT.\n\n", g.version)
+	buf.WriteString("package steamworks\n\n")
+}
+
+// genDecls emits the flat API function names, enum types, callback IDs and the interfaces of the generated methods.
+func (g *generator) genDecls() []byte {
+	var buf bytes.Buffer
+	g.header(&buf)
+
+	var consts bytes.Buffer
+	for _, i := range g.api.Interfaces {
+		for _, a := range i.Accessors {
+			if !g.flatNames[a.NameFlat] {
+				g.constLine(&consts, "flatAPI_"+a.Name, strconv.Quote(a.NameFlat))
+			}
+		}
+		for _, m := range i.Methods {
+			if !g.flatNames[m.NameFlat] {
+				g.constLine(&consts, "flatAPI_"+strings.TrimPrefix(m.NameFlat, "SteamAPI_"), strconv.Quote(m.NameFlat))
+			}
+		}
+	}
+	constBlock(&buf, &consts)
+
+	for _, e := range g.api.Enums {
+		if !g.enums[e.Name] {
+			continue
+		}
+		if !g.decls[e.Name] {
+			fmt.Fprintf(&buf, "type %s %s\n\n", e.Name, enumBaseType(e))
+		}
+		// Values missing from a hand-written enum are added to it under the same naming.
+		consts.Reset()
+		names := enumValueNames(e)
+		for i, v := range e.Values {
+			if _, ok := g.handWrittenValue(e.Name, names[i], v); !ok {
+				g.constLine(&consts, names[i], e.Name+"("+v.Value+")")
+			}
+		}
+		constBlock(&buf, &consts)
+	}
+
+	consts.Reset()
+	for _, s := range g.api.CallbackStructs {
+		g.constLine(&consts, "iCallbackExpected_"+s.Name, fmt.Sprintf("iCallbackExpected(%d)", s.CallbackID))
+	}
+	constBlock(&buf, &consts)
+
+	for _, c := range g.classes {
+		if c.accessor == "" {
+			fmt.Fprintf(&buf, "// %s holds the %s methods that are not hand-written yet. %s() implements it.\n", c.iface, c.name, strings.TrimPrefix(c.name, "I"))
+		} else {
+			fmt.Fprintf(&buf, "// %s is the interface %s returns.\n", c.iface, c.accessor)
+		}
+		fmt.Fprintf(&buf, "type %s interface {\n", c.iface)
+		for _, m := range c.methods {
+			fmt.Fprintf(&buf, "\t%s\n", m.signature())
+		}
+		buf.WriteString("}\n\n")
+	}
+
+	return buf.Bytes()
+}
+
+func constBlock(buf, consts *bytes.Buffer) {
+	if consts.Len() == 0 {
+		return
+	}
+	buf.WriteString("const (\n")
+	buf.Write(consts.Bytes())
+	buf.WriteString(")\n\n")
+}
+
+func (g *generator) constLine(buf *bytes.Buffer, name, value string) {
+	if g.decls[name] {
+		return
+	}
+	g.decls[name] = true
+	fmt.Fprintf(buf, "\t%s = %s\n", name, value)
+}
+
+// enumBaseType returns the smallest Go integer type holding every value of e.
+// Most enums fit int32, but flag enums like ERemoteStoragePlatform use the full uint32 range.
+func enumBaseType(e apiEnum) string {
+	var min, max int64
+	for _, v := range e.Values {
+		n, err := strconv.ParseInt(v.Value, 0, 64)
+		if err != nil {
+			continue
+		}
+		if n < min {
+			min = n
 		}
+		if n > max {
+			max = n
+		}
+	}
+	switch {
+	case max <= math.MaxInt32:
+		return "int32"
+	case min >= 0 && max <= math.MaxUint32:
+		return "uint32"
+	default:
+		return "int64"
+	}
+}
+
+// enumValueNames returns the Go names of the values of e in the Enum_Value form of the hand-written enums:
+// k_ELeaderboardSortMethodAscending becomes ELeaderboardSortMethod_Ascending. Values named after the enum
+// in the singular keep that name, so k_EFriendFlagNone of EFriendFlags becomes EFriendFlag_None, and values
+// named otherwise take the enum's name, so k_EPositionTopLeft of ENotificationPosition becomes
+// ENotificationPosition_TopLeft. Values with an underscore of their own, such as k_EUGCRead_Close, keep it.
+func enumValueNames(e apiEnum) []string {
+	values := make([]string, len(e.Values))
+	for i, v := range e.Values {
+		values[i] = strings.TrimPrefix(v.Name, "k_")
 	}
+	prefix := wordPrefix(values)
 
+	names := make([]string, len(values))
+	for i, v := range values {
+		switch rest, ok := strings.CutPrefix(v, e.Name); {
+		case ok && rest != "":
+			names[i] = e.Name + "_" + strings.TrimPrefix(rest, "_")
+		case strings.Contains(v, "_"):
+			names[i] = v
+		case prefix != "" && strings.HasPrefix(e.Name, prefix):
+			names[i] = prefix + "_" + v[len(prefix):]
+		default:
+			names[i] = e.Name + "_" + v[len(prefix):]
+		}
+	}
+	return names
+}
+
+// wordPrefix returns the longest prefix of whole CamelCase words the values share, leaving each at least
+// one word of its own: "EPosition" for EPositionTopLeft and EPositionBottomRight.
+func wordPrefix(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	n := len(values[0])
+	for _, v := range values[1:] {
+		n = min(n, len(v))
+		for i := 0; i < n; i++ {
+			if v[i] != values[0][i] {
+				n = i
+				break
+			}
+		}
+	}
+	for ; n > 0; n-- {
+		atWord := true
+		for _, v := range values {
+			if n == len(v) || v[n] < 'A' || v[n] > 'Z' {
+				atWord = false
+				break
+			}
+		}
+		if atWord {
+			return values[0][:n]
+		}
+	}
+	return ""
+}
+
+// handWrittenValue returns the hand-written constant of enum for the SDK value v, named either name or as
+// in the SDK, such as ELeaderboardDataRequestGlobal.
+func (g *generator) handWrittenValue(enum, name string, v apiEnumValue) (string, bool) {
+	for _, n := range []string{name, strings.TrimPrefix(v.Name, "k_")} {
+		if g.hw.types[n] == enum {
+			return n, true
+		}
+	}
+	return "", false
+}
+
+// cField describes how a callback struct field is declared in C and decoded in Go.
+type cField struct {
+	cDecl  string
+	goName string
+	goType string
+	decode string
+}
+
+var arrayType = regexp.MustCompile(`^(.*\S)\s*\[(\d+)\]$`)
+
+// resolve follows typedefs down to a C base type.
+func (g *generator) resolve(t string) string {
+	for {
+		u, ok := g.typedefs[t]
+		if !ok {
+			return t
+		}
+		t = u
+	}
+}
+
+func (g *generator) field(f apiField) (cField, bool) {
+	name := goFieldName(f.Name)
+	goType := func(fallback string) string {
+		if g.decls[f.Type] || g.enums[f.Type] {
+			return f.Type
+		}
+		return fallback
+	}
+
+	if m := arrayType.FindStringSubmatch(f.Type); m != nil {
+		if m[1] != "char" {
+			return cField{}, false
+		}
+		return cField{
+			cDecl:  fmt.Sprintf("char %s[%s];", f.Name, m[2]),
+			goName: name,
+			goType: "string",
+			decode: fmt.Sprintf("C.GoString(&cstruct.%s[0])", f.Name),
+		}, true
+	}
+
+	if g.enums[f.Type] || strings.Contains(f.Type, "::") {
+		return cField{
+			cDecl:  fmt.Sprintf("int %s;", f.Name),
+			goName: name,
+			goType: goType("int32"),
+			decode: fmt.Sprintf("%s(cstruct.%s)", goType("int32"), f.Name),
+		}, true
+	}
+
+	switch base := g.resolve(f.Type); base {
+	case "bool", "unsigned char", "uint8":
+		if base == "bool" {
+			return cField{
+				cDecl:  fmt.Sprintf("unsigned char %s;", f.Name),
+				goName: name,
+				goType: "bool",
+				decode: fmt.Sprintf("cstruct.%s != 0", f.Name),
+			}, true
+		}
+		return cField{
+			cDecl:  fmt.Sprintf("unsigned char %s;", f.Name),
+			goName: name,
+			goType: goType("uint8"),
+			decode: fmt.Sprintf("%s(cstruct.%s)", goType("uint8"), f.Name),
+		}, true
+	case "unsigned short", "uint16":
+		return cField{
+			cDecl:  fmt.Sprintf("unsigned short %s;", f.Name),
+			goName: name,
+			goType: goType("uint16"),
+			decode: fmt.Sprintf("%s(cstruct.%s)", goType("uint16"), f.Name),
+		}, true
+	case "int", "int32", "signed int":
+		return cField{
+			cDecl:  fmt.Sprintf("int %s;", f.Name),
+			goName: name,
+			goType: goType("int32"),
+			decode: fmt.Sprintf("%s(cstruct.%s)", goType("int32"), f.Name),
+		}, true
+	case "unsigned int", "uint32":
+		return cField{
+			cDecl:  fmt.Sprintf("unsigned int %s;", f.Name),
+			goName: name,
+			goType: goType("uint32"),
+			decode: fmt.Sprintf("%s(cstruct.%s)", goType("uint32"), f.Name),
+		}, true
+	case "float":
+		return cField{
+			cDecl:  fmt.Sprintf("float %s;", f.Name),
+			goName: name,
+			goType: "float32",
+			decode: fmt.Sprintf("float32(cstruct.%s)", f.Name),
+		}, true
+	case "double":
+		return cField{
+			cDecl:  fmt.Sprintf("uint64_steam %s;", f.Name),
+			goName: name,
+			goType: "float64",
+			decode: fmt.Sprintf("math.Float64frombits(uint64FromC(cstruct.%s))", f.Name),
+		}, true
+	case "unsigned long long", "long long", "uint64", "int64", "CSteamID", "CGameID":
+		return cField{
+			cDecl:  fmt.Sprintf("uint64_steam %s;", f.Name),
+			goName: name,
+			goType: goType("uint64"),
+			decode: fmt.Sprintf("%s(uint64FromC(cstruct.%s))", goType("uint64"), f.Name),
+		}, true
+	}
+	return cField{}, false
+}
+
+var hungarianPrefixes = []string{"rgch", "rtime", "pch", "cub", "psz", "pub", "ull", "ul", "un", "us", "dw", "fl", "sz", "ub", "b", "c", "d", "e", "f", "h", "i", "n", "p", "u"}
+
+// goFieldName converts m_hSteamLeaderboard into SteamLeaderboard.
+func goFieldName(name string) string {
+	name = strings.TrimPrefix(name, "m_")
+	for _, p := range hungarianPrefixes {
+		rest, ok := strings.CutPrefix(name, p)
+		if ok && rest != "" && rest[0] >= 'A' && rest[0] <= 'Z' {
+			return rest
+		}
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// genStructs emits callback structs in the layout used by struct.go.
+func (g *generator) genStructs() []byte {
+	type genStruct struct {
+		name   string
+		fields []cField
+	}
+	var structs []genStruct
+	for _, s := range g.api.CallbackStructs {
+		if g.decls[s.Name] || len(s.Fields) == 0 {
+			continue
+		}
+		gs := genStruct{name: s.Name}
+		ok := true
+		for _, f := range s.Fields {
+			cf, fok := g.field(f)
+			if !fok {
+				ok = false
+				break
+			}
+			gs.fields = append(gs.fields, cf)
+		}
+		if ok {
+			structs = append(structs, gs)
+		}
+	}
+	sort.Slice(structs, func(i, j int) bool {
+		return structs[i].name < structs[j].name
+	})
+
+	var buf bytes.Buffer
+	g.header(&buf)
+	buf.WriteString("import (\n\t\"math\"\n\t\"reflect\"\n\t\"unsafe\"\n)\n\n")
+
+	buf.WriteString("/*\n")
+	buf.WriteString(`#if defined(_WIN32)
+typedef struct __attribute__((aligned(8))) {
+	unsigned int lo;
+	unsigned int hi;
+} uint64_steam;
+#else
+typedef struct {
+	unsigned int lo;
+	unsigned int hi;
+} uint64_steam;
+#endif
+`)
+	for _, s := range structs {
+		buf.WriteString("\ntypedef struct {\n")
+		for _, f := range s.fields {
+			fmt.Fprintf(&buf, "\t%s\n", f.cDecl)
+		}
+		fmt.Fprintf(&buf, "} %s;\n", s.name)
+	}
+	buf.WriteString("*/\nimport \"C\"\n\n")
+	buf.WriteString("var _ = math.Float64frombits\n")
+
+	for _, s := range structs {
+		fmt.Fprintf(&buf, "\ntype %s struct {\n", s.name)
+		for _, f := range s.fields {
+			fmt.Fprintf(&buf, "\t%s %s\n", f.goName, f.goType)
+		}
+		buf.WriteString("}\n\n")
+
+		fmt.Fprintf(&buf, "func (l %[1]s) FromByte(b []byte) %[1]s {\n", s.name)
+		fmt.Fprintf(&buf, "\treturn l.FromCStruct(**(**C.%s)(unsafe.Pointer(&b)))\n}\n\n", s.name)
+
+		fmt.Fprintf(&buf, "func (l %[1]s) FromCStruct(cstruct C.%[1]s) %[1]s {\n", s.name)
+		fmt.Fprintf(&buf, "\treturn %s{\n", s.name)
+		for _, f := range s.fields {
+			fmt.Fprintf(&buf, "\t\t%s: %s,\n", f.goName, f.decode)
+		}
+		buf.WriteString("\t}\n}\n\n")
+
+		fmt.Fprintf(&buf, "func (l %[1]s) CStruct() C.%[1]s {\n\treturn C.%[1]s{}\n}\n\n", s.name)
+		fmt.Fprintf(&buf, "func (l %s) Size() uintptr {\n\treturn reflect.TypeOf(l.CStruct()).Size()\n}\n\n", s.name)
+		fmt.Fprintf(&buf, "func (l %[1]s) callbackExpected() iCallbackExpected {\n\treturn iCallbackExpected_%[1]s\n}\n\n", s.name)
+		fmt.Fprintf(&buf, "func (l %s) decode(b []byte) Callback {\n\treturn l.FromByte(b)\n}\n", s.name)
+	}
+
+	return buf.Bytes()
+}
+
+// callType describes how a parameter or the result of a flat API function is passed.
+type callType struct {
+	// letter names the C type in the funcType names of steamworks_unix.go: Bool, Int32, Int64, Ptr, Float,
+	// Double or Void.
+	letter string
+	goType string
+}
+
+// genClass is an interface of steam_api.json with methods that are not hand-written yet.
+type genClass struct {
+	name string
+	// recv is the type implementing the interface, such as steamApps for ISteamApps.
+	recv string
+	// iface is the Go interface of the generated methods: ISteamAppsGenerated if recv is hand-written,
+	// or else ISteamMusic for a class that is generated as a whole, with accessor as its accessor.
+	iface    string
+	accessor string
+	methods  []genMethod
+}
+
+type genMethod struct {
+	name   string
+	flat   string
+	params []genParam
+	result callType
+}
+
+type genParam struct {
+	name string
+	typ  callType
+	// tmp names the C copy of a string or, on Windows, the word of a bool.
+	tmp string
+}
+
+// funcType returns the name of the funcType m is called with. Methods take the interface pointer first.
+func (m genMethod) funcType() string {
+	letters := []string{m.result.letter, "Ptr"}
+	for _, p := range m.params {
+		letters = append(letters, p.typ.letter)
+	}
+	return "funcType_" + strings.Join(letters, "_")
+}
+
+func (m genMethod) signature() string {
+	params := make([]string, len(m.params))
+	for i, p := range m.params {
+		params[i] = p.name + " " + p.typ.goType
+	}
+	sig := m.name + "(" + strings.Join(params, ", ") + ")"
+	if m.result.letter != "Void" {
+		sig += " " + m.result.goType
+	}
+	return sig
+}
+
+// genClasses collects the methods of steam_api.json without a hand-written binding. They are added to the
+// hand-written type of their class, or to a generated one if the class has none.
+func (g *generator) genClasses() {
+	for _, i := range g.api.Interfaces {
+		if !strings.HasPrefix(i.ClassName, "ISteam") {
+			continue
+		}
+		c := genClass{
+			name: i.ClassName,
+			recv: "steam" + strings.TrimPrefix(i.ClassName, "ISteam"),
+		}
+		if g.hw.decls[c.recv] {
+			c.iface = i.ClassName + "Generated"
+		} else {
+			for _, a := range i.Accessors {
+				if a.Kind == "user" && !g.flatNames[a.NameFlat] {
+					c.accessor = a.Name
+				}
+			}
+			// Game server only interfaces, such as ISteamGameServer, are left out.
+			if c.accessor == "" || g.hw.decls[c.accessor] || g.hw.decls[i.ClassName] {
+				continue
+			}
+			c.iface = i.ClassName
+		}
+		if g.hw.decls[c.iface] {
+			continue
+		}
+
+		for _, m := range i.Methods {
+			if g.flatNames[m.NameFlat] {
+				continue
+			}
+			// The flat name tells overloads apart, as in GetGlobalStatInt64 and GetGlobalStatDouble.
+			name := strings.TrimPrefix(m.NameFlat, "SteamAPI_"+i.ClassName+"_")
+			if g.hw.methods[c.recv][name] || !token.IsIdentifier(name) {
+				continue
+			}
+			gm, err := g.method(name, m)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "gen: skipping %s: %v\n", m.NameFlat, err)
+				continue
+			}
+			c.methods = append(c.methods, gm)
+		}
+		if len(c.methods) > 0 || c.accessor != "" {
+			g.classes = append(g.classes, c)
+		}
+	}
+}
+
+func (g *generator) method(name string, m apiMethod) (genMethod, error) {
+	gm := genMethod{
+		name: name,
+		flat: "flatAPI_" + strings.TrimPrefix(m.NameFlat, "SteamAPI_"),
+	}
+	result, ok := g.callType(m.ReturnType)
+	// A float result comes back in a floating-point register, which a syscall on Windows does not read.
+	if !ok || result.letter == "Float" || result.letter == "Double" {
+		return genMethod{}, fmt.Errorf("cannot return %s", m.ReturnType)
+	}
+	gm.result = result
+
+	used := map[string]bool{"s": true, "v": true, "err": true}
+	for i, p := range m.Params {
+		t, ok := g.callType(p.Type)
+		if !ok || t.letter == "Void" {
+			return genMethod{}, fmt.Errorf("cannot pass %s %s", p.Type, p.Name)
+		}
+		gp := genParam{
+			name: paramName(p.Name, i, used),
+			typ:  t,
+		}
+		switch t.letter {
+		case "Ptr":
+			gp.tmp = unusedName("c"+strings.ToUpper(gp.name[:1])+gp.name[1:], used)
+		case "Bool":
+			gp.tmp = unusedName("b"+strings.ToUpper(gp.name[:1])+gp.name[1:], used)
+		}
+		gm.params = append(gm.params, gp)
+	}
+	return gm, nil
+}
+
+// paramName converts pchConnectString into connectString, or steamIDFriend into steamIDFriend, and makes
+// it differ from the names in used.
+func paramName(name string, index int, used map[string]bool) string {
+	n := "arg" + strconv.Itoa(index)
+	if name != "" && token.IsIdentifier(name) {
+		n = goFieldName(name)
+		upper := 0
+		for upper < len(n) && n[upper] >= 'A' && n[upper] <= 'Z' {
+			upper++
+		}
+		switch {
+		case upper == len(n):
+			n = strings.ToLower(n)
+		case upper > 1:
+			// IDFriend becomes idFriend.
+			n = strings.ToLower(n[:upper-1]) + n[upper-1:]
+		default:
+			n = strings.ToLower(n[:1]) + n[1:]
+		}
+	}
+	return unusedName(n, used)
+}
+
+// unusedName returns name, or name with a number if used holds it, and adds the result to used.
+func unusedName(name string, used map[string]bool) string {
+	n := name
+	for i := 2; used[n] || token.IsKeyword(n); i++ {
+		n = name + strconv.Itoa(i)
+	}
+	used[n] = true
+	return n
+}
+
+// callType returns how a parameter or result of the C type t is passed, or false for what the generator
+// cannot pass, such as structs and pointers other than strings.
+func (g *generator) callType(t string) (callType, bool) {
+	t = strings.TrimSpace(t)
+	switch {
+	case t == "void":
+		return callType{letter: "Void"}, true
+	case t == "bool":
+		return callType{letter: "Bool", goType: "bool"}, true
+	case t == "const char *":
+		return callType{letter: "Ptr", goType: "string"}, true
+	case g.enums[t]:
+		return callType{letter: "Int32", goType: t}, true
+	case strings.Contains(t, "::"):
+		return callType{letter: "Int32", goType: "int32"}, true
+	}
+
+	// A typedef keeps its name if the package declares it as an integer of the same kind, as AppId_t.
+	small := []string{"int", "int8", "int16", "int32", "uint", "uint8", "uint16", "uint32"}
+	wide := []string{"int64", "uint64"}
+	named := func(base string, kinds []string) string {
+		if slices.Contains(kinds, g.hw.underlying[t]) {
+			return t
+		}
+		return base
+	}
+	switch g.resolve(t) {
+	case "float":
+		return callType{letter: "Float", goType: "float32"}, true
+	case "double":
+		return callType{letter: "Double", goType: "float64"}, true
+	case "signed char", "int8":
+		return callType{letter: "Int32", goType: named("int8", small)}, true
+	case "unsigned char", "uint8":
+		return callType{letter: "Int32", goType: named("uint8", small)}, true
+	case "short", "int16":
+		return callType{letter: "Int32", goType: named("int16", small)}, true
+	case "unsigned short", "uint16":
+		return callType{letter: "Int32", goType: named("uint16", small)}, true
+	case "int", "int32", "signed int":
+		return callType{letter: "Int32", goType: named("int32", small)}, true
+	case "unsigned int", "uint32":
+		return callType{letter: "Int32", goType: named("uint32", small)}, true
+	case "long long", "int64":
+		return callType{letter: "Int64", goType: named("int64", wide)}, true
+	case "unsigned long long", "uint64", "CSteamID", "CGameID", "uint64_steamid", "uint64_gameid":
+		return callType{letter: "Int64", goType: named("uint64", wide)}, true
+	}
+	return callType{}, false
+}
+
+// genBackend emits the generated methods and classes in the style of steamworks_unix.go or, if windows is
+// set, steamworks_windows.go.
+func (g *generator) genBackend(windows bool) []byte {
+	var body bytes.Buffer
+	imports := map[string]bool{}
+	call := "theLib.call"
+	if windows {
+		call = "theDLL.call"
+	}
+
+	for _, c := range g.classes {
+		if c.accessor != "" {
+			fmt.Fprintf(&body, "func %s() %s {\n", c.accessor, c.iface)
+			if windows {
+				fmt.Fprintf(&body, "\tv, err := %s(flatAPI_%s)\n", call, c.accessor)
+				fmt.Fprintf(&body, "\tif err != nil {\n\t\thandleError(err)\n\t}\n\treturn %s(v)\n}\n\n", c.recv)
+				fmt.Fprintf(&body, "type %s uintptr\n\n", c.recv)
+			} else {
+				fmt.Fprintf(&body, "\tv, err := %s(funcType_Ptr, flatAPI_%s)\n", call, c.accessor)
+				fmt.Fprintf(&body, "\tif err != nil {\n\t\thandleError(err)\n\t}\n\treturn %s(v)\n}\n\n", c.recv)
+				fmt.Fprintf(&body, "type %s C.uintptr_t\n\n", c.recv)
+			}
+		}
+		for _, m := range c.methods {
+			g.writeMethod(&body, c, m, windows, imports)
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gen.go from steamworks_sdk_%s.zip; DO NOT EDIT.\n\n", g.version)
+	if !windows {
+		buf.WriteString("//go:build !windows\n\n")
+	}
+	buf.WriteString("package steamworks\n\n")
+	if len(imports) > 0 {
+		buf.WriteString("import (\n")
+		for _, p := range slices.Sorted(maps.Keys(imports)) {
+			fmt.Fprintf(&buf, "\t%q\n", p)
+		}
+		buf.WriteString(")\n\n")
+	}
+	if !windows && len(g.classes) > 0 {
+		g.writeTrampolines(&buf, bytes.Contains(body.Bytes(), []byte("C.uintptrToCString")))
+	}
+	buf.Write(body.Bytes())
+	return buf.Bytes()
+}
+
+func (g *generator) writeMethod(buf *bytes.Buffer, c genClass, m genMethod, windows bool, imports map[string]bool) {
+	fmt.Fprintf(buf, "func (s %s) %s {\n", c.recv, m.signature())
+
+	args := []string{"uintptr(s)"}
+	var prologue bool
+	for _, p := range m.params {
+		switch p.typ.letter {
+		case "Bool":
+			if windows {
+				fmt.Fprintf(buf, "\tvar %[1]s uintptr\n\tif %[2]s {\n\t\t%[1]s = 1\n\t}\n", p.tmp, p.name)
+				args = append(args, p.tmp)
+				prologue = true
+			} else {
+				args = append(args, "cBool("+p.name+")")
+			}
+		case "Int32":
+			args = append(args, "uintptr("+p.name+")")
+		case "Int64":
+			args = append(args, p.name)
+		case "Float":
+			args = append(args, "uintptr(math.Float32bits("+p.name+"))")
+			imports["math"] = true
+		case "Double":
+			args = append(args, "math.Float64bits("+p.name+")")
+			imports["math"] = true
+		case "Ptr":
+			if windows {
+				fmt.Fprintf(buf, "\t%s := append([]byte(%s), 0)\n\tdefer runtime.KeepAlive(%[1]s)\n", p.tmp, p.name)
+				args = append(args, "unsafe.Pointer(&"+p.tmp+"[0])")
+				imports["runtime"] = true
+			} else {
+				fmt.Fprintf(buf, "\t%s := C.CString(%s)\n\tdefer C.free(unsafe.Pointer(%[1]s))\n", p.tmp, p.name)
+				args = append(args, "unsafe.Pointer("+p.tmp+")")
+			}
+			imports["unsafe"] = true
+			prologue = true
+		}
+	}
+	if prologue {
+		buf.WriteString("\n")
+	}
+
+	callExpr := "theDLL.call(" + m.flat + ", " + strings.Join(args, ", ") + ")"
+	if !windows {
+		callExpr = "theLib.call(" + m.funcType() + ", " + m.flat + ", " + strings.Join(args, ", ") + ")"
+	}
+	if m.result.letter == "Void" {
+		fmt.Fprintf(buf, "\tif _, err := %s; err != nil {\n\t\thandleError(err)\n\t}\n}\n\n", callExpr)
+		return
+	}
+
+	var zero, result string
+	switch {
+	case m.result.letter == "Bool":
+		zero, result = "false", "byte(v) != 0"
+	case m.result.goType == "string" && windows:
+		zero, result = `""`, "cStringToGoString(uintptr(v), 256)"
+	case m.result.goType == "string":
+		zero, result = `""`, "C.GoString(C.uintptrToCString(C.uintptr_t(v)))"
+	default:
+		zero, result = "0", m.result.goType+"(v)"
+	}
+	fmt.Fprintf(buf, "\tv, err := %s\n\tif err != nil {\n\t\thandleError(err)\n\t\treturn %s\n\t}\n\treturn %s\n}\n\n", callExpr, zero, result)
+}
+
+var (
+	cParamTypes = map[string]string{"Bool": "uint8_t", "Int32": "int32_t", "Int64": "int64_t", "Ptr": "uintptr_t", "Float": "uint32_t", "Double": "uint64_t"}
+	cFuncTypes  = map[string]string{"Bool": "bool", "Int32": "int32_t", "Int64": "int64_t", "Ptr": "void*", "Float": "float", "Double": "double", "Void": "void"}
+	cGoTypes    = map[string]string{"Bool": "C.uint8_t", "Int32": "C.int32_t", "Int64": "C.int64_t", "Ptr": "C.uintptr_t", "Float": "C.uint32_t", "Double": "C.uint64_t"}
+)
+
+// writeTrampolines emits the callFunc functions of the function types steamworks_unix.go lacks, and hooks
+// them into lib.call.
+func (g *generator) writeTrampolines(buf *bytes.Buffer, cString bool) {
+	var ftypes []genMethod
+	seen := map[string]bool{}
+	for _, c := range g.classes {
+		for _, m := range c.methods {
+			if ft := m.funcType(); !g.hw.decls[ft] && !seen[ft] {
+				seen[ft] = true
+				ftypes = append(ftypes, m)
+			}
+		}
+	}
+	slices.SortFunc(ftypes, func(a, b genMethod) int {
+		return strings.Compare(a.funcType(), b.funcType())
+	})
+
+	buf.WriteString("// #include <stdbool.h>\n// #include <stdint.h>\n// #include <stdlib.h>\n")
+	if cString {
+		buf.WriteString("//\n// static const char* uintptrToCString(uintptr_t str) {\n//   return (const char*)str;\n// }\n")
+	}
+	for _, m := range ftypes {
+		name := strings.Replace(m.funcType(), "funcType_", "callFunc_", 1)
+		letters := []string{"Ptr"}
+		for _, p := range m.params {
+			letters = append(letters, p.typ.letter)
+		}
+		var params, fparams, cargs, unions []string
+		for i, l := range letters {
+			params = append(params, fmt.Sprintf("%s arg%d", cParamTypes[l], i))
+			fparams = append(fparams, cFuncTypes[l])
+			switch l {
+			case "Bool":
+				cargs = append(cargs, fmt.Sprintf("(bool)arg%d", i))
+			case "Ptr":
+				cargs = append(cargs, fmt.Sprintf("(void*)arg%d", i))
+			case "Float", "Double":
+				unions = append(unions, fmt.Sprintf("union { %s bits; %s v; } v%d = { arg%d };", cParamTypes[l], cFuncTypes[l], i, i))
+				cargs = append(cargs, fmt.Sprintf("v%d.v", i))
+			default:
+				cargs = append(cargs, fmt.Sprintf("arg%d", i))
+			}
+		}
+		ret := m.result.letter
+		cRet := "void"
+		if ret != "Void" {
+			cRet = cParamTypes[ret]
+		}
+		fmt.Fprintf(buf, "//\n// static %s %s(uintptr_t f, %s) {\n", cRet, name, strings.Join(params, ", "))
+		for _, u := range unions {
+			fmt.Fprintf(buf, "//   %s\n", u)
+		}
+		callExpr := fmt.Sprintf("((%s (*)(%s))(f))(%s)", cFuncTypes[ret], strings.Join(fparams, ", "), strings.Join(cargs, ", "))
+		switch ret {
+		case "Void":
+			fmt.Fprintf(buf, "//   %s;\n", callExpr)
+		case "Ptr":
+			fmt.Fprintf(buf, "//   return (uintptr_t)%s;\n", callExpr)
+		default:
+			fmt.Fprintf(buf, "//   return %s;\n", callExpr)
+		}
+		buf.WriteString("// }\n")
+	}
+	buf.WriteString("import \"C\"\n\n")
+
+	if len(ftypes) == 0 {
+		return
+	}
+	// The generated function types count down from -1, clear of the hand-written ones.
+	buf.WriteString("const (\n")
+	for i, m := range ftypes {
+		if i == 0 {
+			fmt.Fprintf(buf, "\t%s funcType = -1 - iota\n", m.funcType())
+			continue
+		}
+		fmt.Fprintf(buf, "\t%s\n", m.funcType())
+	}
+	buf.WriteString(")\n\n")
+
+	buf.WriteString("func init() {\n\tcallGenerated = func(ftype funcType, f C.uintptr_t, args []uint64) (C.uint64_t, bool) {\n\t\tswitch ftype {\n")
+	for _, m := range ftypes {
+		name := strings.Replace(m.funcType(), "funcType_", "callFunc_", 1)
+		cargs := []string{"f", "C.uintptr_t(args[0])"}
+		for i, p := range m.params {
+			cargs = append(cargs, fmt.Sprintf("%s(args[%d])", cGoTypes[p.typ.letter], i+1))
+		}
+		fmt.Fprintf(buf, "\t\tcase %s:\n", m.funcType())
+		if m.result.letter == "Void" {
+			fmt.Fprintf(buf, "\t\t\tC.%s(%s)\n\t\t\treturn 0, true\n", name, strings.Join(cargs, ", "))
+		} else {
+			fmt.Fprintf(buf, "\t\t\treturn C.uint64_t(C.%s(%s)), true\n", name, strings.Join(cargs, ", "))
+		}
+	}
+	buf.WriteString("\t\t}\n\t\treturn 0, false\n\t}\n}\n\n")
+}
+
+// check compares the hand-written flat API names, callback IDs and enum values with steam_api.json,
+// so that a function, callback or enum value that an SDK update renamed or renumbered is reported here
+// rather than failing at run time. Every hand-written value of an enum in steam_api.json must be found
+// there under its Enum_Value name or its SDK name.
+func (g *generator) check() error {
+	flat := map[string]bool{}
+	for _, i := range g.api.Interfaces {
+		for _, a := range i.Accessors {
+			flat[a.NameFlat] = true
+		}
+		for _, m := range i.Methods {
+			flat[m.NameFlat] = true
+		}
+	}
+	want := map[string]int64{}
+	for _, s := range g.api.CallbackStructs {
+		want["iCallbackExpected_"+s.Name] = int64(s.CallbackID)
+	}
+	for _, e := range g.api.Enums {
+		if !g.enums[e.Name] {
+			continue
+		}
+		names := enumValueNames(e)
+		for i, v := range e.Values {
+			name, ok := g.handWrittenValue(e.Name, names[i], v)
+			if !ok {
+				continue
+			}
+			n, err := strconv.ParseInt(v.Value, 0, 64)
+			if err != nil {
+				// The value does not fit int64, so compare it as a uint64 would wrap.
+				u, uerr := strconv.ParseUint(v.Value, 0, 64)
+				if uerr != nil {
+					continue
+				}
+				n = int64(u)
+			}
+			want[name] = n
+		}
+	}
+
+	names := slices.Sorted(maps.Keys(g.hw.values))
+	var errs []string
+	for _, name := range names {
+		v := g.hw.values[name]
+		if s, ok := stringValue(v); ok {
+			// Only the interface functions and accessors are described in steam_api.json,
+			// not SteamAPI_Init and the other global functions.
+			if (strings.HasPrefix(s, "SteamAPI_ISteam") || accessorName.MatchString(s)) && !flat[s] {
+				errs = append(errs, fmt.Sprintf("%s: %s is not in steam_api.json", name, s))
+			}
+			continue
+		}
+		w, ok := want[name]
+		if !ok {
+			if typ := g.hw.types[name]; g.enums[typ] {
+				errs = append(errs, fmt.Sprintf("%s is not a value of %s in steam_api.json", name, typ))
+			}
+			continue
+		}
+		if got, ok := intValue(v); !ok {
+			errs = append(errs, fmt.Sprintf("%s: cannot read its value to compare it with %d in steam_api.json", name, w))
+		} else if !sameValue(got, w) {
+			errs = append(errs, fmt.Sprintf("%s is %d, but %d in steam_api.json", name, got, w))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("gen: hand-written declarations do not match steam_api.json:\n\t%s", strings.Join(errs, "\n\t"))
+	}
 	return nil
 }
+
+// sameValue reports whether two enum values are the same 32-bit value, as 0xffffffff and -1 are.
+func sameValue(x, y int64) bool {
+	fits := func(n int64) bool { return n >= math.MinInt32 && n <= math.MaxUint32 }
+	return x == y || fits(x) && fits(y) && uint32(x) == uint32(y)
+}
+
+var accessorName = regexp.MustCompile(`^SteamAPI_Steam\w+_v\d+$`)
+
+// intValue returns the value of a constant declared as an integer literal, a negated or shifted one,
+// or a conversion of one such as iCallbackExpected(1101).
+func intValue(e ast.Expr) (int64, bool) {
+	switch e := e.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.INT {
+			return 0, false
+		}
+		n, err := strconv.ParseInt(e.Value, 0, 64)
+		if err != nil {
+			u, uerr := strconv.ParseUint(e.Value, 0, 64)
+			return int64(u), uerr == nil
+		}
+		return n, true
+	case *ast.UnaryExpr:
+		if e.Op != token.SUB {
+			return 0, false
+		}
+		n, ok := intValue(e.X)
+		return -n, ok
+	case *ast.ParenExpr:
+		return intValue(e.X)
+	case *ast.BinaryExpr:
+		x, xok := intValue(e.X)
+		y, yok := intValue(e.Y)
+		if !xok || !yok {
+			return 0, false
+		}
+		switch e.Op {
+		case token.SHL:
+			return x << y, true
+		case token.OR:
+			return x | y, true
+		}
+		return 0, false
+	case *ast.CallExpr:
+		if len(e.Args) != 1 {
+			return 0, false
+		}
+		return intValue(e.Args[0])
+	}
+	return 0, false
+}
@@ -3,3 +3,5 @@ module github.com/TaiJiYu/go-steamworks
 go 1.23.3
 
 require golang.org/x/sys v0.27.0
+
+require golang.org/x/text v0.21.0
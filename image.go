@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"errors"
+	"fmt"
+	"image"
+)
+
+// ErrNoImage is returned by ImageRGBA for a handle that has no image, such as 0 from GetAchievementIcon.
+var ErrNoImage = errors.New("steamworks: no image")
+
+// ImageRGBA returns the pixels of a Steam image handle, e.g. from GetAchievementIcon.
+//
+// A handle of 0 means there is no image, and -1 that Steam is still loading it. Achievement icons that
+// are still loading arrive later with UserAchievementIconFetched_t, whose IconHandle can be passed here.
+func ImageRGBA(handle int32) (*image.RGBA, error) {
+	if handle <= 0 {
+		return nil, fmt.Errorf("%w: handle %d", ErrNoImage, handle)
+	}
+	utils := SteamUtils()
+	if utils == nil {
+		return nil, errors.New("steamworks: SteamUtils is not available")
+	}
+	w, h, ok := utils.GetImageSize(handle)
+	if !ok || w == 0 || h == 0 {
+		return nil, fmt.Errorf("%w: handle %d", ErrNoImage, handle)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, int(w), int(h)))
+	if !utils.GetImageRGBA(handle, img.Pix) {
+		return nil, fmt.Errorf("steamworks: GetImageRGBA failed for handle %d", handle)
+	}
+
+	// Steam's pixels are not premultiplied by alpha, but image.RGBA's are.
+	for i := 0; i < len(img.Pix); i += 4 {
+		a := uint32(img.Pix[i+3])
+		if a == 0xff {
+			continue
+		}
+		img.Pix[i] = uint8(uint32(img.Pix[i]) * a / 0xff)
+		img.Pix[i+1] = uint8(uint32(img.Pix[i+1]) * a / 0xff)
+		img.Pix[i+2] = uint8(uint32(img.Pix[i+2]) * a / 0xff)
+	}
+	return img, nil
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks_test
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/TaiJiYu/go-steamworks"
+)
+
+func TestImageRGBA(t *testing.T) {
+	fake := steamworks.NewFake()
+	icon := image.NewNRGBA(image.Rect(0, 0, 2, 3))
+	icon.SetNRGBA(0, 0, color.NRGBA{10, 20, 30, 0xff})
+	icon.SetNRGBA(1, 2, color.NRGBA{200, 100, 50, 128})
+	fake.DefineAchievement(steamworks.FakeAchievement{APIName: "A", Icon: icon})
+	fake.DefineAchievement(steamworks.FakeAchievement{APIName: "B"})
+	startFake(t, fake)
+
+	img, err := steamworks.ImageRGBA(steamworks.SteamUserStats().GetAchievementIcon("A"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := img.Bounds(); got != icon.Bounds() {
+		t.Fatalf("Bounds() = %v, want %v", got, icon.Bounds())
+	}
+	// Steam's pixels are not premultiplied, so ImageRGBA premultiplies them for image.RGBA.
+	for _, c := range []struct {
+		x, y int
+		want color.RGBA
+	}{
+		{0, 0, color.RGBA{10, 20, 30, 0xff}},
+		{1, 2, color.RGBA{100, 50, 25, 128}},
+		{1, 0, color.RGBA{}},
+	} {
+		if got := img.RGBAAt(c.x, c.y); got != c.want {
+			t.Errorf("RGBAAt(%d, %d) = %v, want %v", c.x, c.y, got, c.want)
+		}
+	}
+
+	if _, err := steamworks.ImageRGBA(steamworks.SteamUserStats().GetAchievementIcon("B")); !errors.Is(err, steamworks.ErrNoImage) {
+		t.Errorf("ImageRGBA() = %v for an achievement without an icon, want %v", err, steamworks.ErrNoImage)
+	}
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"golang.org/x/text/language"
+)
+
+// steamLanguages maps the API language codes of Steam to language tags.
+// See https://partner.steamgames.com/doc/store/localization/languages.
+var steamLanguages = []struct {
+	code string
+	tag  language.Tag
+}{
+	{"english", language.English},
+	{"arabic", language.Arabic},
+	{"bulgarian", language.Bulgarian},
+	{"schinese", language.SimplifiedChinese},
+	{"tchinese", language.TraditionalChinese},
+	{"czech", language.Czech},
+	{"danish", language.Danish},
+	{"dutch", language.Dutch},
+	{"finnish", language.Finnish},
+	{"french", language.French},
+	{"german", language.German},
+	{"greek", language.Greek},
+	{"hungarian", language.Hungarian},
+	{"indonesian", language.Indonesian},
+	{"italian", language.Italian},
+	{"japanese", language.Japanese},
+	{"koreana", language.Korean},
+	{"norwegian", language.MustParse("nb")}, // Bokmål. With "no" here, nb would match Danish.
+	{"polish", language.Polish},
+	{"portuguese", language.EuropeanPortuguese},
+	{"brazilian", language.BrazilianPortuguese},
+	{"romanian", language.Romanian},
+	{"russian", language.Russian},
+	{"spanish", language.EuropeanSpanish},
+	{"latam", language.LatinAmericanSpanish},
+	{"swedish", language.Swedish},
+	{"thai", language.Thai},
+	{"turkish", language.Turkish},
+	{"ukrainian", language.Ukrainian},
+	{"vietnamese", language.Vietnamese},
+}
+
+var steamLanguageMatcher = func() language.Matcher {
+	tags := make([]language.Tag, len(steamLanguages))
+	for i, l := range steamLanguages {
+		tags[i] = l.tag
+	}
+	return language.NewMatcher(tags)
+}()
+
+// LanguageTag returns the language tag of a Steam API language code, e.g. "schinese" or "latam".
+func LanguageTag(code string) (language.Tag, bool) {
+	for _, l := range steamLanguages {
+		if l.code == code {
+			return l.tag, true
+		}
+	}
+	return language.Und, false
+}
+
+// LanguageCode returns the Steam API language code closest to tag, e.g. "brazilian" for pt-BR.
+// It returns false if Steam has no such language.
+func LanguageCode(tag language.Tag) (string, bool) {
+	_, i, conf := steamLanguageMatcher.Match(tag)
+	if conf < language.High {
+		return "", false
+	}
+	return steamLanguages[i].code, true
+}
+
+// PreferredLanguages returns the languages the user prefers: the language they play the game in, then the Steam client's language.
+func PreferredLanguages() []language.Tag {
+	var tags []language.Tag
+	for _, code := range []string{SteamApps().GetCurrentGameLanguage(), SteamUtils().GetSteamUILanguage()} {
+		tag, ok := LanguageTag(code)
+		if !ok || len(tags) > 0 && tags[0] == tag {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// MatchLanguage returns the one of supported that best matches PreferredLanguages.
+// Without supported languages it chooses from the app's languages in GetAvailableGameLanguages.
+// It returns language.Und if there is nothing to choose from.
+func MatchLanguage(supported ...language.Tag) language.Tag {
+	if len(supported) == 0 {
+		for _, code := range SteamApps().GetAvailableGameLanguages() {
+			if tag, ok := LanguageTag(code); ok {
+				supported = append(supported, tag)
+			}
+		}
+	}
+	if len(supported) == 0 {
+		return language.Und
+	}
+	_, i, _ := language.NewMatcher(supported).Match(PreferredLanguages()...)
+	return supported[i]
+}
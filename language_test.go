@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks_test
+
+import (
+	"testing"
+
+	"github.com/TaiJiYu/go-steamworks"
+	"golang.org/x/text/language"
+)
+
+func TestLanguageRoundTrip(t *testing.T) {
+	codes := []string{
+		"english", "arabic", "bulgarian", "schinese", "tchinese", "czech", "danish", "dutch", "finnish",
+		"french", "german", "greek", "hungarian", "indonesian", "italian", "japanese", "koreana", "norwegian",
+		"polish", "portuguese", "brazilian", "romanian", "russian", "spanish", "latam", "swedish", "thai",
+		"turkish", "ukrainian", "vietnamese",
+	}
+	for _, code := range codes {
+		tag, ok := steamworks.LanguageTag(code)
+		if !ok {
+			t.Errorf("LanguageTag(%q) failed", code)
+			continue
+		}
+		if got, ok := steamworks.LanguageCode(tag); !ok || got != code {
+			t.Errorf("LanguageCode(%v) = %q, %v, want %q", tag, got, ok, code)
+		}
+	}
+	if _, ok := steamworks.LanguageTag("klingon"); ok {
+		t.Error("LanguageTag(klingon) succeeded")
+	}
+}
+
+func TestLanguageCode(t *testing.T) {
+	for tag, want := range map[string]string{
+		"en-GB":   "english",
+		"pt-BR":   "brazilian",
+		"pt-PT":   "portuguese",
+		"es-MX":   "latam",
+		"es-ES":   "spanish",
+		"zh-TW":   "tchinese",
+		"zh-CN":   "schinese",
+		"ko":      "koreana",
+		"nb":      "norwegian",
+		"ja-JP":   "japanese",
+		"uk":      "ukrainian",
+		"de-AT":   "german",
+		"fr-CA":   "french",
+		"zh-Hans": "schinese",
+	} {
+		if got, ok := steamworks.LanguageCode(language.MustParse(tag)); !ok || got != want {
+			t.Errorf("LanguageCode(%s) = %q, %v, want %q", tag, got, ok, want)
+		}
+	}
+	if got, ok := steamworks.LanguageCode(language.Hindi); ok {
+		t.Errorf("LanguageCode(hi) = %q, want no language", got)
+	}
+}
+
+func TestMatchLanguage(t *testing.T) {
+	fake := steamworks.NewFake()
+	fake.Language = "brazilian"
+	fake.UILanguage = "english"
+	fake.AvailableLanguages = []string{"english", "portuguese", "japanese"}
+	startFake(t, fake)
+
+	if got := steamworks.PreferredLanguages(); len(got) != 2 || got[0] != language.BrazilianPortuguese || got[1] != language.English {
+		t.Errorf("PreferredLanguages() = %v, want pt-BR and en", got)
+	}
+	if got := steamworks.MatchLanguage(language.English, language.Japanese); got != language.English {
+		t.Errorf("MatchLanguage(en, ja) = %v, want en", got)
+	}
+	if got := steamworks.MatchLanguage(); got != language.EuropeanPortuguese {
+		t.Errorf("MatchLanguage() = %v, want pt-PT", got)
+	}
+}
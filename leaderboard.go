@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrLeaderboardNotFound is returned by FindLeaderboard for a leaderboard that does not exist.
+var ErrLeaderboardNotFound = errors.New("steamworks: leaderboard not found")
+
+// Leaderboard is a handle to a leaderboard, from FindLeaderboard or FindOrCreateLeaderboard.
+type Leaderboard struct {
+	stats  steamUserStats
+	handle SteamLeaderboard_t
+	name   string
+}
+
+// LeaderboardEntry is an entry downloaded from a leaderboard.
+type LeaderboardEntry struct {
+	SteamID    CSteamID
+	GlobalRank int
+	Score      int32
+	Details    []int32
+	UGC        UGCHandle_t
+}
+
+// leaderboards caches the handles of the leaderboards found so far by name.
+var leaderboards = struct {
+	sync.Mutex
+	handles map[string]SteamLeaderboard_t
+}{handles: map[string]SteamLeaderboard_t{}}
+
+func forgetLeaderboards() {
+	leaderboards.Lock()
+	defer leaderboards.Unlock()
+	clear(leaderboards.handles)
+}
+
+func (s steamUserStats) cachedLeaderboard(name string) (*Leaderboard, bool) {
+	leaderboards.Lock()
+	defer leaderboards.Unlock()
+	handle, ok := leaderboards.handles[name]
+	if !ok {
+		return nil, false
+	}
+	return &Leaderboard{stats: s, handle: handle, name: name}, true
+}
+
+func (s steamUserStats) awaitLeaderboard(ctx context.Context, name string, call SteamAPICall_t) (*Leaderboard, error) {
+	found, err := Await[LeaderboardFindResult_t](ctx, call)
+	if err != nil {
+		return nil, err
+	}
+	if !found.LeaderboardFound || found.SteamLeaderboard == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrLeaderboardNotFound, name)
+	}
+
+	leaderboards.Lock()
+	defer leaderboards.Unlock()
+	leaderboards.handles[name] = found.SteamLeaderboard
+	return &Leaderboard{stats: s, handle: found.SteamLeaderboard, name: name}, nil
+}
+
+// FindLeaderboard returns the leaderboard name. A leaderboard found before is returned without asking Steam.
+func (s steamUserStats) FindLeaderboard(ctx context.Context, name string) (*Leaderboard, error) {
+	if l, ok := s.cachedLeaderboard(name); ok {
+		return l, nil
+	}
+	return s.awaitLeaderboard(ctx, name, s.findLeaderboard(name))
+}
+
+// FindOrCreateLeaderboard returns the leaderboard name, creating it with sortMethod and displayType if it does not exist.
+// The sort method and display type of an existing leaderboard are left unchanged.
+func (s steamUserStats) FindOrCreateLeaderboard(ctx context.Context, name string, sortMethod ELeaderboardSortMethod, displayType ELeaderboardDisplayType) (*Leaderboard, error) {
+	if l, ok := s.cachedLeaderboard(name); ok {
+		return l, nil
+	}
+	return s.awaitLeaderboard(ctx, name, s.findOrCreateLeaderboard(name, sortMethod, displayType))
+}
+
+// Handle returns the Steam handle of the leaderboard.
+func (l *Leaderboard) Handle() SteamLeaderboard_t {
+	return l.handle
+}
+
+// Name returns the name the leaderboard was found by.
+func (l *Leaderboard) Name() string {
+	return l.name
+}
+
+// EntryCount returns the total number of entries in the leaderboard.
+func (l *Leaderboard) EntryCount() int {
+	return int(l.stats.GetLeaderboardEntryCount(l.handle))
+}
+
+// SortMethod returns whether lower or higher scores rank first.
+func (l *Leaderboard) SortMethod() ELeaderboardSortMethod {
+	return l.stats.GetLeaderboardSortMethod(l.handle)
+}
+
+// DisplayType returns how the scores are shown, e.g. as a number or a time.
+func (l *Leaderboard) DisplayType() ELeaderboardDisplayType {
+	return l.stats.GetLeaderboardDisplayType(l.handle)
+}
+
+// Entries downloads entries from the leaderboard, with up to detailsMax details each.
+//
+// For ELeaderboardDataRequestGlobal, rangeStart and rangeEnd are global ranks counted from 1, inclusive.
+// For ELeaderboardDataRequestGlobalAroundUser, they are offsets from the current user, e.g. -4 and 5.
+// ELeaderboardDataRequestFriends ignores them. Use EntriesForUsers for ELeaderboardDataRequestUsers.
+func (l *Leaderboard) Entries(ctx context.Context, dataRequest ELeaderboardDataRequest, rangeStart, rangeEnd int, detailsMax int) ([]LeaderboardEntry, error) {
+	if dataRequest == ELeaderboardDataRequestUsers {
+		return nil, errors.New("steamworks: ELeaderboardDataRequestUsers needs EntriesForUsers")
+	}
+	return l.download(ctx, l.stats.downloadLeaderboardEntries(l.handle, dataRequest, rangeStart, rangeEnd), detailsMax)
+}
+
+// Page downloads page pageIndex, counted from 0, of the global entries split into pages of pageSize entries.
+func (l *Leaderboard) Page(ctx context.Context, pageIndex, pageSize int, detailsMax int) ([]LeaderboardEntry, error) {
+	if pageIndex < 0 || pageSize <= 0 {
+		return nil, fmt.Errorf("steamworks: invalid leaderboard page %d of size %d", pageIndex, pageSize)
+	}
+	start := pageIndex*pageSize + 1
+	return l.Entries(ctx, ELeaderboardDataRequestGlobal, start, start+pageSize-1, detailsMax)
+}
+
+// EntriesForUsers downloads the entries of users, up to 100 of them. Users without an entry are left out.
+func (l *Leaderboard) EntriesForUsers(ctx context.Context, users []CSteamID, detailsMax int) ([]LeaderboardEntry, error) {
+	if len(users) == 0 {
+		return nil, nil
+	}
+	return l.download(ctx, l.stats.downloadLeaderboardEntriesForUsers(l.handle, users), detailsMax)
+}
+
+func (l *Leaderboard) download(ctx context.Context, call SteamAPICall_t, detailsMax int) ([]LeaderboardEntry, error) {
+	downloaded, err := Await[LeaderboardScoresDownloaded_t](ctx, call)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]LeaderboardEntry, 0, downloaded.EntryCount)
+	for i := 0; i < downloaded.EntryCount; i++ {
+		ok, entry, details := l.stats.getDownloadedLeaderboardEntry(downloaded.SteamLeaderboardEntries, i, detailsMax)
+		if !ok {
+			continue
+		}
+		entries = append(entries, LeaderboardEntry{
+			SteamID:    entry.SteamIDUser,
+			GlobalRank: entry.GlobalRank,
+			Score:      int32(entry.Score),
+			Details:    details[:min(entry.Details, detailsMax)],
+			UGC:        entry.UGC,
+		})
+	}
+	return entries, nil
+}
+
+// UploadScore uploads score for the current user. It fails if Steam does not accept the score.
+func (l *Leaderboard) UploadScore(ctx context.Context, uploadScoreMethod ELeaderboardUploadScoreMethod, score int32, scoreDetails ...int32) (LeaderboardScoreUploaded_t, error) {
+	uploaded, err := Await[LeaderboardScoreUploaded_t](ctx, l.stats.uploadLeaderboardScore(l.handle, uploadScoreMethod, score, scoreDetails...))
+	if err != nil {
+		return uploaded, err
+	}
+	if !uploaded.Success {
+		return uploaded, fmt.Errorf("steamworks: uploading a score to leaderboard %s failed", l.name)
+	}
+	return uploaded, nil
+}
+
+// AttachUGC attaches a file shared with ShareFile, such as a replay, to the current user's entry.
+// Others find it as the UGC of the entry and read it with DownloadUGC.
+func (l *Leaderboard) AttachUGC(ctx context.Context, ugc UGCHandle_t) error {
+	set, err := Await[LeaderboardUGCSet_t](ctx, l.stats.AttachLeaderboardUGC(l.handle, ugc))
+	if err != nil {
+		return err
+	}
+	if set.Result != EResult_OK {
+		return fmt.Errorf("steamworks: attaching UGC to leaderboard %s failed: %d", l.name, set.Result)
+	}
+	return nil
+}
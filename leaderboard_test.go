@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TaiJiYu/go-steamworks"
+)
+
+func TestLeaderboard(t *testing.T) {
+	fake := steamworks.NewFake()
+	for i := 1; i <= 7; i++ {
+		fake.SetLeaderboardScore("Speed", steamworks.CSteamID(i), int32(i*10), int32(i))
+	}
+	startFake(t, fake)
+	ctx := context.Background()
+	us := steamworks.SteamUserStats()
+
+	if _, err := us.FindLeaderboard(ctx, "Missing"); err == nil {
+		t.Error("found a missing leaderboard")
+	}
+	l, err := us.FindLeaderboard(ctx, "Speed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Name() != "Speed" || l.EntryCount() != 7 {
+		t.Errorf("leaderboard %q has %d entries, want Speed with 7", l.Name(), l.EntryCount())
+	}
+
+	page, err := l.Page(ctx, 1, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page) != 3 || page[0].GlobalRank != 4 || len(page[0].Details) != 1 {
+		t.Errorf("page 1 = %+v, want ranks 4 to 6 with details", page)
+	}
+
+	entries, err := l.EntriesForUsers(ctx, []steamworks.CSteamID{2, 5, 99}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("entries for users = %+v, want 2", entries)
+	}
+
+	up, err := l.UploadScore(ctx, steamworks.ELeaderboardUploadScoreMethod_KeepBest, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !up.Success || up.Score != 1000 || up.GlobalRankNew != 1 {
+		t.Errorf("LeaderboardScoreUploaded_t = %+v, want rank 1", up)
+	}
+
+	c, err := us.FindOrCreateLeaderboard(ctx, "New", steamworks.ELeaderboardSortMethod_Ascending, steamworks.ELeaderboardDisplayType_TimeSeconds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.SortMethod() != steamworks.ELeaderboardSortMethod_Ascending || c.DisplayType() != steamworks.ELeaderboardDisplayType_TimeSeconds || c.EntryCount() != 0 {
+		t.Errorf("created leaderboard sorts %v, displays %v, has %d entries", c.SortMethod(), c.DisplayType(), c.EntryCount())
+	}
+}
@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"debug/elf"
+	"debug/pe"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// SDKVersion is the Steamworks SDK version this package is built against.
+const SDKVersion = "159"
+
+// ErrLibraryNotFound is returned by Load when there is no Steam API library to load.
+// A game can check for it with errors.Is and run without Steam.
+var ErrLibraryNotFound = errors.New("steamworks: Steam API library not found")
+
+// LoadOptions configures Load.
+type LoadOptions struct {
+	// Path is the library to load, e.g. a libsteam_api.so shipped beside the executable.
+	// If it is set, nothing else is tried.
+	Path string
+
+	// SearchPaths are the directories searched for the library, in order.
+	// If it is nil, the executable's directory and then the working directory are searched.
+	SearchPaths []string
+
+	// DisableEmbedded stops Load from falling back to the library embedded in the package on Linux and macOS.
+	DisableEmbedded bool
+
+	// CacheDir is where the embedded library is extracted to on Linux and macOS. A copy extracted by an earlier run
+	// is reused. If it is empty, the library is extracted to a temporary directory that is removed
+	// once the library is loaded.
+	CacheDir string
+
+	// SkipVersionCheck skips checking that the library exports the interface versions of SDKVersion.
+	SkipVersionCheck bool
+}
+
+// Load loads the Steam API library. It does nothing if the library is already loaded.
+//
+// Calling Load is optional: the first Steam API call loads the library with the default options.
+// Call it before Init to choose where the library comes from, or to detect that it is missing:
+//
+//	if err := steamworks.Load(nil); errors.Is(err, steamworks.ErrLibraryNotFound) {
+//		// Run without Steam.
+//	}
+//
+// A nil opts uses the default options.
+func Load(opts *LoadOptions) error {
+	if opts == nil {
+		opts = &LoadOptions{}
+	}
+	return loadLibrary(opts)
+}
+
+// versionedInterfaces are the interface accessors this package calls.
+// A library from an incompatible SDK exports other versions of them.
+var versionedInterfaces = []string{
+	flatAPI_SteamApps,
+	flagAPI_SteamFriends,
+	flatAPI_SteamInput,
+	flatAPI_SteamRemoteStorage,
+	flatAPI_SteamUGC,
+	flatAPI_SteamUser,
+	flatAPI_SteamUserStats,
+	flatAPI_SteamUtils,
+}
+
+// findLibrary returns the absolute path of the library file name, looking where opts says.
+// Files built for another architecture are skipped, e.g. a 32-bit libsteam_api.so in the working directory
+// of a 64-bit game.
+func (opts *LoadOptions) findLibrary(name string) (string, error) {
+	if opts.Path != "" {
+		path, err := filepath.Abs(opts.Path)
+		if err != nil {
+			return "", err
+		}
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf("%w: %v", ErrLibraryNotFound, err)
+		}
+		if !matchesArch(path) {
+			return "", fmt.Errorf("%w: %s is not built for %s", ErrLibraryNotFound, path, runtime.GOARCH)
+		}
+		return path, nil
+	}
+
+	dirs := opts.SearchPaths
+	if dirs == nil {
+		if exe, err := os.Executable(); err == nil {
+			dirs = append(dirs, filepath.Dir(exe))
+		}
+		dirs = append(dirs, ".")
+	}
+	for _, dir := range dirs {
+		// An absolute path keeps dlopen and LoadLibrary from searching the system for a bare file name.
+		path, err := filepath.Abs(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		if _, err := os.Stat(path); err == nil && matchesArch(path) {
+			return path, nil
+		}
+	}
+	return "", ErrLibraryNotFound
+}
+
+// libraryMachines are the ELF and PE machine types of the architectures Steam ships libraries for.
+var libraryMachines = map[string]struct {
+	elf elf.Machine
+	pe  uint16
+}{
+	"386":   {elf.EM_386, pe.IMAGE_FILE_MACHINE_I386},
+	"amd64": {elf.EM_X86_64, pe.IMAGE_FILE_MACHINE_AMD64},
+	"arm64": {elf.EM_AARCH64, pe.IMAGE_FILE_MACHINE_ARM64},
+}
+
+// matchesArch reports whether the library at path can be loaded by this process.
+// Files it cannot tell, such as macOS universal binaries, are assumed to match.
+func matchesArch(path string) bool {
+	want, ok := libraryMachines[runtime.GOARCH]
+	if !ok {
+		return true
+	}
+	if f, err := elf.Open(path); err == nil {
+		defer f.Close()
+		return f.Machine == want.elf
+	}
+	if f, err := pe.Open(path); err == nil {
+		defer f.Close()
+		return f.Machine == want.pe
+	}
+	return true
+}
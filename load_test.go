@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// libraries returns the libraries in the repository built for this and for another architecture.
+func libraries(t *testing.T) (this, other string) {
+	switch runtime.GOARCH {
+	case "amd64":
+		return "libsteam_api64.so", "libsteam_api.so"
+	case "386":
+		return "libsteam_api.so", "libsteam_api64.so"
+	}
+	t.Skipf("no Linux library for %s", runtime.GOARCH)
+	return "", ""
+}
+
+func copyLibrary(t *testing.T, src, dir string) {
+	t.Helper()
+	b, err := os.ReadFile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "libsteam_api.so"), b, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMatchesArch(t *testing.T) {
+	this, other := libraries(t)
+	if !matchesArch(this) {
+		t.Errorf("matchesArch(%s) = false, want true", this)
+	}
+	if matchesArch(other) {
+		t.Errorf("matchesArch(%s) = true, want false", other)
+	}
+	// A file that is neither ELF nor PE, such as a macOS library, is assumed to match.
+	if !matchesArch("libsteam_api.dylib") {
+		t.Error("matchesArch(libsteam_api.dylib) = false, want true")
+	}
+}
+
+func TestFindLibrary(t *testing.T) {
+	this, other := libraries(t)
+	wrongArch, rightArch, empty := t.TempDir(), t.TempDir(), t.TempDir()
+	copyLibrary(t, other, wrongArch)
+	copyLibrary(t, this, rightArch)
+
+	// The library built for another architecture is skipped.
+	opts := &LoadOptions{SearchPaths: []string{empty, wrongArch, rightArch}}
+	if got, err := opts.findLibrary("libsteam_api.so"); err != nil || got != filepath.Join(rightArch, "libsteam_api.so") {
+		t.Errorf("findLibrary() = %q, %v, want the library in %s", got, err, rightArch)
+	}
+	opts = &LoadOptions{SearchPaths: []string{empty, wrongArch}}
+	if _, err := opts.findLibrary("libsteam_api.so"); !errors.Is(err, ErrLibraryNotFound) {
+		t.Errorf("findLibrary() = %v, want %v", err, ErrLibraryNotFound)
+	}
+
+	// A relative path is made absolute, so that the system does not search for it.
+	opts = &LoadOptions{Path: this}
+	if got, err := opts.findLibrary("libsteam_api.so"); err != nil || !filepath.IsAbs(got) || filepath.Base(got) != this {
+		t.Errorf("findLibrary() = %q, %v, want the absolute path of %s", got, err, this)
+	}
+	for _, path := range []string{other, filepath.Join(empty, "libsteam_api.so")} {
+		opts = &LoadOptions{Path: path}
+		if _, err := opts.findLibrary("libsteam_api.so"); !errors.Is(err, ErrLibraryNotFound) {
+			t.Errorf("findLibrary() = %v for Path %s, want %v", err, path, ErrLibraryNotFound)
+		}
+	}
+}
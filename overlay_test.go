@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks_test
+
+import (
+	"testing"
+
+	"github.com/TaiJiYu/go-steamworks"
+)
+
+func TestOverlay(t *testing.T) {
+	fake := steamworks.NewFake()
+	startFake(t, fake)
+	friends, utils := steamworks.SteamFriends(), steamworks.SteamUtils()
+	if !utils.IsOverlayEnabled() || utils.BOverlayNeedsPresent() {
+		t.Fatalf("IsOverlayEnabled() = %v, BOverlayNeedsPresent() = %v", utils.IsOverlayEnabled(), utils.BOverlayNeedsPresent())
+	}
+
+	activated := make(chan steamworks.GameOverlayActivated_t, 2)
+	defer steamworks.Subscribe(func(ev steamworks.GameOverlayActivated_t) { activated <- ev })()
+	for _, c := range []struct {
+		open func()
+		want string
+	}{
+		{func() { friends.ActivateGameOverlay(steamworks.OverlayDialog_Achievements) }, "achievements"},
+		{func() { friends.ActivateGameOverlayToUser(steamworks.OverlayUserDialog_SteamID, 76561197960265730) }, "steamid/76561197960265730"},
+		{func() {
+			friends.ActivateGameOverlayToWebPage("https://example.com/", steamworks.EActivateGameOverlayToWebPageMode_Modal)
+		}, "https://example.com/"},
+		{func() { friends.ActivateGameOverlayInviteDialog(109775240000000001) }, "invite/109775240000000001"},
+		{func() { friends.ActivateGameOverlayToStore(480, steamworks.EOverlayToStoreFlag_None) }, "store/480/0"},
+		{func() { friends.ActivateGameOverlayToStore(480, steamworks.EOverlayToStoreFlag_AddToCartAndShow) }, "store/480/2"},
+	} {
+		c.open()
+		if got := fake.Overlay(); got != c.want {
+			t.Errorf("overlay shows %q, want %q", got, c.want)
+		}
+	}
+	// The overlay opened once and stayed open.
+	if ev := receive(t, activated); !ev.Active {
+		t.Errorf("GameOverlayActivated_t = %+v, want active", ev)
+	}
+	fake.CloseOverlay()
+	if ev := receive(t, activated); ev.Active {
+		t.Errorf("GameOverlayActivated_t = %+v, want inactive", ev)
+	}
+}
@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// fileWriteChunkMax is the most FileWriteStreamWriteChunk takes at once.
+const fileWriteChunkMax = 100 << 20
+
+// FileWriteStream writes a Steam Cloud file in chunks, so a large save does not have to be
+// held in memory or written in one blocking call. The file is replaced only when the stream is
+// closed; Cancel abandons it and leaves the previous content.
+type FileWriteStream struct {
+	rs     steamRemoteStorage
+	stream UGCFileWriteStreamHandle_t
+	name   string
+	done   bool
+}
+
+// OpenFileWriteStream opens a stream that replaces file when it is closed.
+func (s steamRemoteStorage) OpenFileWriteStream(file string) (*FileWriteStream, error) {
+	stream := s.FileWriteStreamOpen(file)
+	if stream == 0 {
+		return nil, &fs.PathError{Op: "open", Path: file, Err: errors.New("steamworks: FileWriteStreamOpen failed")}
+	}
+	return &FileWriteStream{rs: s, stream: stream, name: file}, nil
+}
+
+func (w *FileWriteStream) Write(b []byte) (int, error) {
+	if w.done {
+		return 0, &fs.PathError{Op: "write", Path: w.name, Err: fs.ErrClosed}
+	}
+	var n int
+	for n < len(b) {
+		chunk := b[n:min(len(b), n+fileWriteChunkMax)]
+		if !w.rs.FileWriteStreamWriteChunk(w.stream, chunk) {
+			return n, &fs.PathError{Op: "write", Path: w.name, Err: errors.New("steamworks: FileWriteStreamWriteChunk failed")}
+		}
+		n += len(chunk)
+	}
+	return n, nil
+}
+
+// Close commits the file.
+func (w *FileWriteStream) Close() error {
+	if w.done {
+		return &fs.PathError{Op: "close", Path: w.name, Err: fs.ErrClosed}
+	}
+	w.done = true
+	if !w.rs.FileWriteStreamClose(w.stream) {
+		return &fs.PathError{Op: "close", Path: w.name, Err: errors.New("steamworks: FileWriteStreamClose failed")}
+	}
+	return nil
+}
+
+// Cancel abandons the stream. The file keeps its previous content. Cancelling a closed stream does nothing.
+func (w *FileWriteStream) Cancel() error {
+	if w.done {
+		return nil
+	}
+	w.done = true
+	if !w.rs.FileWriteStreamCancel(w.stream) {
+		return &fs.PathError{Op: "cancel", Path: w.name, Err: errors.New("steamworks: FileWriteStreamCancel failed")}
+	}
+	return nil
+}
+
+// AwaitFileRead reads file without blocking Steam. Cancelling ctx stops the wait.
+func (s steamRemoteStorage) AwaitFileRead(ctx context.Context, file string) ([]byte, error) {
+	if !s.FileExists(file) {
+		return nil, &fs.PathError{Op: "read", Path: file, Err: fs.ErrNotExist}
+	}
+	size := s.GetFileSize(file)
+	if size == 0 {
+		return []byte{}, nil
+	}
+	call := s.FileReadAsync(file, 0, uint32(size))
+	read, err := Await[RemoteStorageFileReadAsyncComplete_t](ctx, call)
+	if err != nil {
+		return nil, err
+	}
+	if read.Result != EResult_OK {
+		return nil, fmt.Errorf("steamworks: reading %s failed: %d", file, read.Result)
+	}
+	data := make([]byte, read.Read)
+	if !s.FileReadAsyncComplete(call, data) {
+		return nil, fmt.Errorf("steamworks: FileReadAsyncComplete failed for %s", file)
+	}
+	return data, nil
+}
+
+// AwaitFileWrite writes file without blocking Steam. Cancelling ctx stops the wait, not the write.
+func (s steamRemoteStorage) AwaitFileWrite(ctx context.Context, file string, data []byte) error {
+	written, err := Await[RemoteStorageFileWriteAsyncComplete_t](ctx, s.FileWriteAsync(file, data))
+	if err != nil {
+		return err
+	}
+	if written.Result != EResult_OK {
+		return fmt.Errorf("steamworks: writing %s failed: %d", file, written.Result)
+	}
+	return nil
+}
+
+// ShareFile shares the Steam Cloud file and returns the handle other users download it with,
+// e.g. to attach a replay to a leaderboard entry with Leaderboard.AttachUGC.
+func (s steamRemoteStorage) ShareFile(ctx context.Context, file string) (UGCHandle_t, error) {
+	shared, err := Await[RemoteStorageFileShareResult_t](ctx, s.FileShare(file))
+	if err != nil {
+		return 0, err
+	}
+	if shared.Result != EResult_OK {
+		return 0, fmt.Errorf("steamworks: sharing %s failed: %d", file, shared.Result)
+	}
+	return shared.File, nil
+}
+
+// DownloadUGC downloads a shared file, such as the UGC of a LeaderboardEntry, and returns its content.
+func (s steamRemoteStorage) DownloadUGC(ctx context.Context, ugc UGCHandle_t) ([]byte, error) {
+	downloaded, err := Await[RemoteStorageDownloadUGCResult_t](ctx, s.UGCDownload(ugc, 0))
+	if err != nil {
+		return nil, err
+	}
+	if downloaded.Result != EResult_OK {
+		return nil, fmt.Errorf("steamworks: downloading UGC %d failed: %d", ugc, downloaded.Result)
+	}
+
+	data := make([]byte, downloaded.SizeInBytes)
+	var n int32
+	for n < downloaded.SizeInBytes {
+		read := s.UGCRead(ugc, data[n:], uint32(n), EUGCRead_ContinueReadingUntilFinished)
+		if read <= 0 {
+			break
+		}
+		n += read
+	}
+	if n != downloaded.SizeInBytes {
+		return nil, fmt.Errorf("steamworks: reading UGC %d failed after %d of %d bytes", ugc, n, downloaded.SizeInBytes)
+	}
+	return data, nil
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TaiJiYu/go-steamworks"
+)
+
+func TestShareFile(t *testing.T) {
+	fake := steamworks.NewFake()
+	fake.SetFile("replay", []byte("replay data"))
+	startFake(t, fake)
+	ctx := context.Background()
+	rs := steamworks.SteamRemoteStorage()
+
+	if _, err := rs.ShareFile(ctx, "missing"); err == nil {
+		t.Error("shared a missing file")
+	}
+	h, err := rs.ShareFile(ctx, "replay")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := steamworks.SteamUserStats().FindOrCreateLeaderboard(ctx, "Replays", steamworks.ELeaderboardSortMethod_Descending, steamworks.ELeaderboardDisplayType_Numeric)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.UploadScore(ctx, steamworks.ELeaderboardUploadScoreMethod_ForceUpdate, 5); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.AttachUGC(ctx, h); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := l.Page(ctx, 0, 10, 0)
+	if err != nil || len(entries) != 1 {
+		t.Fatal(entries, err)
+	}
+	data, err := rs.DownloadUGC(ctx, entries[0].UGC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "replay data" {
+		t.Errorf("DownloadUGC() = %q, want %q", data, "replay data")
+	}
+}
+
+func TestRemoteStorage(t *testing.T) {
+	fake := steamworks.NewFake()
+	fake.CloudEnabledForAccount = true
+	fake.CloudQuota = 10
+	startFake(t, fake)
+	rs := steamworks.SteamRemoteStorage()
+
+	if !rs.FileWrite("b", []byte("1234")) || !rs.FileWrite("a", []byte("12")) {
+		t.Fatal("FileWrite failed")
+	}
+	if rs.FileWrite("c", []byte("123456")) {
+		t.Error("FileWrite succeeded beyond the quota")
+	}
+	if total, available, ok := rs.GetQuota(); !ok || total != 10 || available != 4 {
+		t.Errorf("GetQuota() = %d, %d, %v, want 10, 4, true", total, available, ok)
+	}
+
+	var names []string
+	for i := int32(0); i < rs.GetFileCount(); i++ {
+		name, _ := rs.GetFileNameAndSize(i)
+		names = append(names, name)
+	}
+	if len(names) != 2 {
+		t.Errorf("files = %q, want a and b", names)
+	}
+
+	data := make([]byte, rs.GetFileSize("b"))
+	if n := rs.FileRead("b", data); n != 4 || string(data) != "1234" {
+		t.Errorf("FileRead() = %d, %q", n, data)
+	}
+	if !rs.FilePersisted("a") || !rs.FileForget("a") || rs.FilePersisted("a") {
+		t.Error("FileForget did not stop a from being persisted")
+	}
+	if !rs.FileDelete("a") || rs.FileExists("a") {
+		t.Error("FileDelete did not remove a")
+	}
+}
@@ -0,0 +1,552 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrSaveCorrupt is returned when the files of a save slot do not match its manifest,
+// e.g. because the game crashed while writing them.
+var ErrSaveCorrupt = errors.New("steamworks: save slot does not match its manifest")
+
+// saveManifestName is the name of the manifest, both locally and in Steam Cloud.
+const saveManifestName = "manifest.json"
+
+// saveVersionLen is the length of the folder name each version of a slot is written to, a prefix of its hash.
+const saveVersionLen = 16
+
+// SaveSlot describes a saved version of a save slot.
+type SaveSlot struct {
+	// Hash identifies the content of all the files of the slot.
+	Hash string `json:"hash"`
+	// Files maps the names of the files of the slot to their SHA-256 hashes.
+	Files map[string]string `json:"files"`
+	Saved time.Time         `json:"saved"`
+}
+
+// version returns the folder the files of the slot are kept in, so that a new version can be written
+// beside the old one and the manifest switched over once it is complete.
+func (s *SaveSlot) version() string {
+	return s.Hash[:saveVersionLen]
+}
+
+// SaveConflict is a slot that was changed both locally and in Steam Cloud since it was last synchronised.
+// The game decides which to keep, usually by asking the player, and passes it to Resolve.
+type SaveConflict struct {
+	Slot  string
+	Local SaveSlot
+	Cloud SaveSlot
+}
+
+// ErrSaveConflict is wrapped by the SaveConflictError returned by Save when another machine changed
+// the slot in Steam Cloud since this one last synchronised it.
+var ErrSaveConflict = errors.New("steamworks: save slot was changed in Steam Cloud")
+
+// SaveConflictError is returned by Save when the slot was saved locally but not to Steam Cloud,
+// because the Steam Cloud slot has changed since the last Sync. The game resolves it like a conflict
+// returned by Sync.
+type SaveConflictError struct {
+	SaveConflict
+}
+
+func (e *SaveConflictError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrSaveConflict, e.Slot)
+}
+
+func (e *SaveConflictError) Unwrap() error {
+	return ErrSaveConflict
+}
+
+type saveManifest struct {
+	Slots map[string]*SaveSlot `json:"slots"`
+	// Synced maps each slot to the hash both sides last agreed on. It is only kept locally.
+	Synced map[string]string `json:"synced,omitempty"`
+}
+
+// SaveSync keeps save slots in a local directory and in Steam Cloud and tells the game when they diverge.
+//
+// Each slot is a set of files that is always written as a whole. Every version of a slot is written to
+// a folder of its own, and a manifest on each side switches to it only once all its files are written,
+// so a crash or a failed upload leaves the previous version in place. The manifest also records the hashes
+// of the files, so that a slot damaged later is detected rather than loaded, and Sync restores it from Steam Cloud.
+type SaveSync struct {
+	dir    string
+	prefix string
+	rs     ISteamRemoteStorage
+
+	m sync.Mutex
+}
+
+// NewSaveSync returns a SaveSync that keeps local copies in the directory dir and the Steam Cloud copies under
+// the folder prefix, e.g. "saves". Init must have succeeded.
+func NewSaveSync(dir, prefix string) *SaveSync {
+	return &SaveSync{
+		dir:    dir,
+		prefix: strings.Trim(prefix, "/"),
+		rs:     SteamRemoteStorage(),
+	}
+}
+
+// Save writes the files of slot locally and to Steam Cloud, replacing the files it had.
+// If only the Steam Cloud write fails, the local slot is saved and a later Sync uploads it.
+// If the Steam Cloud slot has changed since the last Sync, it is kept and Save returns
+// a *SaveConflictError after saving locally.
+func (s *SaveSync) Save(slot string, files map[string][]byte) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if err := validSlot(slot, files); err != nil {
+		return err
+	}
+	info := newSaveSlot(files, time.Now())
+
+	local, err := s.localManifest()
+	if err != nil {
+		return err
+	}
+	cloud, err := s.cloudManifest()
+	if err != nil {
+		return err
+	}
+	if err := s.writeLocal(local, slot, info, files); err != nil {
+		return err
+	}
+	if c := cloud.Slots[slot]; c != nil && c.Hash != local.Synced[slot] && c.Hash != info.Hash {
+		return &SaveConflictError{SaveConflict{Slot: slot, Local: *info, Cloud: *c}}
+	}
+	if err := s.writeCloud(cloud, slot, info, files); err != nil {
+		return err
+	}
+	local.Synced[slot] = info.Hash
+	return s.writeLocalManifest(local)
+}
+
+// Load returns the local files of slot. It returns an error wrapping ErrSaveCorrupt if they do not match the manifest.
+func (s *SaveSync) Load(slot string) (map[string][]byte, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	local, err := s.localManifest()
+	if err != nil {
+		return nil, err
+	}
+	info, ok := local.Slots[slot]
+	if !ok {
+		return nil, &fs.PathError{Op: "load", Path: slot, Err: fs.ErrNotExist}
+	}
+	return s.readLocal(slot, info)
+}
+
+// Slots returns the names of the local slots, sorted.
+func (s *SaveSync) Slots() ([]string, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	local, err := s.localManifest()
+	if err != nil {
+		return nil, err
+	}
+	slots := make([]string, 0, len(local.Slots))
+	for slot := range local.Slots {
+		slots = append(slots, slot)
+	}
+	sort.Strings(slots)
+	return slots, nil
+}
+
+// Sync brings the local slots and the Steam Cloud slots up to date with each other. A slot changed on only one
+// side is copied to the other. Slots changed on both sides are left alone and returned as conflicts.
+// A local slot that does not match its manifest is downloaded again from Steam Cloud.
+func (s *SaveSync) Sync() ([]SaveConflict, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	local, err := s.localManifest()
+	if err != nil {
+		return nil, err
+	}
+	cloud, err := s.cloudManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	slots := map[string]struct{}{}
+	for slot := range local.Slots {
+		slots[slot] = struct{}{}
+	}
+	for slot := range cloud.Slots {
+		slots[slot] = struct{}{}
+	}
+	names := make([]string, 0, len(slots))
+	for slot := range slots {
+		names = append(names, slot)
+	}
+	sort.Strings(names)
+
+	var conflicts []SaveConflict
+	var errs []error
+	for _, slot := range names {
+		l, c := local.Slots[slot], cloud.Slots[slot]
+		if l != nil {
+			if _, err := s.readLocal(slot, l); err != nil {
+				if c == nil || !errors.Is(err, ErrSaveCorrupt) {
+					errs = append(errs, err)
+				} else if err := s.download(local, cloud, slot); err != nil {
+					errs = append(errs, err)
+				}
+				continue
+			}
+		}
+		synced := local.Synced[slot]
+		switch {
+		case l != nil && c != nil && l.Hash == c.Hash:
+			local.Synced[slot] = l.Hash
+		case c == nil || (l != nil && c.Hash == synced):
+			// Only the local slot changed.
+			if err := s.upload(local, cloud, slot); err != nil {
+				errs = append(errs, err)
+			}
+		case l == nil || l.Hash == synced:
+			// Only the cloud slot changed.
+			if err := s.download(local, cloud, slot); err != nil {
+				errs = append(errs, err)
+			}
+		default:
+			conflicts = append(conflicts, SaveConflict{Slot: slot, Local: *l, Cloud: *c})
+		}
+	}
+	if err := s.writeLocalManifest(local); err != nil {
+		errs = append(errs, err)
+	}
+	return conflicts, errors.Join(errs...)
+}
+
+// Resolve settles a conflict reported by Sync, by copying the local slot to Steam Cloud if keepLocal is true,
+// and the Steam Cloud slot to the local directory otherwise.
+func (s *SaveSync) Resolve(slot string, keepLocal bool) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	local, err := s.localManifest()
+	if err != nil {
+		return err
+	}
+	cloud, err := s.cloudManifest()
+	if err != nil {
+		return err
+	}
+	if keepLocal {
+		err = s.upload(local, cloud, slot)
+	} else {
+		err = s.download(local, cloud, slot)
+	}
+	if err != nil {
+		return err
+	}
+	return s.writeLocalManifest(local)
+}
+
+func (s *SaveSync) upload(local, cloud *saveManifest, slot string) error {
+	info, ok := local.Slots[slot]
+	if !ok {
+		return &fs.PathError{Op: "upload", Path: slot, Err: fs.ErrNotExist}
+	}
+	files, err := s.readLocal(slot, info)
+	if err != nil {
+		return err
+	}
+	if err := s.writeCloud(cloud, slot, info, files); err != nil {
+		return err
+	}
+	local.Synced[slot] = info.Hash
+	return nil
+}
+
+func (s *SaveSync) download(local, cloud *saveManifest, slot string) error {
+	info, ok := cloud.Slots[slot]
+	if !ok {
+		return &fs.PathError{Op: "download", Path: slot, Err: fs.ErrNotExist}
+	}
+	files, err := readSlot(slot, info, func(name string) ([]byte, error) {
+		return s.readCloud(s.cloudPath(slot, info, name))
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.writeLocal(local, slot, info, files); err != nil {
+		return err
+	}
+	local.Synced[slot] = info.Hash
+	return nil
+}
+
+// writeLocal writes the local files of slot to a new version folder and then switches the local manifest
+// over to it. The versions it replaces are removed afterwards.
+func (s *SaveSync) writeLocal(local *saveManifest, slot string, info *SaveSlot, files map[string][]byte) error {
+	old := local.Slots[slot]
+	for name, data := range files {
+		if err := writeFileAtomic(s.localPath(slot, info, name), data); err != nil {
+			if old == nil || old.version() != info.version() {
+				os.RemoveAll(filepath.Join(s.dir, slot, info.version()))
+			}
+			return err
+		}
+	}
+	local.Slots[slot] = info
+	if err := s.writeLocalManifest(local); err != nil {
+		if old != nil {
+			local.Slots[slot] = old
+		} else {
+			delete(local.Slots, slot)
+		}
+		return err
+	}
+
+	// Besides the version just replaced, this removes any left by a crash before the switch.
+	entries, err := os.ReadDir(filepath.Join(s.dir, slot))
+	if err != nil {
+		return nil
+	}
+	for _, e := range entries {
+		if e.Name() != info.version() {
+			os.RemoveAll(filepath.Join(s.dir, slot, e.Name()))
+		}
+	}
+	return nil
+}
+
+// writeCloud writes the Steam Cloud files of slot and then the cloud manifest, in one batch.
+// The files go to a new version folder, so the slot the cloud manifest describes stays intact until
+// the manifest switches over. cloud is only updated if everything was written.
+func (s *SaveSync) writeCloud(cloud *saveManifest, slot string, info *SaveSlot, files map[string][]byte) error {
+	old := cloud.Slots[slot]
+	next := &saveManifest{Slots: maps.Clone(cloud.Slots)}
+	next.Slots[slot] = info
+	manifest, err := json.Marshal(next)
+	if err != nil {
+		return err
+	}
+
+	if !s.rs.BeginFileWriteBatch() {
+		return errors.New("steamworks: BeginFileWriteBatch failed")
+	}
+	defer s.rs.EndFileWriteBatch()
+
+	// removeNew deletes what was written of the new version after a failure. The version the manifest
+	// describes is left alone, even if it has the same content.
+	removeNew := func() {
+		if old != nil && old.version() == info.version() {
+			return
+		}
+		for name := range files {
+			s.rs.FileDelete(s.cloudPath(slot, info, name))
+		}
+	}
+	for name, data := range files {
+		if !s.rs.FileWrite(s.cloudPath(slot, info, name), data) {
+			removeNew()
+			return fmt.Errorf("steamworks: writing %s to Steam Cloud failed", s.cloudPath(slot, info, name))
+		}
+	}
+	if !s.rs.FileWrite(s.cloudManifestPath(), manifest) {
+		removeNew()
+		return fmt.Errorf("steamworks: writing %s to Steam Cloud failed", s.cloudManifestPath())
+	}
+	cloud.Slots[slot] = info
+
+	if old != nil && old.version() != info.version() {
+		for name := range old.Files {
+			s.rs.FileDelete(s.cloudPath(slot, old, name))
+		}
+	}
+	return nil
+}
+
+func (s *SaveSync) localManifest() (*saveManifest, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, saveManifestName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return newSaveManifest(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeSaveManifest(data)
+}
+
+func (s *SaveSync) writeLocalManifest(local *saveManifest) error {
+	data, err := json.MarshalIndent(local, "", "\t")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(s.dir, saveManifestName), data)
+}
+
+func (s *SaveSync) cloudManifest() (*saveManifest, error) {
+	name := s.cloudManifestPath()
+	if !s.rs.FileExists(name) {
+		return newSaveManifest(), nil
+	}
+	data, err := s.readCloud(name)
+	if err != nil {
+		return nil, err
+	}
+	m, err := decodeSaveManifest(data)
+	if err != nil {
+		return nil, err
+	}
+	// Only the local manifest knows what was synchronised.
+	m.Synced = map[string]string{}
+	return m, nil
+}
+
+func (s *SaveSync) readCloud(name string) ([]byte, error) {
+	if !s.rs.FileExists(name) {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+	data := make([]byte, s.rs.GetFileSize(name))
+	if len(data) > 0 && int(s.rs.FileRead(name, data)) != len(data) {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: errors.New("steamworks: FileRead failed")}
+	}
+	return data, nil
+}
+
+func (s *SaveSync) readLocal(slot string, info *SaveSlot) (map[string][]byte, error) {
+	return readSlot(slot, info, func(name string) ([]byte, error) {
+		return os.ReadFile(s.localPath(slot, info, name))
+	})
+}
+
+func (s *SaveSync) localPath(slot string, info *SaveSlot, name string) string {
+	return filepath.Join(s.dir, slot, info.version(), filepath.FromSlash(name))
+}
+
+func (s *SaveSync) cloudPath(slot string, info *SaveSlot, name string) string {
+	return path.Join(s.prefix, slot, info.version(), name)
+}
+
+func (s *SaveSync) cloudManifestPath() string {
+	return path.Join(s.prefix, saveManifestName)
+}
+
+func newSaveManifest() *saveManifest {
+	return &saveManifest{
+		Slots:  map[string]*SaveSlot{},
+		Synced: map[string]string{},
+	}
+}
+
+func decodeSaveManifest(data []byte) (*saveManifest, error) {
+	m := newSaveManifest()
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSaveCorrupt, err)
+	}
+	if m.Slots == nil {
+		m.Slots = map[string]*SaveSlot{}
+	}
+	if m.Synced == nil {
+		m.Synced = map[string]string{}
+	}
+	for slot, info := range m.Slots {
+		if info == nil || len(info.Hash) < saveVersionLen {
+			return nil, fmt.Errorf("%w: %s has no hash", ErrSaveCorrupt, slot)
+		}
+	}
+	return m, nil
+}
+
+func newSaveSlot(files map[string][]byte, saved time.Time) *SaveSlot {
+	info := &SaveSlot{
+		Files: map[string]string{},
+		Saved: saved,
+	}
+	names := make([]string, 0, len(files))
+	for name, data := range files {
+		sum := sha256.Sum256(data)
+		info.Files[name] = hex.EncodeToString(sum[:])
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	h := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(h, "%s\x00%s\n", name, info.Files[name])
+	}
+	info.Hash = hex.EncodeToString(h.Sum(nil))
+	return info
+}
+
+// readSlot reads the files of a slot with read and checks them against info.
+func readSlot(slot string, info *SaveSlot, read func(name string) ([]byte, error)) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	for name, want := range info.Files {
+		data, err := read(name)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s: %s is missing", ErrSaveCorrupt, slot, name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(data)
+		if hex.EncodeToString(sum[:]) != want {
+			return nil, fmt.Errorf("%w: %s: %s has changed", ErrSaveCorrupt, slot, name)
+		}
+		files[name] = data
+	}
+	return files, nil
+}
+
+func validSlot(slot string, files map[string][]byte) error {
+	if !fs.ValidPath(slot) || slot == "." || slot == saveManifestName || strings.Contains(slot, "/") {
+		return &fs.PathError{Op: "save", Path: slot, Err: fs.ErrInvalid}
+	}
+	for name := range files {
+		if !fs.ValidPath(name) || name == "." {
+			return &fs.PathError{Op: "save", Path: path.Join(slot, name), Err: fs.ErrInvalid}
+		}
+	}
+	return nil
+}
+
+// writeFileAtomic replaces the file name with data, so that it has either its old or its new content after a crash.
+func writeFileAtomic(name string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Rename(f.Name(), name); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TaiJiYu/go-steamworks"
+)
+
+func loadSlot(t *testing.T, s *steamworks.SaveSync, slot, name string) string {
+	t.Helper()
+	files, err := s.Load(slot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(files[name])
+}
+
+func TestSaveSync(t *testing.T) {
+	startFake(t, steamworks.NewFake())
+	a := steamworks.NewSaveSync(t.TempDir(), "saves")
+	b := steamworks.NewSaveSync(t.TempDir(), "saves")
+
+	if err := a.Save("slot1", map[string][]byte{"world.bin": []byte("w1"), "player.json": []byte("p1")}); err != nil {
+		t.Fatal(err)
+	}
+	if conflicts, err := b.Sync(); err != nil || len(conflicts) != 0 {
+		t.Fatal(conflicts, err)
+	}
+	if got := loadSlot(t, b, "slot1", "world.bin"); got != "w1" {
+		t.Errorf("world.bin = %q, want w1", got)
+	}
+	if slots, err := b.Slots(); err != nil || len(slots) != 1 || slots[0] != "slot1" {
+		t.Errorf("Slots() = %q, %v", slots, err)
+	}
+
+	if err := b.Save("slot1", map[string][]byte{"world.bin": []byte("w2")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if got := loadSlot(t, a, "slot1", "world.bin"); got != "w2" {
+		t.Errorf("world.bin = %q, want w2", got)
+	}
+	if err := a.Save("manifest.json", map[string][]byte{"x": nil}); err == nil {
+		t.Error("saved a slot named like the manifest")
+	}
+}
+
+func TestSaveSyncConflict(t *testing.T) {
+	startFake(t, steamworks.NewFake())
+	a := steamworks.NewSaveSync(t.TempDir(), "saves")
+	b := steamworks.NewSaveSync(t.TempDir(), "saves")
+
+	if err := a.Save("slot1", map[string][]byte{"world.bin": []byte("w1")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Both machines save without synchronising in between. b's save must not replace a's in Steam Cloud.
+	if err := a.Save("slot1", map[string][]byte{"world.bin": []byte("w2")}); err != nil {
+		t.Fatal(err)
+	}
+	err := b.Save("slot1", map[string][]byte{"world.bin": []byte("w3")})
+	var conflict *steamworks.SaveConflictError
+	if !errors.As(err, &conflict) || !errors.Is(err, steamworks.ErrSaveConflict) || conflict.Slot != "slot1" {
+		t.Fatalf("Save() = %v, want a conflict on slot1", err)
+	}
+	if got := loadSlot(t, b, "slot1", "world.bin"); got != "w3" {
+		t.Errorf("b's world.bin = %q, want its local save w3", got)
+	}
+	if _, err := a.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if got := loadSlot(t, a, "slot1", "world.bin"); got != "w2" {
+		t.Errorf("a's world.bin = %q after Sync, want w2", got)
+	}
+
+	conflicts, err := b.Sync()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Slot != "slot1" {
+		t.Fatalf("conflicts = %+v, want slot1", conflicts)
+	}
+	if err := b.Resolve("slot1", true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if got := loadSlot(t, a, "slot1", "world.bin"); got != "w3" {
+		t.Errorf("a's world.bin = %q after Resolve, want w3", got)
+	}
+}
+
+func TestSaveSyncPartialUpload(t *testing.T) {
+	fake := steamworks.NewFake()
+	fake.CloudQuota = 4000
+	startFake(t, fake)
+	a := steamworks.NewSaveSync(t.TempDir(), "saves")
+	b := steamworks.NewSaveSync(t.TempDir(), "saves")
+
+	for _, slot := range []string{"a", "b"} {
+		if err := a.Save(slot, map[string][]byte{"x": []byte(slot + "1")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Slot a no longer fits, but b still uploads and the cloud keeps the last good version of a.
+	if err := a.Save("a", map[string][]byte{"x": []byte(strings.Repeat("a", 5000))}); err == nil {
+		t.Fatal("saved beyond the quota")
+	}
+	if err := a.Save("b", map[string][]byte{"x": []byte("b2")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Sync(); err == nil {
+		t.Error("Sync uploaded a slot beyond the quota")
+	}
+	if _, err := b.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	for slot, want := range map[string]string{"a": "a1", "b": "b2"} {
+		if got := loadSlot(t, b, slot, "x"); got != want {
+			t.Errorf("slot %s = %q, want %q", slot, got, want)
+		}
+	}
+}
+
+func TestSaveSyncRestore(t *testing.T) {
+	startFake(t, steamworks.NewFake())
+	dir := t.TempDir()
+	s := steamworks.NewSaveSync(dir, "saves")
+
+	if err := s.Save("s", map[string][]byte{"x": []byte("one")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save("s", map[string][]byte{"x": []byte("two")}); err != nil {
+		t.Fatal(err)
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, "s", "*", "x"))
+	if err != nil || len(matches) != 1 {
+		t.Fatalf("slot files = %q, want only the latest version", matches)
+	}
+	if err := os.WriteFile(matches[0], []byte("torn"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Load("s"); !errors.Is(err, steamworks.ErrSaveCorrupt) {
+		t.Fatalf("Load() = %v, want %v", err, steamworks.ErrSaveCorrupt)
+	}
+	if _, err := s.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if got := loadSlot(t, s, "s", "x"); got != "two" {
+		t.Errorf("x = %q after Sync, want two", got)
+	}
+
+	// A crash before the manifest switched over leaves a version folder behind, which the next save removes.
+	if err := os.MkdirAll(filepath.Join(dir, "s", "0123456789abcdef"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save("s", map[string][]byte{"x": []byte("three")}); err != nil {
+		t.Fatal(err)
+	}
+	if entries, err := os.ReadDir(filepath.Join(dir, "s")); err != nil || len(entries) != 1 {
+		t.Errorf("slot folder holds %d versions, want 1", len(entries))
+	}
+}
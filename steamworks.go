@@ -5,14 +5,31 @@
 
 package steamworks
 
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
 type AppId_t uint32
 type CSteamID uint64
 type InputHandle_t uint64
 type SteamAPICall_t uint64
+type HSteamPipe int32
 type SteamLeaderboard_t uint64
+type UGCFileWriteStreamHandle_t uint64
 type ESteamAPIInitResult int32
 type SteamLeaderboardEntries_t uint64
 type UGCHandle_t uint64
+type PublishedFileId_t uint64
+type UGCQueryHandle_t uint64
+type UGCUpdateHandle_t uint64
+type AccountID_t uint32
 
 const (
 	ESteamAPIInitResult_OK              ESteamAPIInitResult = 0
@@ -21,6 +38,32 @@ const (
 	ESteamAPIInitResult_VersionMismatch ESteamAPIInitResult = 3
 )
 
+type ESteamAPICallFailure int32
+
+const (
+	ESteamAPICallFailure_None               ESteamAPICallFailure = -1
+	ESteamAPICallFailure_SteamGone          ESteamAPICallFailure = 0
+	ESteamAPICallFailure_NetworkFailure     ESteamAPICallFailure = 1
+	ESteamAPICallFailure_InvalidHandle      ESteamAPICallFailure = 2
+	ESteamAPICallFailure_MismatchedCallback ESteamAPICallFailure = 3
+)
+
+func (e ESteamAPICallFailure) String() string {
+	switch e {
+	case ESteamAPICallFailure_None:
+		return "none"
+	case ESteamAPICallFailure_SteamGone:
+		return "steam gone"
+	case ESteamAPICallFailure_NetworkFailure:
+		return "network failure"
+	case ESteamAPICallFailure_InvalidHandle:
+		return "invalid handle"
+	case ESteamAPICallFailure_MismatchedCallback:
+		return "mismatched callback"
+	}
+	return fmt.Sprintf("ESteamAPICallFailure(%d)", int32(e))
+}
+
 type ESteamInputType int32
 
 const (
@@ -47,10 +90,58 @@ const (
 	_STEAM_INPUT_MAX_COUNT = 16
 )
 
+// EOverlayToStoreFlag is what ActivateGameOverlayToStore does with the app besides showing its store page.
 type EOverlayToStoreFlag int32
 
 const (
-	EOverlayToStoreFlag_None EOverlayToStoreFlag = 0
+	EOverlayToStoreFlag_None             EOverlayToStoreFlag = 0
+	EOverlayToStoreFlag_AddToCart        EOverlayToStoreFlag = 1
+	EOverlayToStoreFlag_AddToCartAndShow EOverlayToStoreFlag = 2
+)
+
+// OverlayDialog is a dialog ActivateGameOverlay opens.
+type OverlayDialog string
+
+const (
+	OverlayDialog_Friends           OverlayDialog = "friends"
+	OverlayDialog_Community         OverlayDialog = "community"
+	OverlayDialog_Players           OverlayDialog = "players"
+	OverlayDialog_Settings          OverlayDialog = "settings"
+	OverlayDialog_OfficialGameGroup OverlayDialog = "officialgamegroup"
+	OverlayDialog_Stats             OverlayDialog = "stats"
+	OverlayDialog_Achievements      OverlayDialog = "achievements"
+)
+
+// OverlayUserDialog is a dialog about a user ActivateGameOverlayToUser opens.
+type OverlayUserDialog string
+
+const (
+	OverlayUserDialog_SteamID             OverlayUserDialog = "steamid" // The user's profile
+	OverlayUserDialog_Chat                OverlayUserDialog = "chat"
+	OverlayUserDialog_JoinTrade           OverlayUserDialog = "jointrade"
+	OverlayUserDialog_Stats               OverlayUserDialog = "stats"
+	OverlayUserDialog_Achievements        OverlayUserDialog = "achievements"
+	OverlayUserDialog_FriendAdd           OverlayUserDialog = "friendadd"
+	OverlayUserDialog_FriendRemove        OverlayUserDialog = "friendremove"
+	OverlayUserDialog_FriendRequestAccept OverlayUserDialog = "friendrequestaccept"
+	OverlayUserDialog_FriendRequestIgnore OverlayUserDialog = "friendrequestignore"
+)
+
+type EActivateGameOverlayToWebPageMode int32
+
+const (
+	EActivateGameOverlayToWebPageMode_Default EActivateGameOverlayToWebPageMode = 0
+	// EActivateGameOverlayToWebPageMode_Modal opens the page in its own overlay, which closes with the page.
+	EActivateGameOverlayToWebPageMode_Modal EActivateGameOverlayToWebPageMode = 1
+)
+
+type ENotificationPosition int32
+
+const (
+	ENotificationPosition_TopLeft     ENotificationPosition = 0
+	ENotificationPosition_TopRight    ENotificationPosition = 1
+	ENotificationPosition_BottomLeft  ENotificationPosition = 2
+	ENotificationPosition_BottomRight ENotificationPosition = 3
 )
 
 type EFloatingGamepadTextInputMode int32
@@ -62,6 +153,69 @@ const (
 	EFloatingGamepadTextInputMode_ModeNumeric       EFloatingGamepadTextInputMode = 3
 )
 
+type EPersonaChange int32
+
+const (
+	EPersonaChange_Name                EPersonaChange = 1
+	EPersonaChange_Status              EPersonaChange = 2
+	EPersonaChange_ComeOnline          EPersonaChange = 4
+	EPersonaChange_GoneOffline         EPersonaChange = 8
+	EPersonaChange_GamePlayed          EPersonaChange = 16
+	EPersonaChange_GameServer          EPersonaChange = 32
+	EPersonaChange_Avatar              EPersonaChange = 64
+	EPersonaChange_JoinedSource        EPersonaChange = 128
+	EPersonaChange_LeftSource          EPersonaChange = 256
+	EPersonaChange_RelationshipChanged EPersonaChange = 512
+	EPersonaChange_NameFirstSet        EPersonaChange = 1024
+	EPersonaChange_Broadcast           EPersonaChange = 2048
+	EPersonaChange_Nickname            EPersonaChange = 4096
+	EPersonaChange_SteamLevel          EPersonaChange = 8192
+	EPersonaChange_RichPresence        EPersonaChange = 16384
+)
+
+type EPersonaState int32
+
+const (
+	EPersonaState_Offline        EPersonaState = 0
+	EPersonaState_Online         EPersonaState = 1
+	EPersonaState_Busy           EPersonaState = 2
+	EPersonaState_Away           EPersonaState = 3
+	EPersonaState_Snooze         EPersonaState = 4
+	EPersonaState_LookingToTrade EPersonaState = 5
+	EPersonaState_LookingToPlay  EPersonaState = 6
+	EPersonaState_Invisible      EPersonaState = 7
+)
+
+// EFriendFlags selects users for GetFriendCount and GetFriendByIndex.
+type EFriendFlags int32
+
+const (
+	EFriendFlag_None                 EFriendFlags = 0x00
+	EFriendFlag_Blocked              EFriendFlags = 0x01
+	EFriendFlag_FriendshipRequested  EFriendFlags = 0x02
+	EFriendFlag_Immediate            EFriendFlags = 0x04 // Regular friends
+	EFriendFlag_ClanMember           EFriendFlags = 0x08
+	EFriendFlag_OnGameServer         EFriendFlags = 0x10
+	EFriendFlag_RequestingFriendship EFriendFlags = 0x80
+	EFriendFlag_RequestingInfo       EFriendFlags = 0x100
+	EFriendFlag_Ignored              EFriendFlags = 0x200
+	EFriendFlag_IgnoredFriend        EFriendFlags = 0x400
+	EFriendFlag_ChatMember           EFriendFlags = 0x1000
+	EFriendFlag_All                  EFriendFlags = 0xFFFF
+)
+
+type EFriendRelationship int32
+
+const (
+	EFriendRelationship_None             EFriendRelationship = 0
+	EFriendRelationship_Blocked          EFriendRelationship = 1
+	EFriendRelationship_RequestRecipient EFriendRelationship = 2
+	EFriendRelationship_Friend           EFriendRelationship = 3
+	EFriendRelationship_RequestInitiator EFriendRelationship = 4
+	EFriendRelationship_Ignored          EFriendRelationship = 5
+	EFriendRelationship_IgnoredFriend    EFriendRelationship = 6
+)
+
 type ELeaderboardDisplayType int32
 
 const (
@@ -96,12 +250,209 @@ const (
 	ELeaderboardUploadScoreMethod_ForceUpdate ELeaderboardUploadScoreMethod = 2
 )
 
+type EUGCReadAction int32
+
+const (
+	EUGCRead_ContinueReadingUntilFinished EUGCReadAction = 0
+	EUGCRead_ContinueReading              EUGCReadAction = 1
+	EUGCRead_Close                        EUGCReadAction = 2
+)
+
+type ERemoteStoragePlatform uint32
+
+const (
+	ERemoteStoragePlatform_None    ERemoteStoragePlatform = 0
+	ERemoteStoragePlatform_Windows ERemoteStoragePlatform = 1 << 0
+	ERemoteStoragePlatform_OSX     ERemoteStoragePlatform = 1 << 1
+	ERemoteStoragePlatform_PS3     ERemoteStoragePlatform = 1 << 2
+	ERemoteStoragePlatform_Linux   ERemoteStoragePlatform = 1 << 3
+	ERemoteStoragePlatform_Switch  ERemoteStoragePlatform = 1 << 4
+	ERemoteStoragePlatform_Android ERemoteStoragePlatform = 1 << 5
+	ERemoteStoragePlatform_IOS     ERemoteStoragePlatform = 1 << 6
+	ERemoteStoragePlatform_All     ERemoteStoragePlatform = 0xffffffff
+)
+
+type EUserUGCList int32
+
+const (
+	EUserUGCList_Published    EUserUGCList = 0
+	EUserUGCList_VotedOn      EUserUGCList = 1
+	EUserUGCList_VotedUp      EUserUGCList = 2
+	EUserUGCList_VotedDown    EUserUGCList = 3
+	EUserUGCList_Favorited    EUserUGCList = 5
+	EUserUGCList_Subscribed   EUserUGCList = 6
+	EUserUGCList_UsedOrPlayed EUserUGCList = 7
+	EUserUGCList_Followed     EUserUGCList = 8
+)
+
+type EUGCMatchingUGCType int32
+
+const (
+	EUGCMatchingUGCType_Items              EUGCMatchingUGCType = 0
+	EUGCMatchingUGCType_Items_Mtx          EUGCMatchingUGCType = 1
+	EUGCMatchingUGCType_Items_ReadyToUse   EUGCMatchingUGCType = 2
+	EUGCMatchingUGCType_Collections        EUGCMatchingUGCType = 3
+	EUGCMatchingUGCType_Artwork            EUGCMatchingUGCType = 4
+	EUGCMatchingUGCType_Videos             EUGCMatchingUGCType = 5
+	EUGCMatchingUGCType_Screenshots        EUGCMatchingUGCType = 6
+	EUGCMatchingUGCType_AllGuides          EUGCMatchingUGCType = 7
+	EUGCMatchingUGCType_WebGuides          EUGCMatchingUGCType = 8
+	EUGCMatchingUGCType_IntegratedGuides   EUGCMatchingUGCType = 9
+	EUGCMatchingUGCType_UsableInGame       EUGCMatchingUGCType = 10
+	EUGCMatchingUGCType_ControllerBindings EUGCMatchingUGCType = 11
+	EUGCMatchingUGCType_GameManagedItems   EUGCMatchingUGCType = 12
+	EUGCMatchingUGCType_All                EUGCMatchingUGCType = -1
+)
+
+type EUserUGCListSortOrder int32
+
+const (
+	EUserUGCListSortOrder_CreationOrderDesc    EUserUGCListSortOrder = 0
+	EUserUGCListSortOrder_CreationOrderAsc     EUserUGCListSortOrder = 1
+	EUserUGCListSortOrder_TitleAsc             EUserUGCListSortOrder = 2
+	EUserUGCListSortOrder_LastUpdatedDesc      EUserUGCListSortOrder = 3
+	EUserUGCListSortOrder_SubscriptionDateDesc EUserUGCListSortOrder = 4
+	EUserUGCListSortOrder_VoteScoreDesc        EUserUGCListSortOrder = 5
+	EUserUGCListSortOrder_ForModeration        EUserUGCListSortOrder = 6
+)
+
+type EUGCQuery int32
+
+const (
+	EUGCQuery_RankedByVote                                  EUGCQuery = 0
+	EUGCQuery_RankedByPublicationDate                       EUGCQuery = 1
+	EUGCQuery_AcceptedForGameRankedByAcceptanceDate         EUGCQuery = 2
+	EUGCQuery_RankedByTrend                                 EUGCQuery = 3
+	EUGCQuery_FavoritedByFriendsRankedByPublicationDate     EUGCQuery = 4
+	EUGCQuery_CreatedByFriendsRankedByPublicationDate       EUGCQuery = 5
+	EUGCQuery_RankedByNumTimesReported                      EUGCQuery = 6
+	EUGCQuery_CreatedByFollowedUsersRankedByPublicationDate EUGCQuery = 7
+	EUGCQuery_NotYetRated                                   EUGCQuery = 8
+	EUGCQuery_RankedByTotalVotesAsc                         EUGCQuery = 9
+	EUGCQuery_RankedByVotesUp                               EUGCQuery = 10
+	EUGCQuery_RankedByTextSearch                            EUGCQuery = 11
+	EUGCQuery_RankedByTotalUniqueSubscriptions              EUGCQuery = 12
+	EUGCQuery_RankedByPlaytimeTrend                         EUGCQuery = 13
+	EUGCQuery_RankedByTotalPlaytime                         EUGCQuery = 14
+	EUGCQuery_RankedByAveragePlaytimeTrend                  EUGCQuery = 15
+	EUGCQuery_RankedByLifetimeAveragePlaytime               EUGCQuery = 16
+	EUGCQuery_RankedByPlaytimeSessionsTrend                 EUGCQuery = 17
+	EUGCQuery_RankedByLifetimePlaytimeSessions              EUGCQuery = 18
+	EUGCQuery_RankedByLastUpdatedDate                       EUGCQuery = 19
+)
+
+// EItemState is a set of flags describing a Workshop item on this computer.
+type EItemState uint32
+
+const (
+	EItemState_None            EItemState = 0
+	EItemState_Subscribed      EItemState = 1 << 0
+	EItemState_LegacyItem      EItemState = 1 << 1
+	EItemState_Installed       EItemState = 1 << 2
+	EItemState_NeedsUpdate     EItemState = 1 << 3
+	EItemState_Downloading     EItemState = 1 << 4
+	EItemState_DownloadPending EItemState = 1 << 5
+	EItemState_DisabledLocally EItemState = 1 << 6
+)
+
+type EItemStatistic int32
+
+const (
+	EItemStatistic_NumSubscriptions       EItemStatistic = 0
+	EItemStatistic_NumFavorites           EItemStatistic = 1
+	EItemStatistic_NumFollowers           EItemStatistic = 2
+	EItemStatistic_NumUniqueSubscriptions EItemStatistic = 3
+	EItemStatistic_NumUniqueFavorites     EItemStatistic = 4
+	EItemStatistic_NumUniqueFollowers     EItemStatistic = 5
+	EItemStatistic_NumUniqueWebsiteViews  EItemStatistic = 6
+	EItemStatistic_ReportScore            EItemStatistic = 7
+	EItemStatistic_NumSecondsPlayed       EItemStatistic = 8
+	EItemStatistic_NumPlaytimeSessions    EItemStatistic = 9
+	EItemStatistic_NumComments            EItemStatistic = 10
+)
+
+type EWorkshopFileType int32
+
+const (
+	EWorkshopFileType_Community              EWorkshopFileType = 0
+	EWorkshopFileType_Microtransaction       EWorkshopFileType = 1
+	EWorkshopFileType_Collection             EWorkshopFileType = 2
+	EWorkshopFileType_Art                    EWorkshopFileType = 3
+	EWorkshopFileType_Video                  EWorkshopFileType = 4
+	EWorkshopFileType_Screenshot             EWorkshopFileType = 5
+	EWorkshopFileType_Game                   EWorkshopFileType = 6
+	EWorkshopFileType_Software               EWorkshopFileType = 7
+	EWorkshopFileType_Concept                EWorkshopFileType = 8
+	EWorkshopFileType_WebGuide               EWorkshopFileType = 9
+	EWorkshopFileType_IntegratedGuide        EWorkshopFileType = 10
+	EWorkshopFileType_Merch                  EWorkshopFileType = 11
+	EWorkshopFileType_ControllerBinding      EWorkshopFileType = 12
+	EWorkshopFileType_SteamworksAccessInvite EWorkshopFileType = 13
+	EWorkshopFileType_SteamVideo             EWorkshopFileType = 14
+	EWorkshopFileType_GameManagedItem        EWorkshopFileType = 15
+)
+
+type ERemoteStoragePublishedFileVisibility int32
+
+const (
+	ERemoteStoragePublishedFileVisibility_Public      ERemoteStoragePublishedFileVisibility = 0
+	ERemoteStoragePublishedFileVisibility_FriendsOnly ERemoteStoragePublishedFileVisibility = 1
+	ERemoteStoragePublishedFileVisibility_Private     ERemoteStoragePublishedFileVisibility = 2
+	ERemoteStoragePublishedFileVisibility_Unlisted    ERemoteStoragePublishedFileVisibility = 3
+)
+
+type EItemUpdateStatus int32
+
+const (
+	EItemUpdateStatus_Invalid              EItemUpdateStatus = 0
+	EItemUpdateStatus_PreparingConfig      EItemUpdateStatus = 1
+	EItemUpdateStatus_PreparingContent     EItemUpdateStatus = 2
+	EItemUpdateStatus_UploadingContent     EItemUpdateStatus = 3
+	EItemUpdateStatus_UploadingPreviewFile EItemUpdateStatus = 4
+	EItemUpdateStatus_CommittingChanges    EItemUpdateStatus = 5
+)
+
 type ISteamApps interface {
 	BGetDLCDataByIndex(iDLC int) (appID AppId_t, available bool, pchName string, success bool)
 	BIsDlcInstalled(appID AppId_t) bool
 	GetAppInstallDir(appID AppId_t) string
 	GetCurrentGameLanguage() string
 	GetDLCCount() int32
+
+	// BIsSubscribed reports whether the user owns the running app.
+	BIsSubscribed() bool
+	// BIsSubscribedApp reports whether the user owns appID, which can be a DLC.
+	BIsSubscribedApp(appID AppId_t) bool
+	BIsLowViolence() bool
+	BIsCybercafe() bool
+	BIsVACBanned() bool
+	// BIsSubscribedFromFreeWeekend reports whether the user plays the app through a free weekend.
+	BIsSubscribedFromFreeWeekend() bool
+	// BIsTimedTrial reports whether the user plays a timed trial, and how long it lasts and has been played.
+	BIsTimedTrial() (allowed, played time.Duration, ok bool)
+	// GetAvailableGameLanguages returns the API language codes the app supports, e.g. "english".
+	GetAvailableGameLanguages() []string
+	// GetEarliestPurchaseUnixTime returns when the user bought appID, or the zero time.
+	GetEarliestPurchaseUnixTime(appID AppId_t) time.Time
+	// GetCurrentBetaName returns the beta branch the app runs on. ok is false on the default branch.
+	GetCurrentBetaName() (name string, ok bool)
+	GetAppBuildId() int32
+	// GetAppOwner returns the owner of the app, who differs from the user under Family Sharing.
+	GetAppOwner() CSteamID
+	// GetLaunchQueryParam returns a parameter of a steam://run/<appid>//?key=value launch, or "".
+	GetLaunchQueryParam(key string) string
+	// GetLaunchCommandLine returns the command line of a steam://run/<appid>//<command line> launch.
+	GetLaunchCommandLine() string
+
+	// InstallDLC starts downloading the DLC appID. DlcInstalled_t is posted once it is installed.
+	InstallDLC(appID AppId_t)
+	UninstallDLC(appID AppId_t)
+	// GetDlcDownloadProgress returns how far the download of the DLC appID is. ok is false if it is not downloading.
+	GetDlcDownloadProgress(appID AppId_t) (downloaded, total uint64, ok bool)
+	// RequestAppProofOfPurchaseKey requests the CD key of appID. AppProofOfPurchaseKeyResponse_t is posted with it.
+	RequestAppProofOfPurchaseKey(appID AppId_t)
+	// DLCs returns all the DLC of the app.
+	DLCs() []DLC
 }
 
 type ISteamInput interface {
@@ -116,6 +467,132 @@ type ISteamRemoteStorage interface {
 	FileRead(file string, data []byte) int32
 	FileDelete(file string) bool
 	GetFileSize(file string) int32
+	FileExists(file string) bool
+	// FilePersisted reports whether a file is stored in Steam Cloud, and not only on this computer.
+	FilePersisted(file string) bool
+	GetFileTimestamp(file string) time.Time
+	// FileForget removes a file from Steam Cloud but keeps it on this computer, e.g. to free quota.
+	FileForget(file string) bool
+	// SetSyncPlatforms sets the platforms a file is synchronised to. New files go to all of them.
+	SetSyncPlatforms(file string, platforms ERemoteStoragePlatform) bool
+
+	// GetFileCount and GetFileNameAndSize enumerate the user's files:
+	//
+	//	for i := int32(0); i < rs.GetFileCount(); i++ {
+	//		name, size := rs.GetFileNameAndSize(i)
+	//	}
+	GetFileCount() int32
+	GetFileNameAndSize(index int32) (name string, size int32)
+	// GetQuota returns the total and available Steam Cloud space in bytes.
+	GetQuota() (totalBytes, availableBytes uint64, success bool)
+
+	// Steam Cloud is enabled for the app only if it is enabled both for the account and for the app.
+	// SetCloudEnabledForApp is the per-game setting the user sees, and should only change at their request.
+	IsCloudEnabledForAccount() bool
+	IsCloudEnabledForApp() bool
+	SetCloudEnabledForApp(enabled bool)
+
+	// BeginFileWriteBatch and EndFileWriteBatch group writes and deletes, so that Steam uploads them
+	// together and another computer never sees only some of them.
+	BeginFileWriteBatch() bool
+	EndFileWriteBatch() bool
+
+	// FileWriteStreamOpen starts writing a file in chunks. The file is replaced once the stream is closed.
+	// OpenFileWriteStream wraps the stream functions as an io.WriteCloser.
+	FileWriteStreamOpen(file string) UGCFileWriteStreamHandle_t
+	FileWriteStreamWriteChunk(stream UGCFileWriteStreamHandle_t, data []byte) bool
+	FileWriteStreamClose(stream UGCFileWriteStreamHandle_t) bool
+	FileWriteStreamCancel(stream UGCFileWriteStreamHandle_t) bool
+	OpenFileWriteStream(file string) (*FileWriteStream, error)
+
+	// FileReadAsync reads size bytes of a file from offset without blocking. It completes with
+	// RemoteStorageFileReadAsyncComplete_t, after which FileReadAsyncComplete copies the data out.
+	FileReadAsync(file string, offset, size uint32) SteamAPICall_t
+	FileReadAsyncComplete(call SteamAPICall_t, data []byte) bool
+	// FileWriteAsync writes a file without blocking. It completes with RemoteStorageFileWriteAsyncComplete_t.
+	// data is copied, and can be reused as soon as FileWriteAsync returns.
+	FileWriteAsync(file string, data []byte) SteamAPICall_t
+	// AwaitFileRead and AwaitFileWrite wrap FileReadAsync and FileWriteAsync and wait for their results.
+	AwaitFileRead(ctx context.Context, file string) ([]byte, error)
+	AwaitFileWrite(ctx context.Context, file string, data []byte) error
+
+	// FileShare makes a file readable by other users. It completes with RemoteStorageFileShareResult_t,
+	// whose File is the handle others pass to UGCDownload.
+	FileShare(file string) SteamAPICall_t
+	// UGCDownload downloads a shared file. It completes with RemoteStorageDownloadUGCResult_t.
+	// Files with a lower priority are downloaded first; 0 is the highest.
+	UGCDownload(ugc UGCHandle_t, priority uint32) SteamAPICall_t
+	// UGCRead reads a downloaded file from offset and returns the number of bytes read.
+	UGCRead(ugc UGCHandle_t, data []byte, offset uint32, action EUGCReadAction) int32
+	// ShareFile and DownloadUGC wrap FileShare and UGCDownload and wait for their results.
+	ShareFile(ctx context.Context, file string) (UGCHandle_t, error)
+	DownloadUGC(ctx context.Context, ugc UGCHandle_t) ([]byte, error)
+}
+
+type ISteamUGC interface {
+	// CreateQueryUserUGCRequest, CreateQueryAllUGCRequest and CreateQueryUGCDetailsRequest create a query of
+	// Workshop items. The Add* and Set* functions narrow it before SendQueryUGCRequest sends it, which
+	// completes with SteamUGCQueryCompleted_t. The results are read with the GetQueryUGC* functions, and the
+	// query must be released with ReleaseQueryUGCRequest. Pages hold NumUGCResultsPerPage items and count from 1.
+	// Query and QueryItems wrap all of this.
+	CreateQueryUserUGCRequest(account AccountID_t, listType EUserUGCList, matchingType EUGCMatchingUGCType, sortOrder EUserUGCListSortOrder, creatorAppID, consumerAppID AppId_t, page uint32) UGCQueryHandle_t
+	CreateQueryAllUGCRequest(queryType EUGCQuery, matchingType EUGCMatchingUGCType, creatorAppID, consumerAppID AppId_t, page uint32) UGCQueryHandle_t
+	CreateQueryUGCDetailsRequest(ids []PublishedFileId_t) UGCQueryHandle_t
+	AddRequiredTag(query UGCQueryHandle_t, tag string) bool
+	AddExcludedTag(query UGCQueryHandle_t, tag string) bool
+	// SetMatchAnyTag makes items match if they have any of the required tags instead of all of them.
+	SetMatchAnyTag(query UGCQueryHandle_t, matchAnyTag bool) bool
+	SetSearchText(query UGCQueryHandle_t, text string) bool
+	// SetRankedByTrendDays sets the period of EUGCQuery_RankedByTrend.
+	SetRankedByTrendDays(query UGCQueryHandle_t, days uint32) bool
+	SetReturnLongDescription(query UGCQueryHandle_t, returnLongDescription bool) bool
+	SetReturnMetadata(query UGCQueryHandle_t, returnMetadata bool) bool
+	// SetLanguage sets the language of the titles and descriptions, e.g. "english".
+	SetLanguage(query UGCQueryHandle_t, language string) bool
+	SetAllowCachedResponse(query UGCQueryHandle_t, maxAgeSeconds uint32) bool
+	SendQueryUGCRequest(query UGCQueryHandle_t) SteamAPICall_t
+	GetQueryUGCResult(query UGCQueryHandle_t, index uint32) (details SteamUGCDetails_t, success bool)
+	GetQueryUGCPreviewURL(query UGCQueryHandle_t, index uint32) string
+	GetQueryUGCMetadata(query UGCQueryHandle_t, index uint32) string
+	GetQueryUGCStatistic(query UGCQueryHandle_t, index uint32, statistic EItemStatistic) (value uint64, success bool)
+	ReleaseQueryUGCRequest(query UGCQueryHandle_t) bool
+	Query(ctx context.Context, query *UGCQuery) (*UGCQueryResult, error)
+	QueryItems(ctx context.Context, ids []PublishedFileId_t) ([]UGCItem, error)
+
+	// SubscribeItem completes with RemoteStorageSubscribePublishedFileResult_t, and UnsubscribeItem with
+	// RemoteStorageUnsubscribePublishedFileResult_t. Steam downloads subscribed items in the background and
+	// posts ItemInstalled_t when one is installed. Subscribe and Unsubscribe wait for the results.
+	SubscribeItem(id PublishedFileId_t) SteamAPICall_t
+	UnsubscribeItem(id PublishedFileId_t) SteamAPICall_t
+	Subscribe(ctx context.Context, id PublishedFileId_t) error
+	Unsubscribe(ctx context.Context, id PublishedFileId_t) error
+	GetNumSubscribedItems() uint32
+	GetSubscribedItems() []PublishedFileId_t
+	GetItemState(id PublishedFileId_t) EItemState
+	// GetItemInstallInfo returns where an installed item is. It fails for items that are not installed.
+	GetItemInstallInfo(id PublishedFileId_t) (sizeOnDisk uint64, folder string, timestamp time.Time, success bool)
+	GetItemDownloadInfo(id PublishedFileId_t) (downloaded, total uint64, success bool)
+	// DownloadItem starts downloading or updating an item. It posts DownloadItemResult_t when it is done.
+	DownloadItem(id PublishedFileId_t, highPriority bool) bool
+
+	// CreateItem creates an empty item owned by the user. It completes with CreateItemResult_t.
+	CreateItem(consumerAppID AppId_t, fileType EWorkshopFileType) SteamAPICall_t
+	// StartItemUpdate starts an update of an item. The SetItem* functions change it, and SubmitItemUpdate
+	// uploads the changes and completes with SubmitItemUpdateResult_t. GetItemUpdateProgress reports how
+	// far the upload is. CreateWorkshopItem and UpdateWorkshopItem wrap all of this.
+	StartItemUpdate(consumerAppID AppId_t, id PublishedFileId_t) UGCUpdateHandle_t
+	SetItemTitle(update UGCUpdateHandle_t, title string) bool
+	SetItemDescription(update UGCUpdateHandle_t, description string) bool
+	SetItemTags(update UGCUpdateHandle_t, tags []string) bool
+	SetItemVisibility(update UGCUpdateHandle_t, visibility ERemoteStoragePublishedFileVisibility) bool
+	// SetItemContent sets the folder whose files make up the item.
+	SetItemContent(update UGCUpdateHandle_t, folder string) bool
+	// SetItemPreview sets the preview image file, which must be smaller than 1 MB.
+	SetItemPreview(update UGCUpdateHandle_t, file string) bool
+	SubmitItemUpdate(update UGCUpdateHandle_t, changeNote string) SteamAPICall_t
+	GetItemUpdateProgress(update UGCUpdateHandle_t) (status EItemUpdateStatus, bytesProcessed, bytesTotal uint64)
+	CreateWorkshopItem(consumerAppID AppId_t, fileType EWorkshopFileType) *WorkshopItemUpdate
+	UpdateWorkshopItem(consumerAppID AppId_t, id PublishedFileId_t) *WorkshopItemUpdate
 }
 
 type ISteamUser interface {
@@ -123,35 +600,139 @@ type ISteamUser interface {
 }
 
 type ISteamUserStats interface {
+	// RequestCurrentStats requests the stats and achievements of the current user. It completes with UserStatsReceived_t.
 	RequestCurrentStats() SteamAPICall_t
+	// RequestUserStats requests the stats and achievements of another user. It completes with UserStatsReceived_t.
+	RequestUserStats(steamID CSteamID) SteamAPICall_t
 	GetGlobalStats(names []string, successFunc GlobalStatsSuccessFunc)
+	// AddStat adds 1 to an int stat of the current user, whose stats must have been received.
+	// Like SetStatInt32, it takes effect on StoreStats.
 	AddStat(name string)
+
+	GetStatInt32(name string) (value int32, success bool)
+	GetStatFloat(name string) (value float32, success bool)
+	SetStatInt32(name string, value int32) bool
+	SetStatFloat(name string, value float32) bool
+	// UpdateAvgRateStat adds countThisSession over sessionLength seconds to an average rate stat.
+	UpdateAvgRateStat(name string, countThisSession float32, sessionLength float64) bool
+	// GetUserStatInt32, GetUserStatFloat and GetUserAchievement read the stats of a user requested with RequestUserStats.
+	GetUserStatInt32(steamID CSteamID, name string) (value int32, success bool)
+	GetUserStatFloat(steamID CSteamID, name string) (value float32, success bool)
+	GetUserAchievement(steamID CSteamID, name string) (achieved, success bool)
+	// ResetAllStats resets the current user's stats, and achievements too if achievementsToo is true.
+	// Like other changes, it takes effect on StoreStats.
+	ResetAllStats(achievementsToo bool) bool
+
+	GetNumAchievements() uint32
+	GetAchievementName(index uint32) string
+	// GetAchievementDisplayAttribute returns the "name", "desc" or "hidden" ("0" or "1") attribute of an achievement.
+	GetAchievementDisplayAttribute(name, key string) string
+	GetAchievementAndUnlockTime(name string) (achieved bool, unlockTime time.Time, success bool)
+	// IndicateAchievementProgress shows a progress notification. It fails for achievements that are already unlocked.
+	IndicateAchievementProgress(name string, curProgress, maxProgress uint32) bool
+	GetAchievementProgressLimitsInt32(name string) (minProgress, maxProgress int32, success bool)
+	GetAchievementProgressLimitsFloat(name string) (minProgress, maxProgress float32, success bool)
+	// RequestGlobalAchievementPercentages completes with GlobalAchievementPercentagesReady_t.
+	// The percentages can be read once it has.
+	RequestGlobalAchievementPercentages() SteamAPICall_t
+	GetAchievementAchievedPercent(name string) (percent float32, success bool)
+	// GetMostAchievedAchievementInfo and GetNextMostAchievedAchievementInfo iterate over the achievements
+	// from the most to the least achieved. The iterator is -1 at the end.
+	GetMostAchievedAchievementInfo() (iterator int32, name string, percent float32, achieved bool)
+	GetNextMostAchievedAchievementInfo(iteratorPrevious int32) (iterator int32, name string, percent float32, achieved bool)
+	// GetAchievementIcon returns the image handle of the achievement's icon for GetImageSize and GetImageRGBA.
+	// It returns 0 while the icon is being fetched; UserAchievementIconFetched_t is posted when it arrives.
+	GetAchievementIcon(name string) int32
+
 	GetAchievement(name string) (achieved, success bool)
 	SetAchievement(name string) bool
 	ClearAchievement(name string) bool
 	StoreStats() bool
 
 	// Leaderboard
+	// FindLeaderboard and FindOrCreateLeaderboard return a handle to a leaderboard, which is cached by name.
+	FindLeaderboard(ctx context.Context, name string) (*Leaderboard, error)
+	FindOrCreateLeaderboard(ctx context.Context, name string, sortMethod ELeaderboardSortMethod, displayType ELeaderboardDisplayType) (*Leaderboard, error)
+	GetLeaderboardName(leaderboard SteamLeaderboard_t) string
+	GetLeaderboardEntryCount(leaderboard SteamLeaderboard_t) int32
+	GetLeaderboardSortMethod(leaderboard SteamLeaderboard_t) ELeaderboardSortMethod
+	GetLeaderboardDisplayType(leaderboard SteamLeaderboard_t) ELeaderboardDisplayType
+	// AttachLeaderboardUGC attaches a file shared with FileShare to the current user's entry.
+	// It completes with LeaderboardUGCSet_t.
+	AttachLeaderboardUGC(leaderboard SteamLeaderboard_t, ugc UGCHandle_t) SteamAPICall_t
 	ReadLeadboard(leaderboardName string, dataRequest ELeaderboardDataRequest, rangeStart, rangeEnd int, successFunc DealLeaderboardFunc, timeoutFunc ReadTimeoutFunc, detailsMax int)
 	UploadLeaderboardScore(leaderboardName string, uploadScoreMethod ELeaderboardUploadScoreMethod, retFunc UploadRetFunc, timeoutFunc ReadTimeoutFunc, score int32, scoreDetails ...int32)
 }
 
 type ISteamUtils interface {
 	IsSteamRunningOnSteamDeck() bool
+	// IsOverlayEnabled reports whether the overlay runs and the user has it turned on.
+	IsOverlayEnabled() bool
+	// BOverlayNeedsPresent reports whether the overlay needs the game to present a frame to be drawn.
+	// A game that only presents frames when something changed should present one if this is true.
+	BOverlayNeedsPresent() bool
+	// SetOverlayNotificationPosition sets the corner in which the overlay shows notifications.
+	SetOverlayNotificationPosition(position ENotificationPosition)
+	// SetOverlayNotificationInset sets how far in pixels notifications are from the corner.
+	SetOverlayNotificationInset(horizontal, vertical int32)
+	// GetSteamUILanguage returns the API language code of the Steam client's language, e.g. "english".
+	GetSteamUILanguage() string
 	ShowFloatingGamepadTextInput(keyboardMode EFloatingGamepadTextInputMode, textFieldXPosition, textFieldYPosition, textFieldWidth, textFieldHeight int32) bool
 	GetAPICallResult(apiCall SteamAPICall_t, callbackExpected iCallbackExpected, callbaseSize int) (callback []byte, success bool, pbFailed bool)
+	GetAPICallFailureReason(apiCall SteamAPICall_t) ESteamAPICallFailure
+	// GetImageSize returns the size in pixels of an image handle, e.g. from GetAchievementIcon.
+	GetImageSize(image int32) (width, height uint32, success bool)
+	// GetImageRGBA copies an image into dest, which must hold 4*width*height bytes.
+	GetImageRGBA(image int32, dest []byte) bool
 }
 
 type ISteamFriends interface {
 	GetPersonaName() string
 	SetRichPresence(string, string) bool
-	ActivateGameOverlayToStore(appID uint32)
+	// ActivateGameOverlayToStore opens the store page of appID in the overlay, adding the app to the cart
+	// if flag says so.
+	ActivateGameOverlayToStore(appID uint32, flag EOverlayToStoreFlag)
+	// ActivateGameOverlay opens the overlay at dialog. GameOverlayActivated_t is posted when it opens and closes.
+	ActivateGameOverlay(dialog OverlayDialog)
+	ActivateGameOverlayToUser(dialog OverlayUserDialog, user CSteamID)
+	ActivateGameOverlayToWebPage(url string, mode EActivateGameOverlayToWebPageMode)
+	// ActivateGameOverlayInviteDialog opens the dialog for inviting friends to lobby.
+	ActivateGameOverlayInviteDialog(lobby CSteamID)
+
+	// GetFriendCount returns the number of users the user has a relationship in flags with.
+	GetFriendCount(flags EFriendFlags) int
+	GetFriendByIndex(index int, flags EFriendFlags) CSteamID
+	GetFriendPersonaName(friend CSteamID) string
+	GetFriendPersonaState(friend CSteamID) EPersonaState
+	// GetFriendGamePlayed returns the game friend is playing. ok is false if they are not in a game.
+	GetFriendGamePlayed(friend CSteamID) (info FriendGameInfo_t, ok bool)
+	GetFriendRelationship(friend CSteamID) EFriendRelationship
+	// GetSmallFriendAvatar returns a 32x32 image handle for ImageRGBA, or 0 if the user has no avatar.
+	GetSmallFriendAvatar(friend CSteamID) int32
+	// GetMediumFriendAvatar returns a 64x64 image handle for ImageRGBA, or 0 if the user has no avatar.
+	GetMediumFriendAvatar(friend CSteamID) int32
+	// GetLargeFriendAvatar returns a 184x184 image handle for ImageRGBA, 0 if the user has no avatar,
+	// or -1 if Steam is still loading it. AvatarImageLoaded_t is posted once it is loaded.
+	GetLargeFriendAvatar(friend CSteamID) int32
+	// RequestUserInformation requests the name, and unless requireNameOnly the avatar, of a user who is not a friend.
+	// It returns false if Steam has them already, and otherwise posts PersonaStateChange_t once it does.
+	RequestUserInformation(user CSteamID, requireNameOnly bool) bool
+	// Friends returns the users the user has a relationship in flags with, or nil if there are none.
+	Friends(flags EFriendFlags) []Friend
 }
 
 const (
 	flatAPI_RestartAppIfNecessary = "SteamAPI_RestartAppIfNecessary"
 	flatAPI_InitFlat              = "SteamAPI_InitFlat"
 	flatAPI_RunCallbacks          = "SteamAPI_RunCallbacks"
+	flatAPI_Shutdown              = "SteamAPI_Shutdown"
+	flatAPI_GetHSteamPipe         = "SteamAPI_GetHSteamPipe"
+
+	flatAPI_ManualDispatch_Init             = "SteamAPI_ManualDispatch_Init"
+	flatAPI_ManualDispatch_RunFrame         = "SteamAPI_ManualDispatch_RunFrame"
+	flatAPI_ManualDispatch_GetNextCallback  = "SteamAPI_ManualDispatch_GetNextCallback"
+	flatAPI_ManualDispatch_FreeLastCallback = "SteamAPI_ManualDispatch_FreeLastCallback"
+	flatAPI_ManualDispatch_GetAPICallResult = "SteamAPI_ManualDispatch_GetAPICallResult"
 
 	flatAPI_SteamApps                         = "SteamAPI_SteamApps_v008"
 	flatAPI_ISteamApps_BGetDLCDataByIndex     = "SteamAPI_ISteamApps_BGetDLCDataByIndex"
@@ -160,11 +741,45 @@ const (
 	flatAPI_ISteamApps_GetCurrentGameLanguage = "SteamAPI_ISteamApps_GetCurrentGameLanguage"
 	flatAPI_ISteamApps_GetDLCCount            = "SteamAPI_ISteamApps_GetDLCCount"
 
-	flagAPI_SteamFriends                             = "SteamAPI_SteamFriends_v018"
+	flatAPI_ISteamApps_BIsSubscribed                = "SteamAPI_ISteamApps_BIsSubscribed"
+	flatAPI_ISteamApps_BIsSubscribedApp             = "SteamAPI_ISteamApps_BIsSubscribedApp"
+	flatAPI_ISteamApps_BIsLowViolence               = "SteamAPI_ISteamApps_BIsLowViolence"
+	flatAPI_ISteamApps_BIsCybercafe                 = "SteamAPI_ISteamApps_BIsCybercafe"
+	flatAPI_ISteamApps_BIsVACBanned                 = "SteamAPI_ISteamApps_BIsVACBanned"
+	flatAPI_ISteamApps_BIsSubscribedFromFreeWeekend = "SteamAPI_ISteamApps_BIsSubscribedFromFreeWeekend"
+	flatAPI_ISteamApps_BIsTimedTrial                = "SteamAPI_ISteamApps_BIsTimedTrial"
+	flatAPI_ISteamApps_GetAvailableGameLanguages    = "SteamAPI_ISteamApps_GetAvailableGameLanguages"
+	flatAPI_ISteamApps_GetEarliestPurchaseUnixTime  = "SteamAPI_ISteamApps_GetEarliestPurchaseUnixTime"
+	flatAPI_ISteamApps_GetCurrentBetaName           = "SteamAPI_ISteamApps_GetCurrentBetaName"
+	flatAPI_ISteamApps_GetAppBuildId                = "SteamAPI_ISteamApps_GetAppBuildId"
+	flatAPI_ISteamApps_GetAppOwner                  = "SteamAPI_ISteamApps_GetAppOwner"
+	flatAPI_ISteamApps_GetLaunchQueryParam          = "SteamAPI_ISteamApps_GetLaunchQueryParam"
+	flatAPI_ISteamApps_GetLaunchCommandLine         = "SteamAPI_ISteamApps_GetLaunchCommandLine"
+	flatAPI_ISteamApps_InstallDLC                   = "SteamAPI_ISteamApps_InstallDLC"
+	flatAPI_ISteamApps_UninstallDLC                 = "SteamAPI_ISteamApps_UninstallDLC"
+	flatAPI_ISteamApps_GetDlcDownloadProgress       = "SteamAPI_ISteamApps_GetDlcDownloadProgress"
+	flatAPI_ISteamApps_RequestAppProofOfPurchaseKey = "SteamAPI_ISteamApps_RequestAppProofOfPurchaseKey"
+
+	flagAPI_SteamFriends                             = "SteamAPI_SteamFriends_v017"
 	flatAPI_ISteamFriends_GetPersonaName             = "SteamAPI_ISteamFriends_GetPersonaName"
 	flatAPI_ISteamFriends_SetRichPresence            = "SteamAPI_ISteamFriends_SetRichPresence"
 	flatAPI_ISteamFriends_ActivateGameOverlayToStore = "SteamAPI_ISteamFriends_ActivateGameOverlayToStore"
 
+	flatAPI_ISteamFriends_ActivateGameOverlay             = "SteamAPI_ISteamFriends_ActivateGameOverlay"
+	flatAPI_ISteamFriends_ActivateGameOverlayToUser       = "SteamAPI_ISteamFriends_ActivateGameOverlayToUser"
+	flatAPI_ISteamFriends_ActivateGameOverlayToWebPage    = "SteamAPI_ISteamFriends_ActivateGameOverlayToWebPage"
+	flatAPI_ISteamFriends_ActivateGameOverlayInviteDialog = "SteamAPI_ISteamFriends_ActivateGameOverlayInviteDialog"
+	flatAPI_ISteamFriends_GetFriendCount                  = "SteamAPI_ISteamFriends_GetFriendCount"
+	flatAPI_ISteamFriends_GetFriendByIndex                = "SteamAPI_ISteamFriends_GetFriendByIndex"
+	flatAPI_ISteamFriends_GetFriendPersonaName            = "SteamAPI_ISteamFriends_GetFriendPersonaName"
+	flatAPI_ISteamFriends_GetFriendPersonaState           = "SteamAPI_ISteamFriends_GetFriendPersonaState"
+	flatAPI_ISteamFriends_GetFriendGamePlayed             = "SteamAPI_ISteamFriends_GetFriendGamePlayed"
+	flatAPI_ISteamFriends_GetFriendRelationship           = "SteamAPI_ISteamFriends_GetFriendRelationship"
+	flatAPI_ISteamFriends_GetSmallFriendAvatar            = "SteamAPI_ISteamFriends_GetSmallFriendAvatar"
+	flatAPI_ISteamFriends_GetMediumFriendAvatar           = "SteamAPI_ISteamFriends_GetMediumFriendAvatar"
+	flatAPI_ISteamFriends_GetLargeFriendAvatar            = "SteamAPI_ISteamFriends_GetLargeFriendAvatar"
+	flatAPI_ISteamFriends_RequestUserInformation          = "SteamAPI_ISteamFriends_RequestUserInformation"
+
 	flatAPI_SteamInput                          = "SteamAPI_SteamInput_v006"
 	flatAPI_ISteamInput_GetConnectedControllers = "SteamAPI_ISteamInput_GetConnectedControllers"
 	flatAPI_ISteamInput_GetInputTypeForHandle   = "SteamAPI_ISteamInput_GetInputTypeForHandle"
@@ -176,17 +791,103 @@ const (
 	flatAPI_ISteamRemoteStorage_FileRead    = "SteamAPI_ISteamRemoteStorage_FileRead"
 	flatAPI_ISteamRemoteStorage_FileDelete  = "SteamAPI_ISteamRemoteStorage_FileDelete"
 	flatAPI_ISteamRemoteStorage_GetFileSize = "SteamAPI_ISteamRemoteStorage_GetFileSize"
+	flatAPI_ISteamRemoteStorage_FileShare   = "SteamAPI_ISteamRemoteStorage_FileShare"
+
+	flatAPI_ISteamRemoteStorage_BeginFileWriteBatch       = "SteamAPI_ISteamRemoteStorage_BeginFileWriteBatch"
+	flatAPI_ISteamRemoteStorage_EndFileWriteBatch         = "SteamAPI_ISteamRemoteStorage_EndFileWriteBatch"
+	flatAPI_ISteamRemoteStorage_FileWriteStreamOpen       = "SteamAPI_ISteamRemoteStorage_FileWriteStreamOpen"
+	flatAPI_ISteamRemoteStorage_FileWriteStreamWriteChunk = "SteamAPI_ISteamRemoteStorage_FileWriteStreamWriteChunk"
+	flatAPI_ISteamRemoteStorage_FileWriteStreamClose      = "SteamAPI_ISteamRemoteStorage_FileWriteStreamClose"
+	flatAPI_ISteamRemoteStorage_FileWriteStreamCancel     = "SteamAPI_ISteamRemoteStorage_FileWriteStreamCancel"
+	flatAPI_ISteamRemoteStorage_FileReadAsync             = "SteamAPI_ISteamRemoteStorage_FileReadAsync"
+	flatAPI_ISteamRemoteStorage_FileReadAsyncComplete     = "SteamAPI_ISteamRemoteStorage_FileReadAsyncComplete"
+	flatAPI_ISteamRemoteStorage_FileWriteAsync            = "SteamAPI_ISteamRemoteStorage_FileWriteAsync"
+
+	flatAPI_ISteamRemoteStorage_UGCDownload = "SteamAPI_ISteamRemoteStorage_UGCDownload"
+	flatAPI_ISteamRemoteStorage_UGCRead     = "SteamAPI_ISteamRemoteStorage_UGCRead"
+
+	flatAPI_ISteamRemoteStorage_FileExists               = "SteamAPI_ISteamRemoteStorage_FileExists"
+	flatAPI_ISteamRemoteStorage_FilePersisted            = "SteamAPI_ISteamRemoteStorage_FilePersisted"
+	flatAPI_ISteamRemoteStorage_GetFileTimestamp         = "SteamAPI_ISteamRemoteStorage_GetFileTimestamp"
+	flatAPI_ISteamRemoteStorage_FileForget               = "SteamAPI_ISteamRemoteStorage_FileForget"
+	flatAPI_ISteamRemoteStorage_SetSyncPlatforms         = "SteamAPI_ISteamRemoteStorage_SetSyncPlatforms"
+	flatAPI_ISteamRemoteStorage_GetFileCount             = "SteamAPI_ISteamRemoteStorage_GetFileCount"
+	flatAPI_ISteamRemoteStorage_GetFileNameAndSize       = "SteamAPI_ISteamRemoteStorage_GetFileNameAndSize"
+	flatAPI_ISteamRemoteStorage_GetQuota                 = "SteamAPI_ISteamRemoteStorage_GetQuota"
+	flatAPI_ISteamRemoteStorage_IsCloudEnabledForAccount = "SteamAPI_ISteamRemoteStorage_IsCloudEnabledForAccount"
+	flatAPI_ISteamRemoteStorage_IsCloudEnabledForApp     = "SteamAPI_ISteamRemoteStorage_IsCloudEnabledForApp"
+	flatAPI_ISteamRemoteStorage_SetCloudEnabledForApp    = "SteamAPI_ISteamRemoteStorage_SetCloudEnabledForApp"
+
+	flatAPI_SteamUGC                               = "SteamAPI_SteamUGC_v020"
+	flatAPI_ISteamUGC_CreateQueryUserUGCRequest    = "SteamAPI_ISteamUGC_CreateQueryUserUGCRequest"
+	flatAPI_ISteamUGC_CreateQueryAllUGCRequest     = "SteamAPI_ISteamUGC_CreateQueryAllUGCRequestPage"
+	flatAPI_ISteamUGC_CreateQueryUGCDetailsRequest = "SteamAPI_ISteamUGC_CreateQueryUGCDetailsRequest"
+	flatAPI_ISteamUGC_AddRequiredTag               = "SteamAPI_ISteamUGC_AddRequiredTag"
+	flatAPI_ISteamUGC_AddExcludedTag               = "SteamAPI_ISteamUGC_AddExcludedTag"
+	flatAPI_ISteamUGC_SetMatchAnyTag               = "SteamAPI_ISteamUGC_SetMatchAnyTag"
+	flatAPI_ISteamUGC_SetSearchText                = "SteamAPI_ISteamUGC_SetSearchText"
+	flatAPI_ISteamUGC_SetRankedByTrendDays         = "SteamAPI_ISteamUGC_SetRankedByTrendDays"
+	flatAPI_ISteamUGC_SetReturnLongDescription     = "SteamAPI_ISteamUGC_SetReturnLongDescription"
+	flatAPI_ISteamUGC_SetReturnMetadata            = "SteamAPI_ISteamUGC_SetReturnMetadata"
+	flatAPI_ISteamUGC_SetLanguage                  = "SteamAPI_ISteamUGC_SetLanguage"
+	flatAPI_ISteamUGC_SetAllowCachedResponse       = "SteamAPI_ISteamUGC_SetAllowCachedResponse"
+	flatAPI_ISteamUGC_SendQueryUGCRequest          = "SteamAPI_ISteamUGC_SendQueryUGCRequest"
+	flatAPI_ISteamUGC_GetQueryUGCResult            = "SteamAPI_ISteamUGC_GetQueryUGCResult"
+	flatAPI_ISteamUGC_GetQueryUGCPreviewURL        = "SteamAPI_ISteamUGC_GetQueryUGCPreviewURL"
+	flatAPI_ISteamUGC_GetQueryUGCMetadata          = "SteamAPI_ISteamUGC_GetQueryUGCMetadata"
+	flatAPI_ISteamUGC_GetQueryUGCStatistic         = "SteamAPI_ISteamUGC_GetQueryUGCStatistic"
+	flatAPI_ISteamUGC_ReleaseQueryUGCRequest       = "SteamAPI_ISteamUGC_ReleaseQueryUGCRequest"
+
+	flatAPI_ISteamUGC_SubscribeItem         = "SteamAPI_ISteamUGC_SubscribeItem"
+	flatAPI_ISteamUGC_UnsubscribeItem       = "SteamAPI_ISteamUGC_UnsubscribeItem"
+	flatAPI_ISteamUGC_GetNumSubscribedItems = "SteamAPI_ISteamUGC_GetNumSubscribedItems"
+	flatAPI_ISteamUGC_GetSubscribedItems    = "SteamAPI_ISteamUGC_GetSubscribedItems"
+	flatAPI_ISteamUGC_GetItemState          = "SteamAPI_ISteamUGC_GetItemState"
+	flatAPI_ISteamUGC_GetItemInstallInfo    = "SteamAPI_ISteamUGC_GetItemInstallInfo"
+	flatAPI_ISteamUGC_GetItemDownloadInfo   = "SteamAPI_ISteamUGC_GetItemDownloadInfo"
+	flatAPI_ISteamUGC_DownloadItem          = "SteamAPI_ISteamUGC_DownloadItem"
+
+	flatAPI_ISteamUGC_CreateItem            = "SteamAPI_ISteamUGC_CreateItem"
+	flatAPI_ISteamUGC_StartItemUpdate       = "SteamAPI_ISteamUGC_StartItemUpdate"
+	flatAPI_ISteamUGC_SetItemTitle          = "SteamAPI_ISteamUGC_SetItemTitle"
+	flatAPI_ISteamUGC_SetItemDescription    = "SteamAPI_ISteamUGC_SetItemDescription"
+	flatAPI_ISteamUGC_SetItemTags           = "SteamAPI_ISteamUGC_SetItemTags"
+	flatAPI_ISteamUGC_SetItemVisibility     = "SteamAPI_ISteamUGC_SetItemVisibility"
+	flatAPI_ISteamUGC_SetItemContent        = "SteamAPI_ISteamUGC_SetItemContent"
+	flatAPI_ISteamUGC_SetItemPreview        = "SteamAPI_ISteamUGC_SetItemPreview"
+	flatAPI_ISteamUGC_SubmitItemUpdate      = "SteamAPI_ISteamUGC_SubmitItemUpdate"
+	flatAPI_ISteamUGC_GetItemUpdateProgress = "SteamAPI_ISteamUGC_GetItemUpdateProgress"
 
 	flatAPI_SteamUser             = "SteamAPI_SteamUser_v023"
 	flatAPI_ISteamUser_GetSteamID = "SteamAPI_ISteamUser_GetSteamID"
 
 	flatAPI_SteamUserStats                     = "SteamAPI_SteamUserStats_v013"
-	flatAPI_ISteamUserStats_GetStatInt         = "SteamAPI_ISteamUserStats_GetUserStatInt32"
-	flatAPI_ISteamUserStats_SetStatInt         = "SteamAPI_ISteamUserStats_SetStatInt32"
-	flatAPI_ISteamUserStats_RequestGlobalStats = "SteamAPI_ISteamUserStats_RequestGlobalStats"
-	flatAPI_ISteamUserStats_GetGlobalStatInt   = "SteamAPI_ISteamUserStats_GetGlobalStatInt64"
-
-	flatAPI_ISteamUserStats_RequestCurrentStats     = "SteamAPI_ISteamUserStats_RequestUserStats"
+	flatAPI_ISteamUserStats_GetStatInt32       = "SteamAPI_ISteamUserStats_GetStatInt32"
+	flatAPI_ISteamUserStats_GetStatFloat       = "SteamAPI_ISteamUserStats_GetStatFloat"
+	flatAPI_ISteamUserStats_SetStatInt32       = "SteamAPI_ISteamUserStats_SetStatInt32"
+	flatAPI_ISteamUserStats_SetStatFloat       = "SteamAPI_ISteamUserStats_SetStatFloat"
+	flatAPI_ISteamUserStats_UpdateAvgRateStat  = "SteamAPI_ISteamUserStats_UpdateAvgRateStat"
+	flatAPI_ISteamUserStats_GetUserStatInt32   = "SteamAPI_ISteamUserStats_GetUserStatInt32"
+	flatAPI_ISteamUserStats_GetUserStatFloat   = "SteamAPI_ISteamUserStats_GetUserStatFloat"
+	flatAPI_ISteamUserStats_GetUserAchievement = "SteamAPI_ISteamUserStats_GetUserAchievement"
+	flatAPI_ISteamUserStats_ResetAllStats      = "SteamAPI_ISteamUserStats_ResetAllStats"
+
+	flatAPI_ISteamUserStats_GetNumAchievements                  = "SteamAPI_ISteamUserStats_GetNumAchievements"
+	flatAPI_ISteamUserStats_GetAchievementName                  = "SteamAPI_ISteamUserStats_GetAchievementName"
+	flatAPI_ISteamUserStats_GetAchievementDisplayAttribute      = "SteamAPI_ISteamUserStats_GetAchievementDisplayAttribute"
+	flatAPI_ISteamUserStats_GetAchievementAndUnlockTime         = "SteamAPI_ISteamUserStats_GetAchievementAndUnlockTime"
+	flatAPI_ISteamUserStats_IndicateAchievementProgress         = "SteamAPI_ISteamUserStats_IndicateAchievementProgress"
+	flatAPI_ISteamUserStats_GetAchievementProgressLimitsInt32   = "SteamAPI_ISteamUserStats_GetAchievementProgressLimitsInt32"
+	flatAPI_ISteamUserStats_GetAchievementProgressLimitsFloat   = "SteamAPI_ISteamUserStats_GetAchievementProgressLimitsFloat"
+	flatAPI_ISteamUserStats_RequestGlobalAchievementPercentages = "SteamAPI_ISteamUserStats_RequestGlobalAchievementPercentages"
+	flatAPI_ISteamUserStats_GetAchievementAchievedPercent       = "SteamAPI_ISteamUserStats_GetAchievementAchievedPercent"
+	flatAPI_ISteamUserStats_GetMostAchievedAchievementInfo      = "SteamAPI_ISteamUserStats_GetMostAchievedAchievementInfo"
+	flatAPI_ISteamUserStats_GetNextMostAchievedAchievementInfo  = "SteamAPI_ISteamUserStats_GetNextMostAchievedAchievementInfo"
+	flatAPI_ISteamUserStats_GetAchievementIcon                  = "SteamAPI_ISteamUserStats_GetAchievementIcon"
+	flatAPI_ISteamUserStats_RequestGlobalStats                  = "SteamAPI_ISteamUserStats_RequestGlobalStats"
+	flatAPI_ISteamUserStats_GetGlobalStatInt                    = "SteamAPI_ISteamUserStats_GetGlobalStatInt64"
+
+	flatAPI_ISteamUserStats_RequestUserStats        = "SteamAPI_ISteamUserStats_RequestUserStats"
 	flatAPI_ISteamUserStats_GetAchievement          = "SteamAPI_ISteamUserStats_GetAchievement"
 	flatAPI_ISteamUserStats_SetAchievement          = "SteamAPI_ISteamUserStats_SetAchievement"
 	flatAPI_ISteamUserStats_ClearAchievement        = "SteamAPI_ISteamUserStats_ClearAchievement"
@@ -195,17 +896,76 @@ const (
 	flatAPI_ISteamUserStats_FindOrCreateLeaderboard = "SteamAPI_ISteamUserStats_FindOrCreateLeaderboard"
 	flatAPI_ISteamUserStats_GetLeaderboardName      = "SteamAPI_ISteamUserStats_GetLeaderboardName"
 
+	flatAPI_ISteamUserStats_GetLeaderboardEntryCount  = "SteamAPI_ISteamUserStats_GetLeaderboardEntryCount"
+	flatAPI_ISteamUserStats_GetLeaderboardSortMethod  = "SteamAPI_ISteamUserStats_GetLeaderboardSortMethod"
+	flatAPI_ISteamUserStats_GetLeaderboardDisplayType = "SteamAPI_ISteamUserStats_GetLeaderboardDisplayType"
+	flatAPI_ISteamUserStats_AttachLeaderboardUGC      = "SteamAPI_ISteamUserStats_AttachLeaderboardUGC"
+
 	flatAPI_ISteamUserStats_DownloadLeaderboardEntries         = "SteamAPI_ISteamUserStats_DownloadLeaderboardEntries"
 	flatAPI_ISteamUserStats_UploadLeaderboardScore             = "SteamAPI_ISteamUserStats_UploadLeaderboardScore"
 	flatAPI_ISteamUserStats_DownloadLeaderboardEntriesForUsers = "SteamAPI_ISteamUserStats_DownloadLeaderboardEntriesForUsers"
 	flatAPI_ISteamUserStats_GetDownloadedLeaderboardEntry      = "SteamAPI_ISteamUserStats_GetDownloadedLeaderboardEntry"
 
-	flatAPI_SteamUtils                               = "SteamAPI_SteamUtils_v010"
-	flatAPI_ISteamUtils_IsSteamRunningOnSteamDeck    = "SteamAPI_ISteamUtils_IsSteamRunningOnSteamDeck"
-	flatAPI_ISteamUtils_ShowFloatingGamepadTextInput = "SteamAPI_ISteamUtils_ShowFloatingGamepadTextInput"
-	flatAPI_ISteamUtils_GetAPICallResult             = "SteamAPI_ISteamUtils_GetAPICallResult"
+	flatAPI_SteamUtils                                 = "SteamAPI_SteamUtils_v010"
+	flatAPI_ISteamUtils_IsSteamRunningOnSteamDeck      = "SteamAPI_ISteamUtils_IsSteamRunningOnSteamDeck"
+	flatAPI_ISteamUtils_ShowFloatingGamepadTextInput   = "SteamAPI_ISteamUtils_ShowFloatingGamepadTextInput"
+	flatAPI_ISteamUtils_IsOverlayEnabled               = "SteamAPI_ISteamUtils_IsOverlayEnabled"
+	flatAPI_ISteamUtils_BOverlayNeedsPresent           = "SteamAPI_ISteamUtils_BOverlayNeedsPresent"
+	flatAPI_ISteamUtils_SetOverlayNotificationPosition = "SteamAPI_ISteamUtils_SetOverlayNotificationPosition"
+	flatAPI_ISteamUtils_SetOverlayNotificationInset    = "SteamAPI_ISteamUtils_SetOverlayNotificationInset"
+	flatAPI_ISteamUtils_GetSteamUILanguage             = "SteamAPI_ISteamUtils_GetSteamUILanguage"
+	flatAPI_ISteamUtils_GetAPICallResult               = "SteamAPI_ISteamUtils_GetAPICallResult"
+	flatAPI_ISteamUtils_GetAPICallFailureReason        = "SteamAPI_ISteamUtils_GetAPICallFailureReason"
+	flatAPI_ISteamUtils_GetImageSize                   = "SteamAPI_ISteamUtils_GetImageSize"
+	flatAPI_ISteamUtils_GetImageRGBA                   = "SteamAPI_ISteamUtils_GetImageRGBA"
 )
 
+// steamParamStringArray is SteamParamStringArray_t: strings points to num C strings.
+type steamParamStringArray struct {
+	strings uintptr
+	num     int32
+}
+
+// achievementNameMax is the size of the buffers Steam fills with achievement API names.
+const achievementNameMax = 128
+
+// unixTime converts a Unix time from Steam, such as an RTime32, to a time.Time. 0 means unset.
+func unixTime(t int64) time.Time {
+	if t == 0 {
+		return time.Time{}
+	}
+	return time.Unix(t, 0)
+}
+
+// AccountID returns the account part of a Steam ID, which Workshop queries identify users by.
+func (id CSteamID) AccountID() AccountID_t {
+	return AccountID_t(id)
+}
+
+// unixSeconds is the inverse of unixTime.
+func unixSeconds(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+// splitLanguages splits a comma separated list of API language codes.
+func splitLanguages(list string) []string {
+	if list == "" {
+		return nil
+	}
+	return strings.Split(list, ",")
+}
+
+// cBufferToString returns the NUL-terminated string Steam wrote into b.
+func cBufferToString(b []byte) string {
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		return string(b[:i])
+	}
+	return string(b)
+}
+
 type steamErrMsg [1024]byte
 
 func (s *steamErrMsg) String() string {
@@ -216,3 +976,43 @@ func (s *steamErrMsg) String() string {
 	}
 	return ""
 }
+
+var errorHandler atomic.Pointer[func(err error)]
+
+// loggedErrors holds the messages of the errors handleError has logged.
+var loggedErrors sync.Map
+
+// SetErrorHandler sets the function called when a binding fails to call into steam_api,
+// e.g. because an export is missing from an older library. The failing call returns zero values,
+// so a single missing feature does not stop the process.
+//
+// By default each such error is logged once with the log package. Pass PanicOnError to panic instead.
+// Passing nil restores the default behavior.
+//
+// f is also called from the dispatcher's background goroutine, where the game cannot recover a panic.
+// The pending calls fail with the error either way.
+func SetErrorHandler(f func(err error)) {
+	if f == nil {
+		errorHandler.Store(nil)
+		return
+	}
+	errorHandler.Store(&f)
+}
+
+// PanicOnError is an error handler for SetErrorHandler that panics with the error, for games that would
+// rather stop than run with a Steam feature missing.
+func PanicOnError(err error) {
+	panic(err)
+}
+
+// handleError passes err to the error handler. Without one, it logs err unless an error with the same
+// message, which names the failing function, has been logged already.
+func handleError(err error) {
+	if f := errorHandler.Load(); f != nil {
+		(*f)(err)
+		return
+	}
+	if _, logged := loggedErrors.LoadOrStore(err.Error(), struct{}{}); !logged {
+		log.Print(err)
+	}
+}
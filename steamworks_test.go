@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks_test
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/TaiJiYu/go-steamworks"
+)
+
+func TestErrorHandler(t *testing.T) {
+	fake := steamworks.NewFake()
+	missing := errors.New("steamworks: function SteamAPI_ISteamFriends_GetPersonaName not found")
+	fake.FailCalls("SteamAPI_ISteamFriends_GetPersonaName", missing)
+	startFake(t, fake)
+	friends := steamworks.SteamFriends()
+
+	// By default the error is logged once, however often the call fails.
+	var buf bytes.Buffer
+	stderr := log.Writer()
+	log.SetOutput(&buf)
+	defer log.SetOutput(stderr)
+	for range 3 {
+		if name := friends.GetPersonaName(); name != "" {
+			t.Errorf("GetPersonaName() = %q, want \"\"", name)
+		}
+	}
+	log.SetOutput(stderr)
+	if got := strings.Count(buf.String(), missing.Error()); got != 1 {
+		t.Errorf("the error was logged %d times, want once:\n%s", got, buf.String())
+	}
+
+	var handled []error
+	steamworks.SetErrorHandler(func(err error) { handled = append(handled, err) })
+	defer steamworks.SetErrorHandler(nil)
+	friends.GetPersonaName()
+	friends.GetPersonaName()
+	if len(handled) != 2 || !errors.Is(handled[0], missing) {
+		t.Errorf("handled %v, want the error twice", handled)
+	}
+
+	steamworks.SetErrorHandler(steamworks.PanicOnError)
+	defer func() {
+		if r := recover(); r == nil || !errors.Is(r.(error), missing) {
+			t.Errorf("recovered %v, want %v", r, missing)
+		}
+	}()
+	friends.GetPersonaName()
+	t.Error("GetPersonaName did not panic")
+}
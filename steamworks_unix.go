@@ -6,11 +6,17 @@
 package steamworks
 
 import (
+	"bytes"
+	"crypto/sha256"
 	_ "embed"
+	"encoding/hex"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sync"
+	"time"
 	"unsafe"
 )
 
@@ -25,6 +31,10 @@ import (
 //   return (uintptr_t)dlsym((void*)handle, name);
 // }
 //
+// static void dlclose_(uintptr_t handle) {
+//   dlclose((void*)handle);
+// }
+//
 // static const char* uintptrToChar(uintptr_t str) {
 //   return (const char*)str;
 // }
@@ -33,6 +43,14 @@ import (
 //   return ((bool (*)())(f))();
 // }
 //
+// static uint8_t callFunc_Bool_Int32_Int64_Ptr_Int32_Int32_Ptr(uintptr_t f, int32_t arg0, int64_t arg1, uintptr_t arg2, int32_t arg3, int32_t arg4, uintptr_t arg5) {
+//   return ((bool (*)(int32_t, int64_t, void*, int32_t, int32_t, void*))(f))(arg0, arg1, (void*)arg2, arg3, arg4, (void*)arg5);
+// }
+//
+// static uint8_t callFunc_Bool_Int32_Ptr(uintptr_t f, int32_t arg0, uintptr_t arg1) {
+//   return ((bool (*)(int32_t, void*))(f))(arg0, (void*)arg1);
+// }
+//
 // static uint8_t callFunc_Bool_Ptr(uintptr_t f, uintptr_t arg0) {
 //   return ((bool (*)(void*))(f))((void*)arg0);
 // }
@@ -45,14 +63,97 @@ import (
 //   return ((bool (*)(void*, int32_t))(f))((void*)arg0, arg1);
 // }
 //
+// static uint8_t callFunc_Bool_Ptr_Int64(uintptr_t f, uintptr_t arg0, int64_t arg1) {
+//   return ((bool (*)(void*, int64_t))(f))((void*)arg0, arg1);
+// }
+//
+// static uint8_t callFunc_Bool_Ptr_Int64_Bool(uintptr_t f, uintptr_t arg0, int64_t arg1, uint8_t arg2) {
+//   return ((bool (*)(void*, int64_t, bool))(f))((void*)arg0, arg1, (bool)arg2);
+// }
+//
+// static uint8_t callFunc_Bool_Ptr_Int64_Int32(uintptr_t f, uintptr_t arg0, int64_t arg1, int32_t arg2) {
+//   return ((bool (*)(void*, int64_t, int32_t))(f))((void*)arg0, arg1, arg2);
+// }
+//
+// static uint8_t callFunc_Bool_Ptr_Int64_Int32_Int32_Ptr(uintptr_t f, uintptr_t arg0, int64_t arg1, int32_t arg2, int32_t arg3, uintptr_t arg4) {
+//   return ((bool (*)(void*, int64_t, int32_t, int32_t, void*))(f))((void*)arg0, arg1, arg2, arg3, (void*)arg4);
+// }
+//
+// static uint8_t callFunc_Bool_Ptr_Int64_Int32_Int32_Ptr_Int32(uintptr_t f, uintptr_t arg0, int64_t arg1, int32_t arg2, int32_t arg3, uintptr_t arg4, int32_t arg5) {
+//   return ((bool (*)(void*, int64_t, int32_t, int32_t, void*, int32_t))(f))((void*)arg0, arg1, arg2, arg3, (void*)arg4, arg5);
+// }
+//
+// static uint8_t callFunc_Bool_Ptr_Int64_Int32_Ptr(uintptr_t f, uintptr_t arg0, int64_t arg1, int32_t arg2, uintptr_t arg3) {
+//   return ((bool (*)(void*, int64_t, int32_t, void*))(f))((void*)arg0, arg1, arg2, (void*)arg3);
+// }
+//
+// static uint8_t callFunc_Bool_Ptr_Int64_Int32_Ptr_Int32(uintptr_t f, uintptr_t arg0, int64_t arg1, int32_t arg2, uintptr_t arg3, int32_t arg4) {
+//   return ((bool (*)(void*, int64_t, int32_t, void*, int32_t))(f))((void*)arg0, arg1, arg2, (void*)arg3, arg4);
+// }
+//
+// static uint8_t callFunc_Bool_Ptr_Int64_Int32_Ptr_Ptr_Int32(uintptr_t f, uintptr_t arg0, int64_t arg1, int32_t arg2, uintptr_t arg3, uintptr_t arg4, int32_t arg5) {
+//   return ((bool (*)(void*, int64_t, int32_t, void*, void*, int32_t))(f))((void*)arg0, arg1, arg2, (void*)arg3, (void*)arg4, arg5);
+// }
+//
+// static uint8_t callFunc_Bool_Ptr_Int64_Ptr(uintptr_t f, uintptr_t arg0, int64_t arg1, uintptr_t arg2) {
+//   return ((bool (*)(void*, int64_t, void*))(f))((void*)arg0, arg1, (void*)arg2);
+// }
+//
+// static uint8_t callFunc_Bool_Ptr_Int64_Ptr_Bool(uintptr_t f, uintptr_t arg0, int64_t arg1, uintptr_t arg2, uint8_t arg3) {
+//   return ((bool (*)(void*, int64_t, void*, bool))(f))((void*)arg0, arg1, (void*)arg2, (bool)arg3);
+// }
+//
+// static uint8_t callFunc_Bool_Ptr_Int64_Ptr_Int32(uintptr_t f, uintptr_t arg0, int64_t arg1, uintptr_t arg2, int32_t arg3) {
+//   return ((bool (*)(void*, int64_t, void*, int32_t))(f))((void*)arg0, arg1, (void*)arg2, arg3);
+// }
+//
+// static uint8_t callFunc_Bool_Ptr_Int64_Ptr_Int32_Int32_Ptr(uintptr_t f, uintptr_t arg0, int64_t arg1, uintptr_t arg2, int32_t arg3, int32_t arg4, uintptr_t arg5) {
+//   return ((bool (*)(void*, int64_t, void*, int32_t, int32_t, void*))(f))((void*)arg0, arg1, (void*)arg2, arg3, arg4, (void*)arg5);
+// }
+//
+// static uint8_t callFunc_Bool_Ptr_Int64_Ptr_Ptr(uintptr_t f, uintptr_t arg0, int64_t arg1, uintptr_t arg2, uintptr_t arg3) {
+//   return ((bool (*)(void*, int64_t, void*, void*))(f))((void*)arg0, arg1, (void*)arg2, (void*)arg3);
+// }
+//
+// static uint8_t callFunc_Bool_Ptr_Int64_Ptr_Ptr_Int32_Ptr(uintptr_t f, uintptr_t arg0, int64_t arg1, uintptr_t arg2, uintptr_t arg3, int32_t arg4, uintptr_t arg5) {
+//   return ((bool (*)(void*, int64_t, void*, void*, int32_t, void*))(f))((void*)arg0, arg1, (void*)arg2, (void*)arg3, arg4, (void*)arg5);
+// }
+//
 // static uint8_t callFunc_Bool_Ptr_Int32_Int32_Int32_Int32_Int32(uintptr_t f, uintptr_t arg0, int32_t arg1, int32_t arg2, int32_t arg3, int32_t arg4, int32_t arg5) {
 //   return ((bool (*)(void*, int32_t, int32_t, int32_t, int32_t, int32_t))(f))((void*)arg0, arg1, arg2, arg3, arg4, arg5);
 // }
 //
+// static uint8_t callFunc_Bool_Ptr_Int32_Ptr_Int32(uintptr_t f, uintptr_t arg0, int32_t arg1, uintptr_t arg2, int32_t arg3) {
+//   return ((bool (*)(void*, int32_t, void*, int32_t))(f))((void*)arg0, arg1, (void*)arg2, arg3);
+// }
+//
+// static uint8_t callFunc_Bool_Ptr_Int32_Ptr_Ptr(uintptr_t f, uintptr_t arg0, int32_t arg1, uintptr_t arg2, uintptr_t arg3) {
+//   return ((bool (*)(void*, int32_t, void*, void*))(f))((void*)arg0, arg1, (void*)arg2, (void*)arg3);
+// }
+//
 // static uint8_t callFunc_Bool_Ptr_Ptr(uintptr_t f, uintptr_t arg0, uintptr_t arg1) {
 //   return ((bool (*)(void*, void*))(f))((void*)arg0, (void*)arg1);
 // }
 //
+// static uint8_t callFunc_Bool_Ptr_Ptr_Int32(uintptr_t f, uintptr_t arg0, uintptr_t arg1, int32_t arg2) {
+//   return ((bool (*)(void*, void*, int32_t))(f))((void*)arg0, (void*)arg1, arg2);
+// }
+//
+// static uint8_t callFunc_Bool_Ptr_Ptr_Int32_Int32(uintptr_t f, uintptr_t arg0, uintptr_t arg1, int32_t arg2, int32_t arg3) {
+//   return ((bool (*)(void*, void*, int32_t, int32_t))(f))((void*)arg0, (void*)arg1, arg2, arg3);
+// }
+//
+// static uint8_t callFunc_Bool_Ptr_Ptr_Float(uintptr_t f, uintptr_t arg0, uintptr_t arg1, uint32_t arg2) {
+//   union { uint32_t bits; float v; } v2 = { arg2 };
+//   return ((bool (*)(void*, void*, float))(f))((void*)arg0, (void*)arg1, v2.v);
+// }
+//
+// static uint8_t callFunc_Bool_Ptr_Ptr_Float_Double(uintptr_t f, uintptr_t arg0, uintptr_t arg1, uint32_t arg2, uint64_t arg3) {
+//   union { uint32_t bits; float v; } v2 = { arg2 };
+//   union { uint64_t bits; double v; } v3 = { arg3 };
+//   return ((bool (*)(void*, void*, float, double))(f))((void*)arg0, (void*)arg1, v2.v, v3.v);
+// }
+//
 // static uint8_t callFunc_Bool_Ptr_Ptr_Ptr(uintptr_t f, uintptr_t arg0, uintptr_t arg1, uintptr_t arg2) {
 //   return ((bool (*)(void*, void*, void*))(f))((void*)arg0, (void*)arg1, (void*)arg2);
 // }
@@ -61,18 +162,38 @@ import (
 //   return ((bool (*)(void*, void*, void*, int32_t))(f))((void*)arg0, (void*)arg1, (void*)arg2, arg3);
 // }
 //
+// static uint8_t callFunc_Bool_Ptr_Ptr_Ptr_Ptr(uintptr_t f, uintptr_t arg0, uintptr_t arg1, uintptr_t arg2, uintptr_t arg3) {
+//   return ((bool (*)(void*, void*, void*, void*))(f))((void*)arg0, (void*)arg1, (void*)arg2, (void*)arg3);
+// }
+//
 // static uint8_t callFunc_Bool_Int32(uintptr_t f, uint32_t arg0) {
 //   return ((bool (*)(uint32_t))(f))(arg0);
 // }
 //
+// static int32_t callFunc_Int32(uintptr_t f) {
+//   return ((int32_t (*)())(f))();
+// }
+//
 // static int32_t callFunc_Int32_Ptr(uintptr_t f, uintptr_t arg0) {
 //   return ((int32_t (*)(void*))(f))((void*)arg0);
 // }
 //
+// static int32_t callFunc_Int32_Ptr_Bool(uintptr_t f, uintptr_t arg0, uint8_t arg1) {
+//   return ((int32_t (*)(void*, bool))(f))((void*)arg0, (bool)arg1);
+// }
+//
+// static int32_t callFunc_Int32_Ptr_Int32(uintptr_t f, uintptr_t arg0, int32_t arg1) {
+//   return ((int32_t (*)(void*, int32_t))(f))((void*)arg0, arg1);
+// }
+//
 // static int32_t callFunc_Int32_Ptr_Int32_Ptr_Int32(uintptr_t f, uintptr_t arg0, int32_t arg1, uintptr_t arg2, int32_t arg3) {
 //   return ((int32_t (*)(void*, int32_t, void*, int32_t))(f))((void*)arg0, arg1, (void*)arg2, arg3);
 // }
 //
+// static int32_t callFunc_Int32_Ptr_Int32_Ptr_Int32_Ptr_Ptr(uintptr_t f, uintptr_t arg0, int32_t arg1, uintptr_t arg2, int32_t arg3, uintptr_t arg4, uintptr_t arg5) {
+//   return ((int32_t (*)(void*, int32_t, void*, int32_t, void*, void*))(f))((void*)arg0, arg1, (void*)arg2, arg3, (void*)arg4, (void*)arg5);
+// }
+//
 // static int32_t callFunc_Int32_Ptr_Int32_Ptr_Ptr_Ptr_Int32(uintptr_t f, uintptr_t arg0, int32_t arg1, uintptr_t arg2, uintptr_t arg3, uintptr_t arg4, int32_t arg5) {
 //   return ((int32_t (*)(void*, int32_t, void*, void*, void*, int32_t))(f))((void*)arg0, arg1, (void*)arg2, (void*)arg3, (void*)arg4, arg5);
 // }
@@ -81,10 +202,34 @@ import (
 //   return ((int32_t (*)(void*, int64_t))(f))((void*)arg0, arg1);
 // }
 //
+// static int32_t callFunc_Int32_Ptr_Int64_Int32(uintptr_t f, uintptr_t arg0, int64_t arg1, int32_t arg2) {
+//   return ((int32_t (*)(void*, int64_t, int32_t))(f))((void*)arg0, arg1, arg2);
+// }
+//
+// static int32_t callFunc_Int32_Ptr_Int64_Ptr_Int32_Int32_Int32(uintptr_t f, uintptr_t arg0, int64_t arg1, uintptr_t arg2, int32_t arg3, int32_t arg4, int32_t arg5) {
+//   return ((int32_t (*)(void*, int64_t, void*, int32_t, int32_t, int32_t))(f))((void*)arg0, arg1, (void*)arg2, arg3, arg4, arg5);
+// }
+//
+// static int32_t callFunc_Int32_Ptr_Int64_Ptr_Ptr(uintptr_t f, uintptr_t arg0, int64_t arg1, uintptr_t arg2, uintptr_t arg3) {
+//   return ((int32_t (*)(void*, int64_t, void*, void*))(f))((void*)arg0, arg1, (void*)arg2, (void*)arg3);
+// }
+//
 // static int32_t callFunc_Int32_Ptr_Ptr(uintptr_t f, uintptr_t arg0, uintptr_t arg1) {
 //   return ((int32_t (*)(void*, void*))(f))((void*)arg0, (void*)arg1);
 // }
 //
+// static int32_t callFunc_Int32_Ptr_Ptr_Int32(uintptr_t f, uintptr_t arg0, uintptr_t arg1, int32_t arg2) {
+//   return ((int32_t (*)(void*, void*, int32_t))(f))((void*)arg0, (void*)arg1, arg2);
+// }
+//
+// static int32_t callFunc_Int32_Ptr_Ptr_Int32_Bool(uintptr_t f, uintptr_t arg0, uintptr_t arg1, int32_t arg2, uint8_t arg3) {
+//   return ((int32_t (*)(void*, void*, int32_t, bool))(f))((void*)arg0, (void*)arg1, arg2, (bool)arg3);
+// }
+//
+// static int32_t callFunc_Int32_Ptr_Ptr_Int32_Ptr_Ptr(uintptr_t f, uintptr_t arg0, uintptr_t arg1, int32_t arg2, uintptr_t arg3, uintptr_t arg4) {
+//   return ((int32_t (*)(void*, void*, int32_t, void*, void*))(f))((void*)arg0, (void*)arg1, arg2, (void*)arg3, (void*)arg4);
+// }
+//
 // static int32_t callFunc_Int32_Ptr_Ptr_Ptr_Int32(uintptr_t f, uintptr_t arg0, uintptr_t arg1, uintptr_t arg2, int32_t arg3) {
 //   return ((int32_t (*)(void*, void*, void*, int32_t))(f))((void*)arg0, (void*)arg1, (void*)arg2, arg3);
 // }
@@ -93,6 +238,70 @@ import (
 //   return ((int64_t (*)(void*))(f))((void*)arg0);
 // }
 //
+// static int64_t callFunc_Int64_Ptr_Int32(uintptr_t f, uintptr_t arg0, int32_t arg1) {
+//   return ((int64_t (*)(void*, int32_t))(f))((void*)arg0, arg1);
+// }
+//
+// static int64_t callFunc_Int64_Ptr_Int32_Int32(uintptr_t f, uintptr_t arg0, int32_t arg1, int32_t arg2) {
+//   return ((int64_t (*)(void*, int32_t, int32_t))(f))((void*)arg0, arg1, arg2);
+// }
+//
+// static int64_t callFunc_Int64_Ptr_Int32_Int32_Int32_Int32_Int32(uintptr_t f, uintptr_t arg0, int32_t arg1, int32_t arg2, int32_t arg3, int32_t arg4, int32_t arg5) {
+//   return ((int64_t (*)(void*, int32_t, int32_t, int32_t, int32_t, int32_t))(f))((void*)arg0, arg1, arg2, arg3, arg4, arg5);
+// }
+//
+// static int64_t callFunc_Int64_Ptr_Int32_Int32_Int32_Int32_Int32_Int32_Int32(uintptr_t f, uintptr_t arg0, int32_t arg1, int32_t arg2, int32_t arg3, int32_t arg4, int32_t arg5, int32_t arg6, int32_t arg7) {
+//   return ((int64_t (*)(void*, int32_t, int32_t, int32_t, int32_t, int32_t, int32_t, int32_t))(f))((void*)arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7);
+// }
+//
+// static int64_t callFunc_Int64_Ptr_Int32_Int64(uintptr_t f, uintptr_t arg0, int32_t arg1, int64_t arg2) {
+//   return ((int64_t (*)(void*, int32_t, int64_t))(f))((void*)arg0, arg1, arg2);
+// }
+//
+// static int64_t callFunc_Int64_Ptr_Int64(uintptr_t f, uintptr_t arg0, int64_t arg1) {
+//   return ((int64_t (*)(void*, int64_t))(f))((void*)arg0, arg1);
+// }
+//
+// static int64_t callFunc_Int64_Ptr_Int64_Int32(uintptr_t f, uintptr_t arg0, int64_t arg1, int32_t arg2) {
+//   return ((int64_t (*)(void*, int64_t, int32_t))(f))((void*)arg0, arg1, arg2);
+// }
+//
+// static int64_t callFunc_Int64_Ptr_Int64_Int32_Int32_Int32(uintptr_t f, uintptr_t arg0, int64_t arg1, int32_t arg2, int32_t arg3, int32_t arg4) {
+//   return ((int64_t (*)(void*, int64_t, int32_t, int32_t, int32_t))(f))((void*)arg0, arg1, arg2, arg3, arg4);
+// }
+//
+// static int64_t callFunc_Int64_Ptr_Int64_Int32_Int32_Ptr_Int32(uintptr_t f, uintptr_t arg0, int64_t arg1, int32_t arg2, int32_t arg3, uintptr_t arg4, int32_t arg5) {
+//   return ((int64_t (*)(void*, int64_t, int32_t, int32_t, void*, int32_t))(f))((void*)arg0, arg1, arg2, arg3, (void*)arg4, arg5);
+// }
+//
+// static int64_t callFunc_Int64_Ptr_Int64_Int64(uintptr_t f, uintptr_t arg0, int64_t arg1, int64_t arg2) {
+//   return ((int64_t (*)(void*, int64_t, int64_t))(f))((void*)arg0, arg1, arg2);
+// }
+//
+// static int64_t callFunc_Int64_Ptr_Int64_Ptr(uintptr_t f, uintptr_t arg0, int64_t arg1, uintptr_t arg2) {
+//   return ((int64_t (*)(void*, int64_t, void*))(f))((void*)arg0, arg1, (void*)arg2);
+// }
+//
+// static int64_t callFunc_Int64_Ptr_Int64_Ptr_Int32(uintptr_t f, uintptr_t arg0, int64_t arg1, uintptr_t arg2, int32_t arg3) {
+//   return ((int64_t (*)(void*, int64_t, void*, int32_t))(f))((void*)arg0, arg1, (void*)arg2, arg3);
+// }
+//
+// static int64_t callFunc_Int64_Ptr_Ptr(uintptr_t f, uintptr_t arg0, uintptr_t arg1) {
+//   return ((int64_t (*)(void*, void*))(f))((void*)arg0, (void*)arg1);
+// }
+//
+// static int64_t callFunc_Int64_Ptr_Ptr_Int32(uintptr_t f, uintptr_t arg0, uintptr_t arg1, int32_t arg2) {
+//   return ((int64_t (*)(void*, void*, int32_t))(f))((void*)arg0, (void*)arg1, arg2);
+// }
+//
+// static int64_t callFunc_Int64_Ptr_Ptr_Int32_Int32(uintptr_t f, uintptr_t arg0, uintptr_t arg1, int32_t arg2, int32_t arg3) {
+//   return ((int64_t (*)(void*, void*, int32_t, int32_t))(f))((void*)arg0, (void*)arg1, arg2, arg3);
+// }
+//
+// static int64_t callFunc_Int64_Ptr_Ptr_Ptr_Int32(uintptr_t f, uintptr_t arg0, uintptr_t arg1, uintptr_t arg2, int32_t arg3) {
+//   return ((int64_t (*)(void*, void*, void*, int32_t))(f))((void*)arg0, (void*)arg1, (void*)arg2, arg3);
+// }
+//
 // static uintptr_t callFunc_Ptr(uintptr_t f) {
 //   return (uintptr_t)((void* (*)())(f))();
 // }
@@ -101,18 +310,68 @@ import (
 //   return (uintptr_t)((void* (*)(void*))(f))((void*)arg0);
 // }
 //
+// static uintptr_t callFunc_Ptr_Ptr_Int32(uintptr_t f, uintptr_t arg0, int32_t arg1) {
+//   return (uintptr_t)((void* (*)(void*, int32_t))(f))((void*)arg0, arg1);
+// }
+//
+// static uintptr_t callFunc_Ptr_Ptr_Int32_Ptr(uintptr_t f, uintptr_t arg0, int32_t arg1, uintptr_t arg2) {
+//   return (uintptr_t)((void* (*)(void*, int32_t, void*))(f))((void*)arg0, arg1, (void*)arg2);
+// }
+//
+// static uintptr_t callFunc_Ptr_Ptr_Int64(uintptr_t f, uintptr_t arg0, int64_t arg1) {
+//   return (uintptr_t)((void* (*)(void*, int64_t))(f))((void*)arg0, arg1);
+// }
+//
+// static uintptr_t callFunc_Ptr_Ptr_Ptr(uintptr_t f, uintptr_t arg0, uintptr_t arg1) {
+//   return (uintptr_t)((void* (*)(void*, void*))(f))((void*)arg0, (void*)arg1);
+// }
+//
+// static uintptr_t callFunc_Ptr_Ptr_Ptr_Ptr(uintptr_t f, uintptr_t arg0, uintptr_t arg1, uintptr_t arg2) {
+//   return (uintptr_t)((void* (*)(void*, void*, void*))(f))((void*)arg0, (void*)arg1, (void*)arg2);
+// }
+//
 // static void callFunc_Void(uintptr_t f) {
 //   ((void (*)())(f))();
 // }
 //
+// static void callFunc_Void_Int32(uintptr_t f, int32_t arg0) {
+//   ((void (*)(int32_t))(f))(arg0);
+// }
+//
 // static void callFunc_Void_Ptr_Bool(uintptr_t f, uintptr_t arg0, uint8_t arg1) {
 //   ((void (*)(void*, bool))(f))((void*)arg0, (bool)arg1);
 // }
+//
+// static void callFunc_Void_Ptr_Int32(uintptr_t f, uintptr_t arg0, int32_t arg1) {
+//   ((void (*)(void*, int32_t))(f))((void*)arg0, arg1);
+// }
+//
+// static void callFunc_Void_Ptr_Int32_Int32(uintptr_t f, uintptr_t arg0, int32_t arg1, int32_t arg2) {
+//   ((void (*)(void*, int32_t, int32_t))(f))((void*)arg0, arg1, arg2);
+// }
+//
+// static void callFunc_Void_Ptr_Int64(uintptr_t f, uintptr_t arg0, int64_t arg1) {
+//   ((void (*)(void*, int64_t))(f))((void*)arg0, arg1);
+// }
+//
+// static void callFunc_Void_Ptr_Ptr(uintptr_t f, uintptr_t arg0, uintptr_t arg1) {
+//   ((void (*)(void*, void*))(f))((void*)arg0, (void*)arg1);
+// }
+//
+// static void callFunc_Void_Ptr_Ptr_Int32(uintptr_t f, uintptr_t arg0, uintptr_t arg1, int32_t arg2) {
+//   ((void (*)(void*, void*, int32_t))(f))((void*)arg0, (void*)arg1, arg2);
+// }
+//
+// static void callFunc_Void_Ptr_Ptr_Int64(uintptr_t f, uintptr_t arg0, uintptr_t arg1, int64_t arg2) {
+//   ((void (*)(void*, void*, int64_t))(f))((void*)arg0, (void*)arg1, arg2);
+// }
 import "C"
 
 type lib struct {
 	lib   C.uintptr_t
+	err   error
 	procs map[string]C.uintptr_t
+	mu    sync.Mutex
 }
 
 type funcType int
@@ -123,25 +382,89 @@ const (
 	funcType_Bool_Ptr_Bool
 	funcType_Bool_Ptr_Int32
 	funcType_Bool_Ptr_Int32_Int32_Int32_Int32_Int32
+	funcType_Bool_Ptr_Int32_Ptr_Int32
+	funcType_Bool_Ptr_Int32_Ptr_Ptr
+	funcType_Bool_Ptr_Int64
+	funcType_Bool_Ptr_Int64_Bool
+	funcType_Bool_Ptr_Int64_Int32
+	funcType_Bool_Ptr_Int64_Int32_Int32_Ptr
+	funcType_Bool_Ptr_Int64_Int32_Int32_Ptr_Int32
+	funcType_Bool_Ptr_Int64_Int32_Ptr
+	funcType_Bool_Ptr_Int64_Int32_Ptr_Int32
+	funcType_Bool_Ptr_Int64_Int32_Ptr_Ptr_Int32
+	funcType_Bool_Ptr_Int64_Ptr
+	funcType_Bool_Ptr_Int64_Ptr_Bool
+	funcType_Bool_Ptr_Int64_Ptr_Int32
+	funcType_Bool_Ptr_Int64_Ptr_Int32_Int32_Ptr
+	funcType_Bool_Ptr_Int64_Ptr_Ptr
+	funcType_Bool_Ptr_Int64_Ptr_Ptr_Int32_Ptr
 	funcType_Bool_Ptr_Ptr
+	funcType_Bool_Ptr_Ptr_Float
+	funcType_Bool_Ptr_Ptr_Float_Double
+	funcType_Bool_Ptr_Ptr_Int32
+	funcType_Bool_Ptr_Ptr_Int32_Int32
 	funcType_Bool_Ptr_Ptr_Ptr
 	funcType_Bool_Ptr_Ptr_Ptr_Int32
+	funcType_Bool_Ptr_Ptr_Ptr_Ptr
 	funcType_Bool_Int32
+	funcType_Bool_Int32_Int64_Ptr_Int32_Int32_Ptr
+	funcType_Bool_Int32_Ptr
+	funcType_Int32
 	funcType_Int32_Int64
 	funcType_Int32_Ptr
+	funcType_Int32_Ptr_Bool
+	funcType_Int32_Ptr_Int32
 	funcType_Int32_Ptr_Int32_Ptr_Int32
+	funcType_Int32_Ptr_Int32_Ptr_Int32_Ptr_Ptr
 	funcType_Int32_Ptr_Int32_Ptr_Ptr_Ptr_Int32
 	funcType_Int32_Ptr_Int64
+	funcType_Int32_Ptr_Int64_Int32
+	funcType_Int32_Ptr_Int64_Ptr_Int32_Int32_Int32
+	funcType_Int32_Ptr_Int64_Ptr_Ptr
 	funcType_Int32_Ptr_Ptr
+	funcType_Int32_Ptr_Ptr_Int32
+	funcType_Int32_Ptr_Ptr_Int32_Bool
+	funcType_Int32_Ptr_Ptr_Int32_Ptr_Ptr
 	funcType_Int32_Ptr_Ptr_Ptr_Int32
 	funcType_Int64_Ptr
+	funcType_Int64_Ptr_Int32
+	funcType_Int64_Ptr_Int32_Int32
+	funcType_Int64_Ptr_Int32_Int32_Int32_Int32_Int32
+	funcType_Int64_Ptr_Int32_Int32_Int32_Int32_Int32_Int32_Int32
+	funcType_Int64_Ptr_Int32_Int64
+	funcType_Int64_Ptr_Int64
+	funcType_Int64_Ptr_Int64_Int32
+	funcType_Int64_Ptr_Int64_Int32_Int32_Int32
+	funcType_Int64_Ptr_Int64_Int32_Int32_Ptr_Int32
+	funcType_Int64_Ptr_Int64_Int64
+	funcType_Int64_Ptr_Int64_Ptr
+	funcType_Int64_Ptr_Int64_Ptr_Int32
+	funcType_Int64_Ptr_Ptr
+	funcType_Int64_Ptr_Ptr_Int32
+	funcType_Int64_Ptr_Ptr_Int32_Int32
+	funcType_Int64_Ptr_Ptr_Ptr_Int32
 	funcType_Ptr
 	funcType_Ptr_Ptr
+	funcType_Ptr_Ptr_Int32
+	funcType_Ptr_Ptr_Int32_Ptr
+	funcType_Ptr_Ptr_Int64
+	funcType_Ptr_Ptr_Ptr
+	funcType_Ptr_Ptr_Ptr_Ptr
 	funcType_Void
+	funcType_Void_Int32
 	funcType_Void_Ptr_Bool
+	funcType_Void_Ptr_Int32
+	funcType_Void_Ptr_Int32_Int32
+	funcType_Void_Ptr_Int64
+	funcType_Void_Ptr_Ptr
+	funcType_Void_Ptr_Ptr_Int32
+	funcType_Void_Ptr_Ptr_Int64
 )
 
-func (l *lib) call(ftype funcType, name string, args ...uintptr) (C.uint64_t, error) {
+func (l *lib) proc(name string) C.uintptr_t {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
 	if l.procs == nil {
 		l.procs = map[string]C.uintptr_t{}
 	}
@@ -151,8 +474,31 @@ func (l *lib) call(ftype funcType, name string, args ...uintptr) (C.uint64_t, er
 		defer C.free(unsafe.Pointer(cname))
 		l.procs[name] = C.dlsym_(l.lib, cname)
 	}
+	return l.procs[name]
+}
 
-	f := l.procs[name]
+// call calls the function name of the library, or the Backend set by SetBackend.
+// The arguments are those callArg takes. Pointers passed as unsafe.Pointer are kept alive and in place
+// for the duration of the call.
+func (l *lib) call(ftype funcType, name string, argv ...any) (C.uint64_t, error) {
+	escape(argv)
+	defer runtime.KeepAlive(argv)
+
+	args := callArgs(argv)
+	if b := currentBackend(); b != nil {
+		v, err := b.Call(name, args...)
+		return C.uint64_t(v), err
+	}
+	if err := l.ensureLoaded(); err != nil {
+		return 0, err
+	}
+	f := l.proc(name)
+	if f == 0 {
+		return 0, fmt.Errorf("steamworks: function %s not found", name)
+	}
+	if err := checkInterface(name, argv); err != nil {
+		return 0, err
+	}
 	switch ftype {
 	case funcType_Bool:
 		return C.uint64_t(C.callFunc_Bool(f)), nil
@@ -164,81 +510,319 @@ func (l *lib) call(ftype funcType, name string, args ...uintptr) (C.uint64_t, er
 		return C.uint64_t(C.callFunc_Bool_Ptr_Int32(f, C.uintptr_t(args[0]), C.int32_t(args[1]))), nil
 	case funcType_Bool_Ptr_Int32_Int32_Int32_Int32_Int32:
 		return C.uint64_t(C.callFunc_Bool_Ptr_Int32_Int32_Int32_Int32_Int32(f, C.uintptr_t(args[0]), C.int32_t(args[1]), C.int32_t(args[2]), C.int32_t(args[3]), C.int32_t(args[4]), C.int32_t(args[5]))), nil
+	case funcType_Bool_Ptr_Int32_Ptr_Int32:
+		return C.uint64_t(C.callFunc_Bool_Ptr_Int32_Ptr_Int32(f, C.uintptr_t(args[0]), C.int32_t(args[1]), C.uintptr_t(args[2]), C.int32_t(args[3]))), nil
+	case funcType_Bool_Ptr_Int32_Ptr_Ptr:
+		return C.uint64_t(C.callFunc_Bool_Ptr_Int32_Ptr_Ptr(f, C.uintptr_t(args[0]), C.int32_t(args[1]), C.uintptr_t(args[2]), C.uintptr_t(args[3]))), nil
+	case funcType_Bool_Ptr_Int64:
+		return C.uint64_t(C.callFunc_Bool_Ptr_Int64(f, C.uintptr_t(args[0]), C.int64_t(args[1]))), nil
+	case funcType_Bool_Ptr_Int64_Bool:
+		return C.uint64_t(C.callFunc_Bool_Ptr_Int64_Bool(f, C.uintptr_t(args[0]), C.int64_t(args[1]), C.uint8_t(args[2]))), nil
+	case funcType_Bool_Ptr_Int64_Int32:
+		return C.uint64_t(C.callFunc_Bool_Ptr_Int64_Int32(f, C.uintptr_t(args[0]), C.int64_t(args[1]), C.int32_t(args[2]))), nil
+	case funcType_Bool_Ptr_Int64_Int32_Int32_Ptr:
+		return C.uint64_t(C.callFunc_Bool_Ptr_Int64_Int32_Int32_Ptr(f, C.uintptr_t(args[0]), C.int64_t(args[1]), C.int32_t(args[2]), C.int32_t(args[3]), C.uintptr_t(args[4]))), nil
+	case funcType_Bool_Ptr_Int64_Int32_Int32_Ptr_Int32:
+		return C.uint64_t(C.callFunc_Bool_Ptr_Int64_Int32_Int32_Ptr_Int32(f, C.uintptr_t(args[0]), C.int64_t(args[1]), C.int32_t(args[2]), C.int32_t(args[3]), C.uintptr_t(args[4]), C.int32_t(args[5]))), nil
+	case funcType_Bool_Ptr_Int64_Int32_Ptr:
+		return C.uint64_t(C.callFunc_Bool_Ptr_Int64_Int32_Ptr(f, C.uintptr_t(args[0]), C.int64_t(args[1]), C.int32_t(args[2]), C.uintptr_t(args[3]))), nil
+	case funcType_Bool_Ptr_Int64_Int32_Ptr_Int32:
+		return C.uint64_t(C.callFunc_Bool_Ptr_Int64_Int32_Ptr_Int32(f, C.uintptr_t(args[0]), C.int64_t(args[1]), C.int32_t(args[2]), C.uintptr_t(args[3]), C.int32_t(args[4]))), nil
+	case funcType_Bool_Ptr_Int64_Int32_Ptr_Ptr_Int32:
+		return C.uint64_t(C.callFunc_Bool_Ptr_Int64_Int32_Ptr_Ptr_Int32(f, C.uintptr_t(args[0]), C.int64_t(args[1]), C.int32_t(args[2]), C.uintptr_t(args[3]), C.uintptr_t(args[4]), C.int32_t(args[5]))), nil
+	case funcType_Bool_Ptr_Int64_Ptr:
+		return C.uint64_t(C.callFunc_Bool_Ptr_Int64_Ptr(f, C.uintptr_t(args[0]), C.int64_t(args[1]), C.uintptr_t(args[2]))), nil
+	case funcType_Bool_Ptr_Int64_Ptr_Bool:
+		return C.uint64_t(C.callFunc_Bool_Ptr_Int64_Ptr_Bool(f, C.uintptr_t(args[0]), C.int64_t(args[1]), C.uintptr_t(args[2]), C.uint8_t(args[3]))), nil
+	case funcType_Bool_Ptr_Int64_Ptr_Int32:
+		return C.uint64_t(C.callFunc_Bool_Ptr_Int64_Ptr_Int32(f, C.uintptr_t(args[0]), C.int64_t(args[1]), C.uintptr_t(args[2]), C.int32_t(args[3]))), nil
+	case funcType_Bool_Ptr_Int64_Ptr_Int32_Int32_Ptr:
+		return C.uint64_t(C.callFunc_Bool_Ptr_Int64_Ptr_Int32_Int32_Ptr(f, C.uintptr_t(args[0]), C.int64_t(args[1]), C.uintptr_t(args[2]), C.int32_t(args[3]), C.int32_t(args[4]), C.uintptr_t(args[5]))), nil
+	case funcType_Bool_Ptr_Int64_Ptr_Ptr:
+		return C.uint64_t(C.callFunc_Bool_Ptr_Int64_Ptr_Ptr(f, C.uintptr_t(args[0]), C.int64_t(args[1]), C.uintptr_t(args[2]), C.uintptr_t(args[3]))), nil
+	case funcType_Bool_Ptr_Int64_Ptr_Ptr_Int32_Ptr:
+		return C.uint64_t(C.callFunc_Bool_Ptr_Int64_Ptr_Ptr_Int32_Ptr(f, C.uintptr_t(args[0]), C.int64_t(args[1]), C.uintptr_t(args[2]), C.uintptr_t(args[3]), C.int32_t(args[4]), C.uintptr_t(args[5]))), nil
 	case funcType_Bool_Ptr_Ptr:
 		return C.uint64_t(C.callFunc_Bool_Ptr_Ptr(f, C.uintptr_t(args[0]), C.uintptr_t(args[1]))), nil
+	case funcType_Bool_Ptr_Ptr_Float:
+		return C.uint64_t(C.callFunc_Bool_Ptr_Ptr_Float(f, C.uintptr_t(args[0]), C.uintptr_t(args[1]), C.uint32_t(args[2]))), nil
+	case funcType_Bool_Ptr_Ptr_Float_Double:
+		return C.uint64_t(C.callFunc_Bool_Ptr_Ptr_Float_Double(f, C.uintptr_t(args[0]), C.uintptr_t(args[1]), C.uint32_t(args[2]), C.uint64_t(args[3]))), nil
+	case funcType_Bool_Ptr_Ptr_Int32:
+		return C.uint64_t(C.callFunc_Bool_Ptr_Ptr_Int32(f, C.uintptr_t(args[0]), C.uintptr_t(args[1]), C.int32_t(args[2]))), nil
+	case funcType_Bool_Ptr_Ptr_Int32_Int32:
+		return C.uint64_t(C.callFunc_Bool_Ptr_Ptr_Int32_Int32(f, C.uintptr_t(args[0]), C.uintptr_t(args[1]), C.int32_t(args[2]), C.int32_t(args[3]))), nil
 	case funcType_Bool_Ptr_Ptr_Ptr:
 		return C.uint64_t(C.callFunc_Bool_Ptr_Ptr_Ptr(f, C.uintptr_t(args[0]), C.uintptr_t(args[1]), C.uintptr_t(args[2]))), nil
 	case funcType_Bool_Ptr_Ptr_Ptr_Int32:
 		return C.uint64_t(C.callFunc_Bool_Ptr_Ptr_Ptr_Int32(f, C.uintptr_t(args[0]), C.uintptr_t(args[1]), C.uintptr_t(args[2]), C.int32_t(args[3]))), nil
+	case funcType_Bool_Ptr_Ptr_Ptr_Ptr:
+		return C.uint64_t(C.callFunc_Bool_Ptr_Ptr_Ptr_Ptr(f, C.uintptr_t(args[0]), C.uintptr_t(args[1]), C.uintptr_t(args[2]), C.uintptr_t(args[3]))), nil
 	case funcType_Bool_Int32:
 		return C.uint64_t(C.callFunc_Bool_Int32(f, C.uint32_t(args[0]))), nil
+	case funcType_Bool_Int32_Int64_Ptr_Int32_Int32_Ptr:
+		return C.uint64_t(C.callFunc_Bool_Int32_Int64_Ptr_Int32_Int32_Ptr(f, C.int32_t(args[0]), C.int64_t(args[1]), C.uintptr_t(args[2]), C.int32_t(args[3]), C.int32_t(args[4]), C.uintptr_t(args[5]))), nil
+	case funcType_Bool_Int32_Ptr:
+		return C.uint64_t(C.callFunc_Bool_Int32_Ptr(f, C.int32_t(args[0]), C.uintptr_t(args[1]))), nil
+	case funcType_Int32:
+		return C.uint64_t(C.callFunc_Int32(f)), nil
 	case funcType_Int32_Ptr:
 		return C.uint64_t(C.callFunc_Int32_Ptr(f, C.uintptr_t(args[0]))), nil
+	case funcType_Int32_Ptr_Bool:
+		return C.uint64_t(C.callFunc_Int32_Ptr_Bool(f, C.uintptr_t(args[0]), C.uint8_t(args[1]))), nil
+	case funcType_Int32_Ptr_Int32:
+		return C.uint64_t(C.callFunc_Int32_Ptr_Int32(f, C.uintptr_t(args[0]), C.int32_t(args[1]))), nil
 	case funcType_Int32_Ptr_Int32_Ptr_Int32:
 		return C.uint64_t(C.callFunc_Int32_Ptr_Int32_Ptr_Int32(f, C.uintptr_t(args[0]), C.int32_t(args[1]), C.uintptr_t(args[2]), C.int32_t(args[3]))), nil
+	case funcType_Int32_Ptr_Int32_Ptr_Int32_Ptr_Ptr:
+		return C.uint64_t(C.callFunc_Int32_Ptr_Int32_Ptr_Int32_Ptr_Ptr(f, C.uintptr_t(args[0]), C.int32_t(args[1]), C.uintptr_t(args[2]), C.int32_t(args[3]), C.uintptr_t(args[4]), C.uintptr_t(args[5]))), nil
 	case funcType_Int32_Ptr_Int32_Ptr_Ptr_Ptr_Int32:
 		return C.uint64_t(C.callFunc_Int32_Ptr_Int32_Ptr_Ptr_Ptr_Int32(f, C.uintptr_t(args[0]), C.int32_t(args[1]), C.uintptr_t(args[2]), C.uintptr_t(args[3]), C.uintptr_t(args[4]), C.int32_t(args[5]))), nil
 	case funcType_Int32_Ptr_Int64:
 		return C.uint64_t(C.callFunc_Int32_Ptr_Int64(f, C.uintptr_t(args[0]), C.int64_t(args[1]))), nil
+	case funcType_Int32_Ptr_Int64_Int32:
+		return C.uint64_t(C.callFunc_Int32_Ptr_Int64_Int32(f, C.uintptr_t(args[0]), C.int64_t(args[1]), C.int32_t(args[2]))), nil
+	case funcType_Int32_Ptr_Int64_Ptr_Int32_Int32_Int32:
+		return C.uint64_t(C.callFunc_Int32_Ptr_Int64_Ptr_Int32_Int32_Int32(f, C.uintptr_t(args[0]), C.int64_t(args[1]), C.uintptr_t(args[2]), C.int32_t(args[3]), C.int32_t(args[4]), C.int32_t(args[5]))), nil
+	case funcType_Int32_Ptr_Int64_Ptr_Ptr:
+		return C.uint64_t(C.callFunc_Int32_Ptr_Int64_Ptr_Ptr(f, C.uintptr_t(args[0]), C.int64_t(args[1]), C.uintptr_t(args[2]), C.uintptr_t(args[3]))), nil
 	case funcType_Int32_Ptr_Ptr:
 		return C.uint64_t(C.callFunc_Int32_Ptr_Ptr(f, C.uintptr_t(args[0]), C.uintptr_t(args[1]))), nil
+	case funcType_Int32_Ptr_Ptr_Int32:
+		return C.uint64_t(C.callFunc_Int32_Ptr_Ptr_Int32(f, C.uintptr_t(args[0]), C.uintptr_t(args[1]), C.int32_t(args[2]))), nil
+	case funcType_Int32_Ptr_Ptr_Int32_Bool:
+		return C.uint64_t(C.callFunc_Int32_Ptr_Ptr_Int32_Bool(f, C.uintptr_t(args[0]), C.uintptr_t(args[1]), C.int32_t(args[2]), C.uint8_t(args[3]))), nil
+	case funcType_Int32_Ptr_Ptr_Int32_Ptr_Ptr:
+		return C.uint64_t(C.callFunc_Int32_Ptr_Ptr_Int32_Ptr_Ptr(f, C.uintptr_t(args[0]), C.uintptr_t(args[1]), C.int32_t(args[2]), C.uintptr_t(args[3]), C.uintptr_t(args[4]))), nil
 	case funcType_Int32_Ptr_Ptr_Ptr_Int32:
 		return C.uint64_t(C.callFunc_Int32_Ptr_Ptr_Ptr_Int32(f, C.uintptr_t(args[0]), C.uintptr_t(args[1]), C.uintptr_t(args[2]), C.int32_t(args[3]))), nil
 	case funcType_Int64_Ptr:
 		return C.uint64_t(C.callFunc_Int64_Ptr(f, C.uintptr_t(args[0]))), nil
+	case funcType_Int64_Ptr_Int32:
+		return C.uint64_t(C.callFunc_Int64_Ptr_Int32(f, C.uintptr_t(args[0]), C.int32_t(args[1]))), nil
+	case funcType_Int64_Ptr_Int32_Int32:
+		return C.uint64_t(C.callFunc_Int64_Ptr_Int32_Int32(f, C.uintptr_t(args[0]), C.int32_t(args[1]), C.int32_t(args[2]))), nil
+	case funcType_Int64_Ptr_Int32_Int32_Int32_Int32_Int32:
+		return C.uint64_t(C.callFunc_Int64_Ptr_Int32_Int32_Int32_Int32_Int32(f, C.uintptr_t(args[0]), C.int32_t(args[1]), C.int32_t(args[2]), C.int32_t(args[3]), C.int32_t(args[4]), C.int32_t(args[5]))), nil
+	case funcType_Int64_Ptr_Int32_Int32_Int32_Int32_Int32_Int32_Int32:
+		return C.uint64_t(C.callFunc_Int64_Ptr_Int32_Int32_Int32_Int32_Int32_Int32_Int32(f, C.uintptr_t(args[0]), C.int32_t(args[1]), C.int32_t(args[2]), C.int32_t(args[3]), C.int32_t(args[4]), C.int32_t(args[5]), C.int32_t(args[6]), C.int32_t(args[7]))), nil
+	case funcType_Int64_Ptr_Int32_Int64:
+		return C.uint64_t(C.callFunc_Int64_Ptr_Int32_Int64(f, C.uintptr_t(args[0]), C.int32_t(args[1]), C.int64_t(args[2]))), nil
+	case funcType_Int64_Ptr_Int64:
+		return C.uint64_t(C.callFunc_Int64_Ptr_Int64(f, C.uintptr_t(args[0]), C.int64_t(args[1]))), nil
+	case funcType_Int64_Ptr_Int64_Int32:
+		return C.uint64_t(C.callFunc_Int64_Ptr_Int64_Int32(f, C.uintptr_t(args[0]), C.int64_t(args[1]), C.int32_t(args[2]))), nil
+	case funcType_Int64_Ptr_Int64_Int32_Int32_Int32:
+		return C.uint64_t(C.callFunc_Int64_Ptr_Int64_Int32_Int32_Int32(f, C.uintptr_t(args[0]), C.int64_t(args[1]), C.int32_t(args[2]), C.int32_t(args[3]), C.int32_t(args[4]))), nil
+	case funcType_Int64_Ptr_Int64_Int32_Int32_Ptr_Int32:
+		return C.uint64_t(C.callFunc_Int64_Ptr_Int64_Int32_Int32_Ptr_Int32(f, C.uintptr_t(args[0]), C.int64_t(args[1]), C.int32_t(args[2]), C.int32_t(args[3]), C.uintptr_t(args[4]), C.int32_t(args[5]))), nil
+	case funcType_Int64_Ptr_Int64_Int64:
+		return C.uint64_t(C.callFunc_Int64_Ptr_Int64_Int64(f, C.uintptr_t(args[0]), C.int64_t(args[1]), C.int64_t(args[2]))), nil
+	case funcType_Int64_Ptr_Int64_Ptr:
+		return C.uint64_t(C.callFunc_Int64_Ptr_Int64_Ptr(f, C.uintptr_t(args[0]), C.int64_t(args[1]), C.uintptr_t(args[2]))), nil
+	case funcType_Int64_Ptr_Int64_Ptr_Int32:
+		return C.uint64_t(C.callFunc_Int64_Ptr_Int64_Ptr_Int32(f, C.uintptr_t(args[0]), C.int64_t(args[1]), C.uintptr_t(args[2]), C.int32_t(args[3]))), nil
+	case funcType_Int64_Ptr_Ptr:
+		return C.uint64_t(C.callFunc_Int64_Ptr_Ptr(f, C.uintptr_t(args[0]), C.uintptr_t(args[1]))), nil
+	case funcType_Int64_Ptr_Ptr_Int32:
+		return C.uint64_t(C.callFunc_Int64_Ptr_Ptr_Int32(f, C.uintptr_t(args[0]), C.uintptr_t(args[1]), C.int32_t(args[2]))), nil
+	case funcType_Int64_Ptr_Ptr_Int32_Int32:
+		return C.uint64_t(C.callFunc_Int64_Ptr_Ptr_Int32_Int32(f, C.uintptr_t(args[0]), C.uintptr_t(args[1]), C.int32_t(args[2]), C.int32_t(args[3]))), nil
+	case funcType_Int64_Ptr_Ptr_Ptr_Int32:
+		return C.uint64_t(C.callFunc_Int64_Ptr_Ptr_Ptr_Int32(f, C.uintptr_t(args[0]), C.uintptr_t(args[1]), C.uintptr_t(args[2]), C.int32_t(args[3]))), nil
 	case funcType_Ptr:
 		return C.uint64_t(C.callFunc_Ptr(f)), nil
 	case funcType_Ptr_Ptr:
 		return C.uint64_t(C.callFunc_Ptr_Ptr(f, C.uintptr_t(args[0]))), nil
+	case funcType_Ptr_Ptr_Int32:
+		return C.uint64_t(C.callFunc_Ptr_Ptr_Int32(f, C.uintptr_t(args[0]), C.int32_t(args[1]))), nil
+	case funcType_Ptr_Ptr_Int32_Ptr:
+		return C.uint64_t(C.callFunc_Ptr_Ptr_Int32_Ptr(f, C.uintptr_t(args[0]), C.int32_t(args[1]), C.uintptr_t(args[2]))), nil
+	case funcType_Ptr_Ptr_Int64:
+		return C.uint64_t(C.callFunc_Ptr_Ptr_Int64(f, C.uintptr_t(args[0]), C.int64_t(args[1]))), nil
+	case funcType_Ptr_Ptr_Ptr:
+		return C.uint64_t(C.callFunc_Ptr_Ptr_Ptr(f, C.uintptr_t(args[0]), C.uintptr_t(args[1]))), nil
+	case funcType_Ptr_Ptr_Ptr_Ptr:
+		return C.uint64_t(C.callFunc_Ptr_Ptr_Ptr_Ptr(f, C.uintptr_t(args[0]), C.uintptr_t(args[1]), C.uintptr_t(args[2]))), nil
 	case funcType_Void:
 		C.callFunc_Void(f)
 		return 0, nil
+	case funcType_Void_Int32:
+		C.callFunc_Void_Int32(f, C.int32_t(args[0]))
+		return 0, nil
 	case funcType_Void_Ptr_Bool:
 		C.callFunc_Void_Ptr_Bool(f, C.uintptr_t(args[0]), C.uint8_t(args[1]))
 		return 0, nil
+	case funcType_Void_Ptr_Int32:
+		C.callFunc_Void_Ptr_Int32(f, C.uintptr_t(args[0]), C.int32_t(args[1]))
+		return 0, nil
+	case funcType_Void_Ptr_Int32_Int32:
+		C.callFunc_Void_Ptr_Int32_Int32(f, C.uintptr_t(args[0]), C.int32_t(args[1]), C.int32_t(args[2]))
+		return 0, nil
+	case funcType_Void_Ptr_Int64:
+		C.callFunc_Void_Ptr_Int64(f, C.uintptr_t(args[0]), C.int64_t(args[1]))
+		return 0, nil
+	case funcType_Void_Ptr_Ptr:
+		C.callFunc_Void_Ptr_Ptr(f, C.uintptr_t(args[0]), C.uintptr_t(args[1]))
+		return 0, nil
+	case funcType_Void_Ptr_Ptr_Int32:
+		C.callFunc_Void_Ptr_Ptr_Int32(f, C.uintptr_t(args[0]), C.uintptr_t(args[1]), C.int32_t(args[2]))
+		return 0, nil
+	case funcType_Void_Ptr_Ptr_Int64:
+		C.callFunc_Void_Ptr_Ptr_Int64(f, C.uintptr_t(args[0]), C.uintptr_t(args[1]), C.int64_t(args[2]))
+		return 0, nil
 	}
 
+	if callGenerated != nil {
+		if v, ok := callGenerated(ftype, f, args); ok {
+			return v, nil
+		}
+	}
 	return 0, fmt.Errorf("steamworks: function %s not implemented", name)
 }
 
-func loadLib() (C.uintptr_t, error) {
-	dir, err := os.MkdirTemp("", "")
-	if err != nil {
-		return 0, err
-	}
+// callGenerated calls f if ftype is one of the function types that go generate adds in steamworks_gen_unix.go.
+var callGenerated func(ftype funcType, f C.uintptr_t, args []uint64) (C.uint64_t, bool)
 
-	ext := ".so"
+func libName() string {
 	if runtime.GOOS == "darwin" {
-		ext = ".dylib"
-	}
-	path := filepath.Join(dir, "libsteam_api"+ext)
-	if err := os.WriteFile(path, libSteamAPI, 0644); err != nil {
-		return 0, err
+		return "libsteam_api.dylib"
 	}
+	return "libsteam_api.so"
+}
 
+func dlopen(path string) (C.uintptr_t, error) {
 	cpath := C.CString(path)
 	defer C.free(unsafe.Pointer(cpath))
 
 	lib := C.uintptr_t(uintptr(C.dlopen(cpath, C.RTLD_LAZY)))
 	if lib == 0 {
-		return 0, fmt.Errorf("steamworks: dlopen failed: %s", C.GoString(C.dlerror()))
+		return 0, fmt.Errorf("steamworks: dlopen %s failed: %s", path, C.GoString(C.dlerror()))
 	}
-
 	return lib, nil
 }
 
-var theLib *lib
+// openLib opens the library found where opts says, or else the embedded one.
+func openLib(opts *LoadOptions) (C.uintptr_t, string, error) {
+	path, err := opts.findLibrary(libName())
+	if err == nil {
+		lib, err := dlopen(path)
+		return lib, path, err
+	}
+	if opts.Path != "" || opts.DisableEmbedded {
+		return 0, "", err
+	}
+
+	if opts.CacheDir != "" {
+		path, err := extractLib(opts.CacheDir)
+		if err != nil {
+			return 0, "", err
+		}
+		lib, err := dlopen(path)
+		return lib, path, err
+	}
+
+	// The library stays mapped after its file is removed.
+	dir, err := os.MkdirTemp("", "steamworks")
+	if err != nil {
+		return 0, "", err
+	}
+	defer os.RemoveAll(dir)
+
+	path = filepath.Join(dir, libName())
+	if err := os.WriteFile(path, libSteamAPI, 0644); err != nil {
+		return 0, "", err
+	}
+	lib, err := dlopen(path)
+	return lib, path, err
+}
+
+// extractLib extracts the embedded library to a directory under cacheDir named after its hash,
+// unless an earlier run already has.
+func extractLib(cacheDir string) (string, error) {
+	sum := sha256.Sum256(libSteamAPI)
+	dir := filepath.Join(cacheDir, "steamworks-"+hex.EncodeToString(sum[:8]))
+	path := filepath.Join(dir, libName())
 
-func init() {
-	l, err := loadLib()
+	if b, err := os.ReadFile(path); err == nil && bytes.Equal(b, libSteamAPI) {
+		return path, nil
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	f, err := os.CreateTemp(dir, libName()+".*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(libSteamAPI); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	// Rename so that a concurrent run never loads a partly written copy.
+	if err := os.Rename(f.Name(), path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func (l *lib) load(opts *LoadOptions) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.lib != 0 {
+		return nil
+	}
+
+	lib, path, err := openLib(opts)
 	if err != nil {
-		panic(err)
+		l.err = err
+		return err
+	}
+	if !opts.SkipVersionCheck {
+		for _, name := range versionedInterfaces {
+			cname := C.CString(name)
+			f := C.dlsym_(lib, cname)
+			C.free(unsafe.Pointer(cname))
+			if f == 0 {
+				C.dlclose_(lib)
+				l.err = fmt.Errorf("steamworks: %s does not export %s, which Steamworks SDK %s has", path, name, SDKVersion)
+				return l.err
+			}
+		}
+	}
+
+	l.lib = lib
+	l.err = nil
+	return nil
+}
+
+// ensureLoaded loads the library with the default options, unless Load has already been called.
+func (l *lib) ensureLoaded() error {
+	l.mu.Lock()
+	loaded, err := l.lib != 0, l.err
+	l.mu.Unlock()
+
+	if loaded {
+		return nil
 	}
-	theLib = &lib{
-		lib: l,
+	if err != nil {
+		return err
 	}
+	return l.load(&LoadOptions{})
+}
+
+func loadLibrary(opts *LoadOptions) error {
+	return theLib.load(opts)
 }
 
+var theLib = &lib{}
+
 func cBool(x bool) uintptr {
 	if x {
 		return 1
@@ -249,33 +833,84 @@ func cBool(x bool) uintptr {
 func RestartAppIfNecessary(appID uint32) bool {
 	v, err := theLib.call(funcType_Bool_Int32, flatAPI_RestartAppIfNecessary, uintptr(appID))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
 	return byte(v) != 0
 }
 
 func Init() error {
 	var msg steamErrMsg
-	v, err := theLib.call(funcType_Bool_Ptr, flatAPI_InitFlat, uintptr(unsafe.Pointer(&msg)))
+	v, err := theLib.call(funcType_Bool_Ptr, flatAPI_InitFlat, unsafe.Pointer(&msg))
 	if err != nil {
-		panic(err)
+		return err
 	}
 	if ESteamAPIInitResult(v) != ESteamAPIInitResult_OK {
 		return fmt.Errorf("steamworks: InitFlat failed: %d, %s", ESteamAPIInitResult(v), msg.String())
 	}
+	DefaultDispatcher().restart()
 	return nil
 }
 
-func RunCallbacks() {
-	if _, err := theLib.call(funcType_Void, flatAPI_RunCallbacks); err != nil {
-		panic(err)
+func shutdown() {
+	if _, err := theLib.call(funcType_Void, flatAPI_Shutdown); err != nil {
+		handleError(err)
+	}
+}
+
+func manualDispatchInit() error {
+	_, err := theLib.call(funcType_Void, flatAPI_ManualDispatch_Init)
+	return err
+}
+
+func getHSteamPipe() (HSteamPipe, error) {
+	v, err := theLib.call(funcType_Int32, flatAPI_GetHSteamPipe)
+	if err != nil {
+		return 0, err
+	}
+	return HSteamPipe(v), nil
+}
+
+func manualDispatchRunFrame(pipe HSteamPipe) error {
+	_, err := theLib.call(funcType_Void_Int32, flatAPI_ManualDispatch_RunFrame, uintptr(pipe))
+	return err
+}
+
+func manualDispatchGetNextCallback(pipe HSteamPipe) (msg callbackMsg, success bool, err error) {
+	msgC := msg.CStruct()
+	v, err := theLib.call(funcType_Bool_Int32_Ptr, flatAPI_ManualDispatch_GetNextCallback, uintptr(pipe), unsafe.Pointer(&msgC))
+	if err != nil {
+		return msg, false, err
+	}
+	if byte(v) == 0 {
+		return msg, false, nil
+	}
+	return msg.FromCStruct(msgC), true, nil
+}
+
+func manualDispatchFreeLastCallback(pipe HSteamPipe) error {
+	_, err := theLib.call(funcType_Void_Int32, flatAPI_ManualDispatch_FreeLastCallback, uintptr(pipe))
+	return err
+}
+
+func manualDispatchGetAPICallResult(pipe HSteamPipe, apiCall SteamAPICall_t, callbackExpected iCallbackExpected, callbaseSize int) (callback []byte, success bool, pbFailed bool, err error) {
+	callback = make([]byte, callbaseSize+1)
+	defer runtime.KeepAlive(callback)
+
+	v, err := theLib.call(funcType_Bool_Int32_Int64_Ptr_Int32_Int32_Ptr, flatAPI_ManualDispatch_GetAPICallResult, uintptr(pipe), apiCall, unsafe.Pointer(&callback[0]), uintptr(callbaseSize), uintptr(callbackExpected), unsafe.Pointer(&pbFailed))
+	if err != nil {
+		return nil, false, false, err
 	}
+	callback = callback[:callbaseSize]
+	success = byte(v) != 0
+	return
 }
 
 func SteamApps() ISteamApps {
 	v, err := theLib.call(funcType_Ptr, flatAPI_SteamApps)
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return steamApps(0)
 	}
 	return steamApps(v)
 }
@@ -284,9 +919,10 @@ type steamApps C.uintptr_t
 
 func (s steamApps) BGetDLCDataByIndex(iDLC int) (appID AppId_t, available bool, pchName string, success bool) {
 	var name [4096]byte
-	v, err := theLib.call(funcType_Int32_Ptr_Int32_Ptr_Ptr_Ptr_Int32, flatAPI_ISteamApps_BGetDLCDataByIndex, uintptr(s), uintptr(iDLC), uintptr(unsafe.Pointer(&appID)), uintptr(unsafe.Pointer(&available)), uintptr(unsafe.Pointer(&name[0])), uintptr(len(name)))
+	v, err := theLib.call(funcType_Int32_Ptr_Int32_Ptr_Ptr_Ptr_Int32, flatAPI_ISteamApps_BGetDLCDataByIndex, uintptr(s), uintptr(iDLC), unsafe.Pointer(&appID), unsafe.Pointer(&available), unsafe.Pointer(&name[0]), uintptr(len(name)))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return
 	}
 	return appID, available, C.GoString((*C.char)(unsafe.Pointer(&name[0]))), byte(v) != 0
 }
@@ -294,16 +930,18 @@ func (s steamApps) BGetDLCDataByIndex(iDLC int) (appID AppId_t, available bool,
 func (s steamApps) BIsDlcInstalled(appID AppId_t) bool {
 	v, err := theLib.call(funcType_Bool_Ptr_Int32, flatAPI_ISteamApps_BIsDlcInstalled, uintptr(s), uintptr(appID))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
 	return byte(v) != 0
 }
 
 func (s steamApps) GetAppInstallDir(appID AppId_t) string {
 	var path [4096]byte
-	v, err := theLib.call(funcType_Int32_Ptr_Int32_Ptr_Int32, flatAPI_ISteamApps_GetAppInstallDir, uintptr(s), uintptr(appID), uintptr(unsafe.Pointer(&path[0])), uintptr(len(path)))
+	v, err := theLib.call(funcType_Int32_Ptr_Int32_Ptr_Int32, flatAPI_ISteamApps_GetAppInstallDir, uintptr(s), uintptr(appID), unsafe.Pointer(&path[0]), uintptr(len(path)))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return ""
 	}
 	return string(path[:uint32(v)-1])
 }
@@ -311,7 +949,8 @@ func (s steamApps) GetAppInstallDir(appID AppId_t) string {
 func (s steamApps) GetCurrentGameLanguage() string {
 	v, err := theLib.call(funcType_Ptr_Ptr, flatAPI_ISteamApps_GetCurrentGameLanguage, uintptr(s))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return ""
 	}
 	return C.GoString(C.uintptrToChar(C.uintptr_t(v)))
 }
@@ -319,243 +958,1623 @@ func (s steamApps) GetCurrentGameLanguage() string {
 func (s steamApps) GetDLCCount() int32 {
 	v, err := theLib.call(funcType_Int32_Ptr, flatAPI_ISteamApps_GetDLCCount, uintptr(s))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return 0
 	}
 	return int32(v)
 }
 
-func SteamFriends() ISteamFriends {
-	v, err := theLib.call(funcType_Ptr, flagAPI_SteamFriends)
+func (s steamApps) boolCall(name string) bool {
+	v, err := theLib.call(funcType_Bool_Ptr, name, uintptr(s))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
-	return steamFriends(v)
+	return byte(v) != 0
 }
 
-type steamFriends C.uintptr_t
+func (s steamApps) BIsSubscribed() bool {
+	return s.boolCall(flatAPI_ISteamApps_BIsSubscribed)
+}
 
-func (s steamFriends) GetPersonaName() string {
-	v, err := theLib.call(funcType_Ptr_Ptr, flatAPI_ISteamFriends_GetPersonaName, uintptr(s))
+func (s steamApps) BIsSubscribedApp(appID AppId_t) bool {
+	v, err := theLib.call(funcType_Bool_Ptr_Int32, flatAPI_ISteamApps_BIsSubscribedApp, uintptr(s), uintptr(appID))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
-	return C.GoString(C.uintptrToChar(C.uintptr_t(v)))
+	return byte(v) != 0
 }
 
-func (s steamFriends) SetRichPresence(key, value string) bool {
-	ckey := C.CString(key)
-	defer C.free(unsafe.Pointer(ckey))
-	cvalue := C.CString(value)
-	defer C.free(unsafe.Pointer(cvalue))
-
-	v, err := theLib.call(funcType_Bool_Ptr_Ptr_Ptr, flatAPI_ISteamFriends_SetRichPresence, uintptr(s), uintptr(unsafe.Pointer(ckey)), uintptr(unsafe.Pointer(cvalue)))
-	if err != nil {
-		panic(err)
-	}
+func (s steamApps) BIsLowViolence() bool {
+	return s.boolCall(flatAPI_ISteamApps_BIsLowViolence)
+}
 
-	return byte(v) != 0
+func (s steamApps) BIsCybercafe() bool {
+	return s.boolCall(flatAPI_ISteamApps_BIsCybercafe)
 }
 
-func SteamInput() ISteamInput {
-	v, err := theLib.call(funcType_Ptr, flatAPI_SteamInput)
-	if err != nil {
-		panic(err)
-	}
-	return steamInput(v)
+func (s steamApps) BIsVACBanned() bool {
+	return s.boolCall(flatAPI_ISteamApps_BIsVACBanned)
 }
 
-type steamInput C.uintptr_t
+func (s steamApps) BIsSubscribedFromFreeWeekend() bool {
+	return s.boolCall(flatAPI_ISteamApps_BIsSubscribedFromFreeWeekend)
+}
 
-func (s steamInput) GetConnectedControllers() []InputHandle_t {
-	var handles [_STEAM_INPUT_MAX_COUNT]InputHandle_t
-	v, err := theLib.call(funcType_Int32_Ptr_Ptr, flatAPI_ISteamInput_GetConnectedControllers, uintptr(s), uintptr(unsafe.Pointer(&handles[0])))
+func (s steamApps) BIsTimedTrial() (allowed, played time.Duration, ok bool) {
+	var secondsAllowed, secondsPlayed uint32
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr_Ptr, flatAPI_ISteamApps_BIsTimedTrial, uintptr(s), unsafe.Pointer(&secondsAllowed), unsafe.Pointer(&secondsPlayed))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return 0, 0, false
 	}
-	return handles[:int(v)]
+	if byte(v) == 0 {
+		return 0, 0, false
+	}
+	return time.Duration(secondsAllowed) * time.Second, time.Duration(secondsPlayed) * time.Second, true
 }
 
-func (s steamInput) GetInputTypeForHandle(inputHandle InputHandle_t) ESteamInputType {
-	v, err := theLib.call(funcType_Int32_Ptr_Int64, flatAPI_ISteamInput_GetInputTypeForHandle, uintptr(s), uintptr(inputHandle))
+func (s steamApps) GetAvailableGameLanguages() []string {
+	v, err := theLib.call(funcType_Ptr_Ptr, flatAPI_ISteamApps_GetAvailableGameLanguages, uintptr(s))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return nil
 	}
-	return ESteamInputType(v)
+	return splitLanguages(C.GoString(C.uintptrToChar(C.uintptr_t(v))))
 }
 
-func (s steamInput) Init(bExplicitlyCallRunFrame bool) bool {
-	var callRunFrame uintptr
-	if bExplicitlyCallRunFrame {
-		callRunFrame = 1
-	}
-	v, err := theLib.call(funcType_Bool_Ptr_Bool, flatAPI_ISteamInput_Init, uintptr(s), callRunFrame)
+func (s steamApps) GetEarliestPurchaseUnixTime(appID AppId_t) time.Time {
+	v, err := theLib.call(funcType_Int32_Ptr_Int32, flatAPI_ISteamApps_GetEarliestPurchaseUnixTime, uintptr(s), uintptr(appID))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return time.Time{}
 	}
-	return byte(v) != 0
+	return unixTime(int64(uint32(v)))
 }
 
-func (s steamInput) RunFrame() {
-	if _, err := theLib.call(funcType_Void_Ptr_Bool, flatAPI_ISteamInput_RunFrame, uintptr(s), 0); err != nil {
-		panic(err)
+func (s steamApps) GetCurrentBetaName() (name string, ok bool) {
+	var buf [256]byte
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr_Int32, flatAPI_ISteamApps_GetCurrentBetaName, uintptr(s), unsafe.Pointer(&buf[0]), uintptr(len(buf)))
+	if err != nil {
+		handleError(err)
+		return "", false
+	}
+	if byte(v) == 0 {
+		return "", false
 	}
+	return cBufferToString(buf[:]), true
 }
 
-func SteamRemoteStorage() ISteamRemoteStorage {
-	v, err := theLib.call(funcType_Ptr, flatAPI_SteamRemoteStorage)
+func (s steamApps) GetAppBuildId() int32 {
+	v, err := theLib.call(funcType_Int32_Ptr, flatAPI_ISteamApps_GetAppBuildId, uintptr(s))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return 0
 	}
-	return steamRemoteStorage(v)
+	return int32(v)
+}
+
+func (s steamApps) GetAppOwner() CSteamID {
+	v, err := theLib.call(funcType_Int64_Ptr, flatAPI_ISteamApps_GetAppOwner, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return CSteamID(v)
+}
+
+func (s steamApps) GetLaunchQueryParam(key string) string {
+	cKey := C.CString(key)
+	defer C.free(unsafe.Pointer(cKey))
+	v, err := theLib.call(funcType_Ptr_Ptr_Ptr, flatAPI_ISteamApps_GetLaunchQueryParam, uintptr(s), unsafe.Pointer(cKey))
+	if err != nil {
+		handleError(err)
+		return ""
+	}
+	return C.GoString(C.uintptrToChar(C.uintptr_t(v)))
+}
+
+func (s steamApps) GetLaunchCommandLine() string {
+	var buf [4096]byte
+	v, err := theLib.call(funcType_Int32_Ptr_Ptr_Int32, flatAPI_ISteamApps_GetLaunchCommandLine, uintptr(s), unsafe.Pointer(&buf[0]), uintptr(len(buf)))
+	if err != nil {
+		handleError(err)
+		return ""
+	}
+	if int32(v) <= 0 {
+		return ""
+	}
+	return cBufferToString(buf[:])
+}
+
+func (s steamApps) InstallDLC(appID AppId_t) {
+	if _, err := theLib.call(funcType_Void_Ptr_Int32, flatAPI_ISteamApps_InstallDLC, uintptr(s), uintptr(appID)); err != nil {
+		handleError(err)
+	}
+}
+
+func (s steamApps) UninstallDLC(appID AppId_t) {
+	if _, err := theLib.call(funcType_Void_Ptr_Int32, flatAPI_ISteamApps_UninstallDLC, uintptr(s), uintptr(appID)); err != nil {
+		handleError(err)
+	}
+}
+
+func (s steamApps) GetDlcDownloadProgress(appID AppId_t) (downloaded, total uint64, ok bool) {
+	v, err := theLib.call(funcType_Bool_Ptr_Int32_Ptr_Ptr, flatAPI_ISteamApps_GetDlcDownloadProgress, uintptr(s), uintptr(appID), unsafe.Pointer(&downloaded), unsafe.Pointer(&total))
+	if err != nil {
+		handleError(err)
+		return 0, 0, false
+	}
+	if byte(v) == 0 {
+		return 0, 0, false
+	}
+	return downloaded, total, true
+}
+
+func (s steamApps) RequestAppProofOfPurchaseKey(appID AppId_t) {
+	if _, err := theLib.call(funcType_Void_Ptr_Int32, flatAPI_ISteamApps_RequestAppProofOfPurchaseKey, uintptr(s), uintptr(appID)); err != nil {
+		handleError(err)
+	}
+}
+
+func SteamFriends() ISteamFriends {
+	v, err := theLib.call(funcType_Ptr, flagAPI_SteamFriends)
+	if err != nil {
+		handleError(err)
+		return steamFriends(0)
+	}
+	return steamFriends(v)
+}
+
+type steamFriends C.uintptr_t
+
+func (s steamFriends) GetPersonaName() string {
+	v, err := theLib.call(funcType_Ptr_Ptr, flatAPI_ISteamFriends_GetPersonaName, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return ""
+	}
+	return C.GoString(C.uintptrToChar(C.uintptr_t(v)))
+}
+
+func (s steamFriends) SetRichPresence(key, value string) bool {
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	cvalue := C.CString(value)
+	defer C.free(unsafe.Pointer(cvalue))
+
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr_Ptr, flatAPI_ISteamFriends_SetRichPresence, uintptr(s), unsafe.Pointer(ckey), unsafe.Pointer(cvalue))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+
+	return byte(v) != 0
+}
+
+func (s steamFriends) ActivateGameOverlayToStore(appID uint32, flag EOverlayToStoreFlag) {
+	if _, err := theLib.call(funcType_Void_Ptr_Int32_Int32, flatAPI_ISteamFriends_ActivateGameOverlayToStore, uintptr(s), uintptr(appID), uintptr(flag)); err != nil {
+		handleError(err)
+	}
+}
+
+func (s steamFriends) ActivateGameOverlay(dialog OverlayDialog) {
+	cDialog := C.CString(string(dialog))
+	defer C.free(unsafe.Pointer(cDialog))
+	if _, err := theLib.call(funcType_Void_Ptr_Ptr, flatAPI_ISteamFriends_ActivateGameOverlay, uintptr(s), unsafe.Pointer(cDialog)); err != nil {
+		handleError(err)
+	}
+}
+
+func (s steamFriends) ActivateGameOverlayToUser(dialog OverlayUserDialog, user CSteamID) {
+	cDialog := C.CString(string(dialog))
+	defer C.free(unsafe.Pointer(cDialog))
+	if _, err := theLib.call(funcType_Void_Ptr_Ptr_Int64, flatAPI_ISteamFriends_ActivateGameOverlayToUser, uintptr(s), unsafe.Pointer(cDialog), user); err != nil {
+		handleError(err)
+	}
+}
+
+func (s steamFriends) ActivateGameOverlayToWebPage(url string, mode EActivateGameOverlayToWebPageMode) {
+	cURL := C.CString(url)
+	defer C.free(unsafe.Pointer(cURL))
+	if _, err := theLib.call(funcType_Void_Ptr_Ptr_Int32, flatAPI_ISteamFriends_ActivateGameOverlayToWebPage, uintptr(s), unsafe.Pointer(cURL), uintptr(mode)); err != nil {
+		handleError(err)
+	}
+}
+
+func (s steamFriends) ActivateGameOverlayInviteDialog(lobby CSteamID) {
+	if _, err := theLib.call(funcType_Void_Ptr_Int64, flatAPI_ISteamFriends_ActivateGameOverlayInviteDialog, uintptr(s), lobby); err != nil {
+		handleError(err)
+	}
+}
+
+func (s steamFriends) GetFriendCount(flags EFriendFlags) int {
+	v, err := theLib.call(funcType_Int32_Ptr_Int32, flatAPI_ISteamFriends_GetFriendCount, uintptr(s), uintptr(flags))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return int(int32(v))
+}
+
+func (s steamFriends) GetFriendByIndex(index int, flags EFriendFlags) CSteamID {
+	v, err := theLib.call(funcType_Int64_Ptr_Int32_Int32, flatAPI_ISteamFriends_GetFriendByIndex, uintptr(s), uintptr(index), uintptr(flags))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return CSteamID(v)
+}
+
+func (s steamFriends) GetFriendPersonaName(friend CSteamID) string {
+	v, err := theLib.call(funcType_Ptr_Ptr_Int64, flatAPI_ISteamFriends_GetFriendPersonaName, uintptr(s), friend)
+	if err != nil {
+		handleError(err)
+		return ""
+	}
+	return C.GoString(C.uintptrToChar(C.uintptr_t(v)))
+}
+
+func (s steamFriends) GetFriendPersonaState(friend CSteamID) EPersonaState {
+	v, err := theLib.call(funcType_Int32_Ptr_Int64, flatAPI_ISteamFriends_GetFriendPersonaState, uintptr(s), friend)
+	if err != nil {
+		handleError(err)
+		return EPersonaState_Offline
+	}
+	return EPersonaState(v)
+}
+
+func (s steamFriends) GetFriendGamePlayed(friend CSteamID) (info FriendGameInfo_t, ok bool) {
+	infoC := info.CStruct()
+	v, err := theLib.call(funcType_Bool_Ptr_Int64_Ptr, flatAPI_ISteamFriends_GetFriendGamePlayed, uintptr(s), friend, unsafe.Pointer(&infoC))
+	if err != nil {
+		handleError(err)
+		return info, false
+	}
+	if byte(v) == 0 {
+		return info, false
+	}
+	return info.FromCStruct(infoC), true
+}
+
+func (s steamFriends) GetFriendRelationship(friend CSteamID) EFriendRelationship {
+	v, err := theLib.call(funcType_Int32_Ptr_Int64, flatAPI_ISteamFriends_GetFriendRelationship, uintptr(s), friend)
+	if err != nil {
+		handleError(err)
+		return EFriendRelationship_None
+	}
+	return EFriendRelationship(v)
+}
+
+func (s steamFriends) avatar(name string, friend CSteamID) int32 {
+	v, err := theLib.call(funcType_Int32_Ptr_Int64, name, uintptr(s), friend)
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return int32(v)
+}
+
+func (s steamFriends) GetSmallFriendAvatar(friend CSteamID) int32 {
+	return s.avatar(flatAPI_ISteamFriends_GetSmallFriendAvatar, friend)
+}
+
+func (s steamFriends) GetMediumFriendAvatar(friend CSteamID) int32 {
+	return s.avatar(flatAPI_ISteamFriends_GetMediumFriendAvatar, friend)
+}
+
+func (s steamFriends) GetLargeFriendAvatar(friend CSteamID) int32 {
+	return s.avatar(flatAPI_ISteamFriends_GetLargeFriendAvatar, friend)
+}
+
+func (s steamFriends) RequestUserInformation(user CSteamID, requireNameOnly bool) bool {
+	v, err := theLib.call(funcType_Bool_Ptr_Int64_Bool, flatAPI_ISteamFriends_RequestUserInformation, uintptr(s), user, cBool(requireNameOnly))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func SteamInput() ISteamInput {
+	v, err := theLib.call(funcType_Ptr, flatAPI_SteamInput)
+	if err != nil {
+		handleError(err)
+		return steamInput(0)
+	}
+	return steamInput(v)
+}
+
+type steamInput C.uintptr_t
+
+func (s steamInput) GetConnectedControllers() []InputHandle_t {
+	var handles [_STEAM_INPUT_MAX_COUNT]InputHandle_t
+	v, err := theLib.call(funcType_Int32_Ptr_Ptr, flatAPI_ISteamInput_GetConnectedControllers, uintptr(s), unsafe.Pointer(&handles[0]))
+	if err != nil {
+		handleError(err)
+		return nil
+	}
+	return handles[:int(v)]
+}
+
+func (s steamInput) GetInputTypeForHandle(inputHandle InputHandle_t) ESteamInputType {
+	v, err := theLib.call(funcType_Int32_Ptr_Int64, flatAPI_ISteamInput_GetInputTypeForHandle, uintptr(s), inputHandle)
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return ESteamInputType(v)
+}
+
+func (s steamInput) Init(bExplicitlyCallRunFrame bool) bool {
+	var callRunFrame uintptr
+	if bExplicitlyCallRunFrame {
+		callRunFrame = 1
+	}
+	v, err := theLib.call(funcType_Bool_Ptr_Bool, flatAPI_ISteamInput_Init, uintptr(s), callRunFrame)
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamInput) RunFrame() {
+	if _, err := theLib.call(funcType_Void_Ptr_Bool, flatAPI_ISteamInput_RunFrame, uintptr(s), 0); err != nil {
+		handleError(err)
+	}
+}
+
+func SteamRemoteStorage() ISteamRemoteStorage {
+	v, err := theLib.call(funcType_Ptr, flatAPI_SteamRemoteStorage)
+	if err != nil {
+		handleError(err)
+		return steamRemoteStorage(0)
+	}
+	return steamRemoteStorage(v)
 }
 
 type steamRemoteStorage C.uintptr_t
 
-func (s steamRemoteStorage) FileWrite(file string, data []byte) bool {
-	cfile := C.CString(file)
-	defer C.free(unsafe.Pointer(cfile))
+func (s steamRemoteStorage) FileWrite(file string, data []byte) bool {
+	cfile := C.CString(file)
+	defer C.free(unsafe.Pointer(cfile))
+
+	// Steam wants a valid pointer even for an empty file.
+	buf := data
+	if len(buf) == 0 {
+		buf = make([]byte, 1)
+	}
+	defer runtime.KeepAlive(buf)
+
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr_Ptr_Int32, flatAPI_ISteamRemoteStorage_FileWrite, uintptr(s), unsafe.Pointer(cfile), unsafe.Pointer(&buf[0]), uintptr(len(data)))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamRemoteStorage) FileRead(file string, data []byte) int32 {
+	cfile := C.CString(file)
+	defer C.free(unsafe.Pointer(cfile))
+
+	// Steam wants a valid pointer even for an empty buffer.
+	buf := data
+	if len(buf) == 0 {
+		buf = make([]byte, 1)
+	}
+	defer runtime.KeepAlive(buf)
+
+	v, err := theLib.call(funcType_Int32_Ptr_Ptr_Ptr_Int32, flatAPI_ISteamRemoteStorage_FileRead, uintptr(s), unsafe.Pointer(cfile), unsafe.Pointer(&buf[0]), uintptr(len(data)))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return int32(v)
+}
+
+func (s steamRemoteStorage) FileDelete(file string) bool {
+	cfile := C.CString(file)
+	defer C.free(unsafe.Pointer(cfile))
+
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr, flatAPI_ISteamRemoteStorage_FileDelete, uintptr(s), unsafe.Pointer(cfile))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamRemoteStorage) GetFileSize(file string) int32 {
+	cfile := C.CString(file)
+	defer C.free(unsafe.Pointer(cfile))
+
+	v, err := theLib.call(funcType_Int32_Ptr, flatAPI_ISteamRemoteStorage_GetFileSize, uintptr(s), unsafe.Pointer(cfile))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return int32(v)
+}
+
+func (s steamRemoteStorage) BeginFileWriteBatch() bool {
+	v, err := theLib.call(funcType_Bool_Ptr, flatAPI_ISteamRemoteStorage_BeginFileWriteBatch, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamRemoteStorage) EndFileWriteBatch() bool {
+	v, err := theLib.call(funcType_Bool_Ptr, flatAPI_ISteamRemoteStorage_EndFileWriteBatch, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamRemoteStorage) FileWriteStreamOpen(file string) UGCFileWriteStreamHandle_t {
+	cfile := C.CString(file)
+	defer C.free(unsafe.Pointer(cfile))
+
+	v, err := theLib.call(funcType_Int64_Ptr_Ptr, flatAPI_ISteamRemoteStorage_FileWriteStreamOpen, uintptr(s), unsafe.Pointer(cfile))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return UGCFileWriteStreamHandle_t(v)
+}
+
+func (s steamRemoteStorage) FileWriteStreamWriteChunk(stream UGCFileWriteStreamHandle_t, data []byte) bool {
+	if len(data) == 0 {
+		return true
+	}
+	defer runtime.KeepAlive(data)
+
+	v, err := theLib.call(funcType_Bool_Ptr_Int64_Ptr_Int32, flatAPI_ISteamRemoteStorage_FileWriteStreamWriteChunk, uintptr(s), stream, unsafe.Pointer(&data[0]), uintptr(len(data)))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamRemoteStorage) FileWriteStreamClose(stream UGCFileWriteStreamHandle_t) bool {
+	v, err := theLib.call(funcType_Bool_Ptr_Int64, flatAPI_ISteamRemoteStorage_FileWriteStreamClose, uintptr(s), stream)
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamRemoteStorage) FileWriteStreamCancel(stream UGCFileWriteStreamHandle_t) bool {
+	v, err := theLib.call(funcType_Bool_Ptr_Int64, flatAPI_ISteamRemoteStorage_FileWriteStreamCancel, uintptr(s), stream)
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamRemoteStorage) FileReadAsync(file string, offset, size uint32) SteamAPICall_t {
+	cfile := C.CString(file)
+	defer C.free(unsafe.Pointer(cfile))
+
+	v, err := theLib.call(funcType_Int64_Ptr_Ptr_Int32_Int32, flatAPI_ISteamRemoteStorage_FileReadAsync, uintptr(s), unsafe.Pointer(cfile), uintptr(offset), uintptr(size))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return SteamAPICall_t(v)
+}
+
+func (s steamRemoteStorage) FileReadAsyncComplete(call SteamAPICall_t, data []byte) bool {
+	if len(data) == 0 {
+		return true
+	}
+	defer runtime.KeepAlive(data)
+
+	v, err := theLib.call(funcType_Bool_Ptr_Int64_Ptr_Int32, flatAPI_ISteamRemoteStorage_FileReadAsyncComplete, uintptr(s), call, unsafe.Pointer(&data[0]), uintptr(len(data)))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamRemoteStorage) FileWriteAsync(file string, data []byte) SteamAPICall_t {
+	cfile := C.CString(file)
+	defer C.free(unsafe.Pointer(cfile))
+
+	buf := data
+	if len(buf) == 0 {
+		buf = make([]byte, 1)
+	}
+	defer runtime.KeepAlive(buf)
+
+	v, err := theLib.call(funcType_Int64_Ptr_Ptr_Ptr_Int32, flatAPI_ISteamRemoteStorage_FileWriteAsync, uintptr(s), unsafe.Pointer(cfile), unsafe.Pointer(&buf[0]), uintptr(len(data)))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return SteamAPICall_t(v)
+}
+
+func (s steamRemoteStorage) FileExists(file string) bool {
+	cfile := C.CString(file)
+	defer C.free(unsafe.Pointer(cfile))
+
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr, flatAPI_ISteamRemoteStorage_FileExists, uintptr(s), unsafe.Pointer(cfile))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamRemoteStorage) FilePersisted(file string) bool {
+	cfile := C.CString(file)
+	defer C.free(unsafe.Pointer(cfile))
+
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr, flatAPI_ISteamRemoteStorage_FilePersisted, uintptr(s), unsafe.Pointer(cfile))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamRemoteStorage) GetFileTimestamp(file string) time.Time {
+	cfile := C.CString(file)
+	defer C.free(unsafe.Pointer(cfile))
+
+	v, err := theLib.call(funcType_Int64_Ptr_Ptr, flatAPI_ISteamRemoteStorage_GetFileTimestamp, uintptr(s), unsafe.Pointer(cfile))
+	if err != nil {
+		handleError(err)
+		return time.Time{}
+	}
+	return unixTime(int64(v))
+}
+
+func (s steamRemoteStorage) FileForget(file string) bool {
+	cfile := C.CString(file)
+	defer C.free(unsafe.Pointer(cfile))
+
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr, flatAPI_ISteamRemoteStorage_FileForget, uintptr(s), unsafe.Pointer(cfile))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamRemoteStorage) SetSyncPlatforms(file string, platforms ERemoteStoragePlatform) bool {
+	cfile := C.CString(file)
+	defer C.free(unsafe.Pointer(cfile))
+
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr_Int32, flatAPI_ISteamRemoteStorage_SetSyncPlatforms, uintptr(s), unsafe.Pointer(cfile), uintptr(platforms))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamRemoteStorage) GetFileCount() int32 {
+	v, err := theLib.call(funcType_Int32_Ptr, flatAPI_ISteamRemoteStorage_GetFileCount, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return int32(v)
+}
+
+func (s steamRemoteStorage) GetFileNameAndSize(index int32) (name string, size int32) {
+	v, err := theLib.call(funcType_Ptr_Ptr_Int32_Ptr, flatAPI_ISteamRemoteStorage_GetFileNameAndSize, uintptr(s), uintptr(index), unsafe.Pointer(&size))
+	if err != nil {
+		handleError(err)
+		return "", 0
+	}
+	if v == 0 {
+		return "", 0
+	}
+	return C.GoString(C.uintptrToChar(C.uintptr_t(v))), size
+}
+
+func (s steamRemoteStorage) GetQuota() (totalBytes, availableBytes uint64, success bool) {
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr_Ptr, flatAPI_ISteamRemoteStorage_GetQuota, uintptr(s), unsafe.Pointer(&totalBytes), unsafe.Pointer(&availableBytes))
+	if err != nil {
+		handleError(err)
+		return 0, 0, false
+	}
+	return totalBytes, availableBytes, byte(v) != 0
+}
+
+func (s steamRemoteStorage) IsCloudEnabledForAccount() bool {
+	v, err := theLib.call(funcType_Bool_Ptr, flatAPI_ISteamRemoteStorage_IsCloudEnabledForAccount, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamRemoteStorage) IsCloudEnabledForApp() bool {
+	v, err := theLib.call(funcType_Bool_Ptr, flatAPI_ISteamRemoteStorage_IsCloudEnabledForApp, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamRemoteStorage) SetCloudEnabledForApp(enabled bool) {
+	if _, err := theLib.call(funcType_Void_Ptr_Bool, flatAPI_ISteamRemoteStorage_SetCloudEnabledForApp, uintptr(s), cBool(enabled)); err != nil {
+		handleError(err)
+	}
+}
+
+func (s steamRemoteStorage) FileShare(file string) SteamAPICall_t {
+	cfile := C.CString(file)
+	defer C.free(unsafe.Pointer(cfile))
+
+	v, err := theLib.call(funcType_Int64_Ptr_Ptr, flatAPI_ISteamRemoteStorage_FileShare, uintptr(s), unsafe.Pointer(cfile))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return SteamAPICall_t(v)
+}
+
+func (s steamRemoteStorage) UGCDownload(ugc UGCHandle_t, priority uint32) SteamAPICall_t {
+	v, err := theLib.call(funcType_Int64_Ptr_Int64_Int32, flatAPI_ISteamRemoteStorage_UGCDownload, uintptr(s), ugc, uintptr(priority))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return SteamAPICall_t(v)
+}
+
+func (s steamRemoteStorage) UGCRead(ugc UGCHandle_t, data []byte, offset uint32, action EUGCReadAction) int32 {
+	if len(data) == 0 {
+		return 0
+	}
+	defer runtime.KeepAlive(data)
+
+	v, err := theLib.call(funcType_Int32_Ptr_Int64_Ptr_Int32_Int32_Int32, flatAPI_ISteamRemoteStorage_UGCRead, uintptr(s), ugc, unsafe.Pointer(&data[0]), uintptr(len(data)), uintptr(offset), uintptr(action))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return int32(v)
+}
+
+func SteamUGC() ISteamUGC {
+	v, err := theLib.call(funcType_Ptr, flatAPI_SteamUGC)
+	if err != nil {
+		handleError(err)
+		return steamUGC(0)
+	}
+	return steamUGC(v)
+}
+
+type steamUGC C.uintptr_t
+
+func (s steamUGC) CreateQueryUserUGCRequest(account AccountID_t, listType EUserUGCList, matchingType EUGCMatchingUGCType, sortOrder EUserUGCListSortOrder, creatorAppID, consumerAppID AppId_t, page uint32) UGCQueryHandle_t {
+	v, err := theLib.call(funcType_Int64_Ptr_Int32_Int32_Int32_Int32_Int32_Int32_Int32, flatAPI_ISteamUGC_CreateQueryUserUGCRequest, uintptr(s), uintptr(account), uintptr(listType), uintptr(matchingType), uintptr(sortOrder), uintptr(creatorAppID), uintptr(consumerAppID), uintptr(page))
+	if err != nil {
+		handleError(err)
+		return ugcQueryHandleInvalid
+	}
+	return UGCQueryHandle_t(v)
+}
+
+func (s steamUGC) CreateQueryAllUGCRequest(queryType EUGCQuery, matchingType EUGCMatchingUGCType, creatorAppID, consumerAppID AppId_t, page uint32) UGCQueryHandle_t {
+	v, err := theLib.call(funcType_Int64_Ptr_Int32_Int32_Int32_Int32_Int32, flatAPI_ISteamUGC_CreateQueryAllUGCRequest, uintptr(s), uintptr(queryType), uintptr(matchingType), uintptr(creatorAppID), uintptr(consumerAppID), uintptr(page))
+	if err != nil {
+		handleError(err)
+		return ugcQueryHandleInvalid
+	}
+	return UGCQueryHandle_t(v)
+}
+
+func (s steamUGC) CreateQueryUGCDetailsRequest(ids []PublishedFileId_t) UGCQueryHandle_t {
+	ids = append(ids, 0)
+	defer runtime.KeepAlive(ids)
+
+	v, err := theLib.call(funcType_Int64_Ptr_Ptr_Int32, flatAPI_ISteamUGC_CreateQueryUGCDetailsRequest, uintptr(s), unsafe.Pointer(&ids[0]), uintptr(len(ids)-1))
+	if err != nil {
+		handleError(err)
+		return ugcQueryHandleInvalid
+	}
+	return UGCQueryHandle_t(v)
+}
+
+func (s steamUGC) AddRequiredTag(query UGCQueryHandle_t, tag string) bool {
+	return s.callString(flatAPI_ISteamUGC_AddRequiredTag, uint64(query), tag)
+}
+
+func (s steamUGC) AddExcludedTag(query UGCQueryHandle_t, tag string) bool {
+	return s.callString(flatAPI_ISteamUGC_AddExcludedTag, uint64(query), tag)
+}
+
+func (s steamUGC) SetSearchText(query UGCQueryHandle_t, text string) bool {
+	return s.callString(flatAPI_ISteamUGC_SetSearchText, uint64(query), text)
+}
+
+func (s steamUGC) SetLanguage(query UGCQueryHandle_t, language string) bool {
+	return s.callString(flatAPI_ISteamUGC_SetLanguage, uint64(query), language)
+}
+
+// callString calls a query or update function that takes a string.
+func (s steamUGC) callString(name string, handle uint64, str string) bool {
+	cstr := C.CString(str)
+	defer C.free(unsafe.Pointer(cstr))
+
+	v, err := theLib.call(funcType_Bool_Ptr_Int64_Ptr, name, uintptr(s), handle, unsafe.Pointer(cstr))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUGC) SetMatchAnyTag(query UGCQueryHandle_t, matchAnyTag bool) bool {
+	v, err := theLib.call(funcType_Bool_Ptr_Int64_Bool, flatAPI_ISteamUGC_SetMatchAnyTag, uintptr(s), query, cBool(matchAnyTag))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUGC) SetRankedByTrendDays(query UGCQueryHandle_t, days uint32) bool {
+	v, err := theLib.call(funcType_Bool_Ptr_Int64_Int32, flatAPI_ISteamUGC_SetRankedByTrendDays, uintptr(s), query, uintptr(days))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUGC) SetReturnLongDescription(query UGCQueryHandle_t, returnLongDescription bool) bool {
+	v, err := theLib.call(funcType_Bool_Ptr_Int64_Bool, flatAPI_ISteamUGC_SetReturnLongDescription, uintptr(s), query, cBool(returnLongDescription))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUGC) SetReturnMetadata(query UGCQueryHandle_t, returnMetadata bool) bool {
+	v, err := theLib.call(funcType_Bool_Ptr_Int64_Bool, flatAPI_ISteamUGC_SetReturnMetadata, uintptr(s), query, cBool(returnMetadata))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUGC) SetAllowCachedResponse(query UGCQueryHandle_t, maxAgeSeconds uint32) bool {
+	v, err := theLib.call(funcType_Bool_Ptr_Int64_Int32, flatAPI_ISteamUGC_SetAllowCachedResponse, uintptr(s), query, uintptr(maxAgeSeconds))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUGC) SendQueryUGCRequest(query UGCQueryHandle_t) SteamAPICall_t {
+	v, err := theLib.call(funcType_Int64_Ptr_Int64, flatAPI_ISteamUGC_SendQueryUGCRequest, uintptr(s), query)
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return SteamAPICall_t(v)
+}
+
+func (s steamUGC) GetQueryUGCResult(query UGCQueryHandle_t, index uint32) (details SteamUGCDetails_t, success bool) {
+	detailsC := details.CStruct()
+	v, err := theLib.call(funcType_Bool_Ptr_Int64_Int32_Ptr, flatAPI_ISteamUGC_GetQueryUGCResult, uintptr(s), query, uintptr(index), unsafe.Pointer(&detailsC))
+	if err != nil {
+		handleError(err)
+		return details, false
+	}
+	return details.FromCStruct(detailsC), byte(v) != 0
+}
+
+func (s steamUGC) GetQueryUGCPreviewURL(query UGCQueryHandle_t, index uint32) string {
+	return s.queryString(flatAPI_ISteamUGC_GetQueryUGCPreviewURL, query, index, 1024)
+}
+
+func (s steamUGC) GetQueryUGCMetadata(query UGCQueryHandle_t, index uint32) string {
+	return s.queryString(flatAPI_ISteamUGC_GetQueryUGCMetadata, query, index, ugcMetadataMax)
+}
+
+// queryString calls a query function that copies a string of up to size bytes out.
+func (s steamUGC) queryString(name string, query UGCQueryHandle_t, index uint32, size int) string {
+	buf := make([]byte, size)
+	defer runtime.KeepAlive(buf)
+
+	v, err := theLib.call(funcType_Bool_Ptr_Int64_Int32_Ptr_Int32, name, uintptr(s), query, uintptr(index), unsafe.Pointer(&buf[0]), uintptr(len(buf)))
+	if err != nil {
+		handleError(err)
+		return ""
+	}
+	if byte(v) == 0 {
+		return ""
+	}
+	return cBufferToString(buf)
+}
+
+func (s steamUGC) GetQueryUGCStatistic(query UGCQueryHandle_t, index uint32, statistic EItemStatistic) (value uint64, success bool) {
+	v, err := theLib.call(funcType_Bool_Ptr_Int64_Int32_Int32_Ptr, flatAPI_ISteamUGC_GetQueryUGCStatistic, uintptr(s), query, uintptr(index), uintptr(statistic), unsafe.Pointer(&value))
+	if err != nil {
+		handleError(err)
+		return 0, false
+	}
+	return value, byte(v) != 0
+}
+
+func (s steamUGC) ReleaseQueryUGCRequest(query UGCQueryHandle_t) bool {
+	v, err := theLib.call(funcType_Bool_Ptr_Int64, flatAPI_ISteamUGC_ReleaseQueryUGCRequest, uintptr(s), query)
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUGC) SubscribeItem(id PublishedFileId_t) SteamAPICall_t {
+	v, err := theLib.call(funcType_Int64_Ptr_Int64, flatAPI_ISteamUGC_SubscribeItem, uintptr(s), id)
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return SteamAPICall_t(v)
+}
+
+func (s steamUGC) UnsubscribeItem(id PublishedFileId_t) SteamAPICall_t {
+	v, err := theLib.call(funcType_Int64_Ptr_Int64, flatAPI_ISteamUGC_UnsubscribeItem, uintptr(s), id)
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return SteamAPICall_t(v)
+}
+
+// GetNumSubscribedItems and GetSubscribedItems pass false for bIncludeLocallyDisabled,
+// which libraries from before it was added ignore.
+func (s steamUGC) GetNumSubscribedItems() uint32 {
+	v, err := theLib.call(funcType_Int32_Ptr_Bool, flatAPI_ISteamUGC_GetNumSubscribedItems, uintptr(s), cBool(false))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return uint32(v)
+}
+
+func (s steamUGC) GetSubscribedItems() []PublishedFileId_t {
+	n := s.GetNumSubscribedItems()
+	if n == 0 {
+		return nil
+	}
+	ids := make([]PublishedFileId_t, n)
+	defer runtime.KeepAlive(ids)
+
+	v, err := theLib.call(funcType_Int32_Ptr_Ptr_Int32_Bool, flatAPI_ISteamUGC_GetSubscribedItems, uintptr(s), unsafe.Pointer(&ids[0]), uintptr(len(ids)), cBool(false))
+	if err != nil {
+		handleError(err)
+		return nil
+	}
+	return ids[:min(uint32(v), n)]
+}
+
+func (s steamUGC) GetItemState(id PublishedFileId_t) EItemState {
+	v, err := theLib.call(funcType_Int32_Ptr_Int64, flatAPI_ISteamUGC_GetItemState, uintptr(s), id)
+	if err != nil {
+		handleError(err)
+		return EItemState_None
+	}
+	return EItemState(v)
+}
+
+func (s steamUGC) GetItemInstallInfo(id PublishedFileId_t) (sizeOnDisk uint64, folder string, timestamp time.Time, success bool) {
+	var path [4096]byte
+	var t uint32
+	v, err := theLib.call(funcType_Bool_Ptr_Int64_Ptr_Ptr_Int32_Ptr, flatAPI_ISteamUGC_GetItemInstallInfo, uintptr(s), id, unsafe.Pointer(&sizeOnDisk), unsafe.Pointer(&path[0]), uintptr(len(path)), unsafe.Pointer(&t))
+	if err != nil {
+		handleError(err)
+		return 0, "", time.Time{}, false
+	}
+	if byte(v) == 0 {
+		return 0, "", time.Time{}, false
+	}
+	return sizeOnDisk, cBufferToString(path[:]), unixTime(int64(t)), true
+}
+
+func (s steamUGC) GetItemDownloadInfo(id PublishedFileId_t) (downloaded, total uint64, success bool) {
+	v, err := theLib.call(funcType_Bool_Ptr_Int64_Ptr_Ptr, flatAPI_ISteamUGC_GetItemDownloadInfo, uintptr(s), id, unsafe.Pointer(&downloaded), unsafe.Pointer(&total))
+	if err != nil {
+		handleError(err)
+		return 0, 0, false
+	}
+	return downloaded, total, byte(v) != 0
+}
+
+func (s steamUGC) DownloadItem(id PublishedFileId_t, highPriority bool) bool {
+	v, err := theLib.call(funcType_Bool_Ptr_Int64_Bool, flatAPI_ISteamUGC_DownloadItem, uintptr(s), id, cBool(highPriority))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUGC) CreateItem(consumerAppID AppId_t, fileType EWorkshopFileType) SteamAPICall_t {
+	v, err := theLib.call(funcType_Int64_Ptr_Int32_Int32, flatAPI_ISteamUGC_CreateItem, uintptr(s), uintptr(consumerAppID), uintptr(fileType))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return SteamAPICall_t(v)
+}
+
+func (s steamUGC) StartItemUpdate(consumerAppID AppId_t, id PublishedFileId_t) UGCUpdateHandle_t {
+	v, err := theLib.call(funcType_Int64_Ptr_Int32_Int64, flatAPI_ISteamUGC_StartItemUpdate, uintptr(s), uintptr(consumerAppID), id)
+	if err != nil {
+		handleError(err)
+		return ugcUpdateHandleInvalid
+	}
+	return UGCUpdateHandle_t(v)
+}
+
+func (s steamUGC) SetItemTitle(update UGCUpdateHandle_t, title string) bool {
+	return s.callString(flatAPI_ISteamUGC_SetItemTitle, uint64(update), title)
+}
+
+func (s steamUGC) SetItemDescription(update UGCUpdateHandle_t, description string) bool {
+	return s.callString(flatAPI_ISteamUGC_SetItemDescription, uint64(update), description)
+}
+
+func (s steamUGC) SetItemContent(update UGCUpdateHandle_t, folder string) bool {
+	return s.callString(flatAPI_ISteamUGC_SetItemContent, uint64(update), folder)
+}
+
+func (s steamUGC) SetItemPreview(update UGCUpdateHandle_t, file string) bool {
+	return s.callString(flatAPI_ISteamUGC_SetItemPreview, uint64(update), file)
+}
+
+// SetItemTags passes false for bAllowAdminTags, which libraries from before it was added ignore.
+func (s steamUGC) SetItemTags(update UGCUpdateHandle_t, tags []string) bool {
+	ctags := C.malloc(C.size_t(max(len(tags), 1)) * C.size_t(unsafe.Sizeof(uintptr(0))))
+	defer C.free(ctags)
+	ptrs := unsafe.Slice((**C.char)(ctags), len(tags))
+	for i, tag := range tags {
+		ptrs[i] = C.CString(tag)
+	}
+	defer func() {
+		for _, p := range ptrs {
+			C.free(unsafe.Pointer(p))
+		}
+	}()
+
+	array := steamParamStringArray{strings: uintptr(ctags), num: int32(len(tags))}
+	v, err := theLib.call(funcType_Bool_Ptr_Int64_Ptr_Bool, flatAPI_ISteamUGC_SetItemTags, uintptr(s), update, unsafe.Pointer(&array), cBool(false))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUGC) SetItemVisibility(update UGCUpdateHandle_t, visibility ERemoteStoragePublishedFileVisibility) bool {
+	v, err := theLib.call(funcType_Bool_Ptr_Int64_Int32, flatAPI_ISteamUGC_SetItemVisibility, uintptr(s), update, uintptr(visibility))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUGC) SubmitItemUpdate(update UGCUpdateHandle_t, changeNote string) SteamAPICall_t {
+	cchangeNote := C.CString(changeNote)
+	defer C.free(unsafe.Pointer(cchangeNote))
+
+	v, err := theLib.call(funcType_Int64_Ptr_Int64_Ptr, flatAPI_ISteamUGC_SubmitItemUpdate, uintptr(s), update, unsafe.Pointer(cchangeNote))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return SteamAPICall_t(v)
+}
+
+func (s steamUGC) GetItemUpdateProgress(update UGCUpdateHandle_t) (status EItemUpdateStatus, bytesProcessed, bytesTotal uint64) {
+	v, err := theLib.call(funcType_Int32_Ptr_Int64_Ptr_Ptr, flatAPI_ISteamUGC_GetItemUpdateProgress, uintptr(s), update, unsafe.Pointer(&bytesProcessed), unsafe.Pointer(&bytesTotal))
+	if err != nil {
+		handleError(err)
+		return EItemUpdateStatus_Invalid, 0, 0
+	}
+	return EItemUpdateStatus(v), bytesProcessed, bytesTotal
+}
+
+func SteamUser() ISteamUser {
+	v, err := theLib.call(funcType_Ptr, flatAPI_SteamUser)
+	if err != nil {
+		handleError(err)
+		return steamUser(0)
+	}
+	return steamUser(v)
+}
+
+type steamUser C.uintptr_t
+
+func (s steamUser) GetSteamID() CSteamID {
+	v, err := theLib.call(funcType_Int64_Ptr, flatAPI_ISteamUser_GetSteamID, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return CSteamID(v)
+}
+
+func SteamUserStats() ISteamUserStats {
+	v, err := theLib.call(funcType_Ptr, flatAPI_SteamUserStats)
+	if err != nil {
+		handleError(err)
+		return steamUserStats(0)
+	}
+	return steamUserStats(v)
+}
+
+type steamUserStats C.uintptr_t
+
+func (s steamUserStats) RequestCurrentStats() SteamAPICall_t {
+	return s.RequestUserStats(SteamUser().GetSteamID())
+}
+
+func (s steamUserStats) RequestUserStats(steamID CSteamID) SteamAPICall_t {
+	v, err := theLib.call(funcType_Int64_Ptr_Int64, flatAPI_ISteamUserStats_RequestUserStats, uintptr(s), steamID)
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+
+	return SteamAPICall_t(v)
+}
+
+func (s steamUserStats) requestGlobalStats(historDays int) SteamAPICall_t {
+	v, err := theLib.call(funcType_Int64_Ptr_Int32, flatAPI_ISteamUserStats_RequestGlobalStats, uintptr(s), uintptr(historDays))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+
+	return SteamAPICall_t(v)
+}
+
+func (s steamUserStats) getglobalStats(name string) (statCount int, success bool) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	var data int64
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr_Ptr, flatAPI_ISteamUserStats_GetGlobalStatInt, uintptr(s), unsafe.Pointer(cname), unsafe.Pointer(&data))
+	if err != nil {
+		handleError(err)
+		return
+	}
+
+	return int(data), byte(v) != 0
+}
+
+func (s steamUserStats) GetStatInt32(name string) (value int32, success bool) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr_Ptr, flatAPI_ISteamUserStats_GetStatInt32, uintptr(s), unsafe.Pointer(cname), unsafe.Pointer(&value))
+	if err != nil {
+		handleError(err)
+		return
+	}
+
+	success = byte(v) != 0
+	return
+}
+
+func (s steamUserStats) GetStatFloat(name string) (value float32, success bool) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr_Ptr, flatAPI_ISteamUserStats_GetStatFloat, uintptr(s), unsafe.Pointer(cname), unsafe.Pointer(&value))
+	if err != nil {
+		handleError(err)
+		return
+	}
+
+	success = byte(v) != 0
+	return
+}
+
+func (s steamUserStats) SetStatInt32(name string, value int32) bool {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr_Int32, flatAPI_ISteamUserStats_SetStatInt32, uintptr(s), unsafe.Pointer(cname), uintptr(value))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+
+	return byte(v) != 0
+}
+
+func (s steamUserStats) SetStatFloat(name string, value float32) bool {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr_Float, flatAPI_ISteamUserStats_SetStatFloat, uintptr(s), unsafe.Pointer(cname), uintptr(math.Float32bits(value)))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+
+	return byte(v) != 0
+}
 
-	defer runtime.KeepAlive(data)
+func (s steamUserStats) UpdateAvgRateStat(name string, countThisSession float32, sessionLength float64) bool {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
 
-	v, err := theLib.call(funcType_Bool_Ptr_Ptr_Ptr_Int32, flatAPI_ISteamRemoteStorage_FileWrite, uintptr(s), uintptr(unsafe.Pointer(cfile)), uintptr(unsafe.Pointer(&data[0])), uintptr(len(data)))
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr_Float_Double, flatAPI_ISteamUserStats_UpdateAvgRateStat, uintptr(s), unsafe.Pointer(cname), uintptr(math.Float32bits(countThisSession)), math.Float64bits(sessionLength))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
+
 	return byte(v) != 0
 }
 
-func (s steamRemoteStorage) FileRead(file string, data []byte) int32 {
-	cfile := C.CString(file)
-	defer C.free(unsafe.Pointer(cfile))
+func (s steamUserStats) GetUserStatInt32(steamID CSteamID, name string) (value int32, success bool) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
 
-	defer runtime.KeepAlive(data)
+	v, err := theLib.call(funcType_Bool_Ptr_Int64_Ptr_Ptr, flatAPI_ISteamUserStats_GetUserStatInt32, uintptr(s), steamID, unsafe.Pointer(cname), unsafe.Pointer(&value))
+	if err != nil {
+		handleError(err)
+		return
+	}
+
+	success = byte(v) != 0
+	return
+}
+
+func (s steamUserStats) GetUserStatFloat(steamID CSteamID, name string) (value float32, success bool) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
 
-	v, err := theLib.call(funcType_Int32_Ptr_Ptr_Ptr_Int32, flatAPI_ISteamRemoteStorage_FileRead, uintptr(s), uintptr(unsafe.Pointer(cfile)), uintptr(unsafe.Pointer(&data[0])), uintptr(len(data)))
+	v, err := theLib.call(funcType_Bool_Ptr_Int64_Ptr_Ptr, flatAPI_ISteamUserStats_GetUserStatFloat, uintptr(s), steamID, unsafe.Pointer(cname), unsafe.Pointer(&value))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return
 	}
-	return int32(v)
+
+	success = byte(v) != 0
+	return
 }
 
-func (s steamRemoteStorage) FileDelete(file string) bool {
-	cfile := C.CString(file)
-	defer C.free(unsafe.Pointer(cfile))
+func (s steamUserStats) GetUserAchievement(steamID CSteamID, name string) (achieved, success bool) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	v, err := theLib.call(funcType_Bool_Ptr_Int64_Ptr_Ptr, flatAPI_ISteamUserStats_GetUserAchievement, uintptr(s), steamID, unsafe.Pointer(cname), unsafe.Pointer(&achieved))
+	if err != nil {
+		handleError(err)
+		return
+	}
+
+	success = byte(v) != 0
+	return
+}
 
-	v, err := theLib.call(funcType_Bool_Ptr_Ptr, flatAPI_ISteamRemoteStorage_FileDelete, uintptr(s), uintptr(unsafe.Pointer(cfile)))
+func (s steamUserStats) ResetAllStats(achievementsToo bool) bool {
+	v, err := theLib.call(funcType_Bool_Ptr_Bool, flatAPI_ISteamUserStats_ResetAllStats, uintptr(s), cBool(achievementsToo))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
+
 	return byte(v) != 0
 }
 
-func (s steamRemoteStorage) GetFileSize(file string) int32 {
-	cfile := C.CString(file)
-	defer C.free(unsafe.Pointer(cfile))
+func (s steamUserStats) GetAchievement(name string) (achieved, success bool) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
 
-	v, err := theLib.call(funcType_Int32_Ptr, flatAPI_ISteamRemoteStorage_GetFileSize, uintptr(s), uintptr(unsafe.Pointer(cfile)))
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr_Ptr, flatAPI_ISteamUserStats_GetAchievement, uintptr(s), unsafe.Pointer(cname), unsafe.Pointer(&achieved))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return
 	}
-	return int32(v)
+	success = byte(v) != 0
+
+	return
 }
 
-func SteamUser() ISteamUser {
-	v, err := theLib.call(funcType_Ptr, flatAPI_SteamUser)
+func (s steamUserStats) SetAchievement(name string) bool {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr, flatAPI_ISteamUserStats_SetAchievement, uintptr(s), unsafe.Pointer(cname))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
-	return steamUser(v)
+
+	return byte(v) != 0
 }
 
-type steamUser C.uintptr_t
+func (s steamUserStats) ClearAchievement(name string) bool {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
 
-func (s steamUser) GetSteamID() CSteamID {
-	v, err := theLib.call(funcType_Int64_Ptr, flatAPI_ISteamUser_GetSteamID, uintptr(s))
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr, flatAPI_ISteamUserStats_ClearAchievement, uintptr(s), unsafe.Pointer(cname))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
-	return CSteamID(v)
+
+	return byte(v) != 0
 }
 
-func SteamUserStats() ISteamUserStats {
-	v, err := theLib.call(funcType_Ptr, flatAPI_SteamUserStats)
+func (s steamUserStats) StoreStats() bool {
+	v, err := theLib.call(funcType_Bool_Ptr, flatAPI_ISteamUserStats_StoreStats, uintptr(s))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
-	return steamUserStats(v)
+
+	return byte(v) != 0
 }
 
-type steamUserStats C.uintptr_t
+func (s steamUserStats) GetNumAchievements() uint32 {
+	v, err := theLib.call(funcType_Int32_Ptr, flatAPI_ISteamUserStats_GetNumAchievements, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+
+	return uint32(v)
+}
+
+func (s steamUserStats) GetAchievementName(index uint32) string {
+	v, err := theLib.call(funcType_Ptr_Ptr_Int32, flatAPI_ISteamUserStats_GetAchievementName, uintptr(s), uintptr(index))
+	if err != nil {
+		handleError(err)
+		return ""
+	}
+
+	return C.GoString(C.uintptrToChar(C.uintptr_t(v)))
+}
+
+func (s steamUserStats) GetAchievementDisplayAttribute(name, key string) string {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+
+	v, err := theLib.call(funcType_Ptr_Ptr_Ptr_Ptr, flatAPI_ISteamUserStats_GetAchievementDisplayAttribute, uintptr(s), unsafe.Pointer(cname), unsafe.Pointer(ckey))
+	if err != nil {
+		handleError(err)
+		return ""
+	}
+
+	return C.GoString(C.uintptrToChar(C.uintptr_t(v)))
+}
+
+func (s steamUserStats) GetAchievementAndUnlockTime(name string) (achieved bool, unlockTime time.Time, success bool) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	var t uint32
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr_Ptr_Ptr, flatAPI_ISteamUserStats_GetAchievementAndUnlockTime, uintptr(s), unsafe.Pointer(cname), unsafe.Pointer(&achieved), unsafe.Pointer(&t))
+	if err != nil {
+		handleError(err)
+		return
+	}
 
-func (s steamUserStats) RequestCurrentStats() bool {
-	v, err := theLib.call(funcType_Bool_Ptr, flatAPI_ISteamUserStats_RequestCurrentStats, uintptr(s))
+	return achieved, unixTime(int64(t)), byte(v) != 0
+}
+
+func (s steamUserStats) IndicateAchievementProgress(name string, curProgress, maxProgress uint32) bool {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr_Int32_Int32, flatAPI_ISteamUserStats_IndicateAchievementProgress, uintptr(s), unsafe.Pointer(cname), uintptr(curProgress), uintptr(maxProgress))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
 
 	return byte(v) != 0
 }
 
-func (s steamUserStats) GetAchievement(name string) (achieved, success bool) {
+func (s steamUserStats) GetAchievementProgressLimitsInt32(name string) (minProgress, maxProgress int32, success bool) {
 	cname := C.CString(name)
 	defer C.free(unsafe.Pointer(cname))
 
-	v, err := theLib.call(funcType_Bool_Ptr_Ptr_Ptr, flatAPI_ISteamUserStats_GetAchievement, uintptr(s), uintptr(unsafe.Pointer(cname)), uintptr(unsafe.Pointer(&achieved)))
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr_Ptr_Ptr, flatAPI_ISteamUserStats_GetAchievementProgressLimitsInt32, uintptr(s), unsafe.Pointer(cname), unsafe.Pointer(&minProgress), unsafe.Pointer(&maxProgress))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return
 	}
+
 	success = byte(v) != 0
+	return
+}
+
+func (s steamUserStats) GetAchievementProgressLimitsFloat(name string) (minProgress, maxProgress float32, success bool) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
 
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr_Ptr_Ptr, flatAPI_ISteamUserStats_GetAchievementProgressLimitsFloat, uintptr(s), unsafe.Pointer(cname), unsafe.Pointer(&minProgress), unsafe.Pointer(&maxProgress))
+	if err != nil {
+		handleError(err)
+		return
+	}
+
+	success = byte(v) != 0
 	return
 }
 
-func (s steamUserStats) SetAchievement(name string) bool {
+func (s steamUserStats) RequestGlobalAchievementPercentages() SteamAPICall_t {
+	v, err := theLib.call(funcType_Int64_Ptr, flatAPI_ISteamUserStats_RequestGlobalAchievementPercentages, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+
+	return SteamAPICall_t(v)
+}
+
+func (s steamUserStats) GetAchievementAchievedPercent(name string) (percent float32, success bool) {
 	cname := C.CString(name)
 	defer C.free(unsafe.Pointer(cname))
 
-	v, err := theLib.call(funcType_Bool_Ptr_Ptr, flatAPI_ISteamUserStats_SetAchievement, uintptr(s), uintptr(unsafe.Pointer(cname)))
+	v, err := theLib.call(funcType_Bool_Ptr_Ptr_Ptr, flatAPI_ISteamUserStats_GetAchievementAchievedPercent, uintptr(s), unsafe.Pointer(cname), unsafe.Pointer(&percent))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return
 	}
 
-	return byte(v) != 0
+	success = byte(v) != 0
+	return
 }
 
-func (s steamUserStats) ClearAchievement(name string) bool {
+func (s steamUserStats) GetMostAchievedAchievementInfo() (iterator int32, name string, percent float32, achieved bool) {
+	var buf [achievementNameMax]byte
+	v, err := theLib.call(funcType_Int32_Ptr_Ptr_Int32_Ptr_Ptr, flatAPI_ISteamUserStats_GetMostAchievedAchievementInfo, uintptr(s), unsafe.Pointer(&buf[0]), uintptr(len(buf)), unsafe.Pointer(&percent), unsafe.Pointer(&achieved))
+	if err != nil {
+		handleError(err)
+		return -1, "", 0, false
+	}
+
+	return int32(v), C.GoString((*C.char)(unsafe.Pointer(&buf[0]))), percent, achieved
+}
+
+func (s steamUserStats) GetNextMostAchievedAchievementInfo(iteratorPrevious int32) (iterator int32, name string, percent float32, achieved bool) {
+	var buf [achievementNameMax]byte
+	v, err := theLib.call(funcType_Int32_Ptr_Int32_Ptr_Int32_Ptr_Ptr, flatAPI_ISteamUserStats_GetNextMostAchievedAchievementInfo, uintptr(s), uintptr(iteratorPrevious), unsafe.Pointer(&buf[0]), uintptr(len(buf)), unsafe.Pointer(&percent), unsafe.Pointer(&achieved))
+	if err != nil {
+		handleError(err)
+		return -1, "", 0, false
+	}
+
+	return int32(v), C.GoString((*C.char)(unsafe.Pointer(&buf[0]))), percent, achieved
+}
+
+func (s steamUserStats) GetAchievementIcon(name string) int32 {
 	cname := C.CString(name)
 	defer C.free(unsafe.Pointer(cname))
 
-	v, err := theLib.call(funcType_Bool_Ptr_Ptr, flatAPI_ISteamUserStats_ClearAchievement, uintptr(s), uintptr(unsafe.Pointer(cname)))
+	v, err := theLib.call(funcType_Int32_Ptr_Ptr, flatAPI_ISteamUserStats_GetAchievementIcon, uintptr(s), unsafe.Pointer(cname))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return 0
 	}
 
-	return byte(v) != 0
+	return int32(v)
 }
 
-func (s steamUserStats) StoreStats() bool {
-	v, err := theLib.call(funcType_Bool_Ptr, flatAPI_ISteamUserStats_StoreStats, uintptr(s))
+func (s steamUserStats) findLeaderboard(leaderboardName string) SteamAPICall_t {
+	cname := C.CString(leaderboardName)
+	defer C.free(unsafe.Pointer(cname))
+
+	v, err := theLib.call(funcType_Int64_Ptr_Ptr, flatAPI_ISteamUserStats_FindLeaderboard, uintptr(s), unsafe.Pointer(cname))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return 0
 	}
 
-	return byte(v) != 0
+	return SteamAPICall_t(v)
+}
+
+func (s steamUserStats) findOrCreateLeaderboard(leaderboardName string, sortMethod ELeaderboardSortMethod, displayType ELeaderboardDisplayType) SteamAPICall_t {
+	cname := C.CString(leaderboardName)
+	defer C.free(unsafe.Pointer(cname))
+
+	v, err := theLib.call(funcType_Int64_Ptr_Ptr_Int32_Int32, flatAPI_ISteamUserStats_FindOrCreateLeaderboard, uintptr(s), unsafe.Pointer(cname), uintptr(sortMethod), uintptr(displayType))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+
+	return SteamAPICall_t(v)
+}
+
+func (s steamUserStats) GetLeaderboardName(leaderboard SteamLeaderboard_t) string {
+	v, err := theLib.call(funcType_Ptr_Ptr_Int64, flatAPI_ISteamUserStats_GetLeaderboardName, uintptr(s), leaderboard)
+	if err != nil {
+		handleError(err)
+		return ""
+	}
+	return C.GoString(C.uintptrToChar(C.uintptr_t(v)))
+}
+
+func (s steamUserStats) GetLeaderboardEntryCount(leaderboard SteamLeaderboard_t) int32 {
+	v, err := theLib.call(funcType_Int32_Ptr_Int64, flatAPI_ISteamUserStats_GetLeaderboardEntryCount, uintptr(s), leaderboard)
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return int32(v)
+}
+
+func (s steamUserStats) GetLeaderboardSortMethod(leaderboard SteamLeaderboard_t) ELeaderboardSortMethod {
+	v, err := theLib.call(funcType_Int32_Ptr_Int64, flatAPI_ISteamUserStats_GetLeaderboardSortMethod, uintptr(s), leaderboard)
+	if err != nil {
+		handleError(err)
+		return ELeaderboardSortMethod_None
+	}
+	return ELeaderboardSortMethod(int32(v))
+}
+
+func (s steamUserStats) GetLeaderboardDisplayType(leaderboard SteamLeaderboard_t) ELeaderboardDisplayType {
+	v, err := theLib.call(funcType_Int32_Ptr_Int64, flatAPI_ISteamUserStats_GetLeaderboardDisplayType, uintptr(s), leaderboard)
+	if err != nil {
+		handleError(err)
+		return ELeaderboardDisplayType_None
+	}
+	return ELeaderboardDisplayType(int32(v))
+}
+
+func (s steamUserStats) AttachLeaderboardUGC(leaderboard SteamLeaderboard_t, ugc UGCHandle_t) SteamAPICall_t {
+	v, err := theLib.call(funcType_Int64_Ptr_Int64_Int64, flatAPI_ISteamUserStats_AttachLeaderboardUGC, uintptr(s), leaderboard, ugc)
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return SteamAPICall_t(v)
+}
+
+func (s steamUserStats) downloadLeaderboardEntries(leaderboard SteamLeaderboard_t, dataRequest ELeaderboardDataRequest, rangeStart, rangeEnd int) SteamAPICall_t {
+	v, err := theLib.call(funcType_Int64_Ptr_Int64_Int32_Int32_Int32, flatAPI_ISteamUserStats_DownloadLeaderboardEntries, uintptr(s), leaderboard, uintptr(dataRequest), uintptr(rangeStart), uintptr(rangeEnd))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+
+	return SteamAPICall_t(v)
+}
+
+func (s steamUserStats) downloadLeaderboardEntriesForUsers(leaderboard SteamLeaderboard_t, prgUsers []CSteamID) SteamAPICall_t {
+	prgUsers = append(prgUsers, 0)
+	defer runtime.KeepAlive(prgUsers)
+
+	v, err := theLib.call(funcType_Int64_Ptr_Int64_Ptr_Int32, flatAPI_ISteamUserStats_DownloadLeaderboardEntriesForUsers, uintptr(s), leaderboard, unsafe.Pointer(&prgUsers[0]), uintptr(len(prgUsers)-1))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+
+	return SteamAPICall_t(v)
+}
+
+func (s steamUserStats) getDownloadedLeaderboardEntry(entries SteamLeaderboardEntries_t, index int, detailsMax int) (success bool, entry LeaderboardEntry_t, details []int32) {
+	details = make([]int32, detailsMax+1)
+	defer runtime.KeepAlive(details)
+
+	entryC := entry.CStruct()
+	v, err := theLib.call(funcType_Bool_Ptr_Int64_Int32_Ptr_Ptr_Int32, flatAPI_ISteamUserStats_GetDownloadedLeaderboardEntry, uintptr(s), entries, uintptr(index), unsafe.Pointer(&entryC), unsafe.Pointer(&details[0]), uintptr(detailsMax))
+	if err != nil {
+		handleError(err)
+		return
+	}
+	entry = entry.FromCStruct(entryC)
+	details = details[:detailsMax]
+	success = byte(v) != 0
+	return
+}
+
+func (s steamUserStats) uploadLeaderboardScore(leaderboard SteamLeaderboard_t, uploadScoreMethod ELeaderboardUploadScoreMethod, score int32, scoreDetails ...int32) SteamAPICall_t {
+	scoreDetails = append(scoreDetails, 0)
+	defer runtime.KeepAlive(scoreDetails)
+
+	v, err := theLib.call(funcType_Int64_Ptr_Int64_Int32_Int32_Ptr_Int32, flatAPI_ISteamUserStats_UploadLeaderboardScore, uintptr(s), leaderboard, uintptr(uploadScoreMethod), uintptr(score), unsafe.Pointer(&scoreDetails[0]), uintptr(len(scoreDetails)-1))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+
+	return SteamAPICall_t(v)
 }
 
 func SteamUtils() ISteamUtils {
+	s, err := getSteamUtils()
+	if err != nil {
+		handleError(err)
+	}
+	return s
+}
+
+func getSteamUtils() (steamUtils, error) {
 	v, err := theLib.call(funcType_Ptr, flatAPI_SteamUtils)
 	if err != nil {
-		panic(err)
+		return 0, err
 	}
-	return steamUtils(v)
+	return steamUtils(v), nil
 }
 
 type steamUtils C.uintptr_t
 
+func (s steamUtils) IsOverlayEnabled() bool {
+	v, err := theLib.call(funcType_Bool_Ptr, flatAPI_ISteamUtils_IsOverlayEnabled, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUtils) BOverlayNeedsPresent() bool {
+	v, err := theLib.call(funcType_Bool_Ptr, flatAPI_ISteamUtils_BOverlayNeedsPresent, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUtils) SetOverlayNotificationPosition(position ENotificationPosition) {
+	if _, err := theLib.call(funcType_Void_Ptr_Int32, flatAPI_ISteamUtils_SetOverlayNotificationPosition, uintptr(s), uintptr(position)); err != nil {
+		handleError(err)
+	}
+}
+
+func (s steamUtils) SetOverlayNotificationInset(horizontal, vertical int32) {
+	if _, err := theLib.call(funcType_Void_Ptr_Int32_Int32, flatAPI_ISteamUtils_SetOverlayNotificationInset, uintptr(s), uintptr(horizontal), uintptr(vertical)); err != nil {
+		handleError(err)
+	}
+}
+
+func (s steamUtils) GetSteamUILanguage() string {
+	v, err := theLib.call(funcType_Ptr_Ptr, flatAPI_ISteamUtils_GetSteamUILanguage, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return ""
+	}
+	return C.GoString(C.uintptrToChar(C.uintptr_t(v)))
+}
+
 func (s steamUtils) IsSteamRunningOnSteamDeck() bool {
 	v, err := theLib.call(funcType_Bool_Ptr, flatAPI_ISteamUtils_IsSteamRunningOnSteamDeck, uintptr(s))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
 	return byte(v) != 0
 }
@@ -563,7 +2582,68 @@ func (s steamUtils) IsSteamRunningOnSteamDeck() bool {
 func (s steamUtils) ShowFloatingGamepadTextInput(keyboardMode EFloatingGamepadTextInputMode, textFieldXPosition, textFieldYPosition, textFieldWidth, textFieldHeight int32) bool {
 	v, err := theLib.call(funcType_Bool_Ptr_Int32_Int32_Int32_Int32_Int32, flatAPI_ISteamUtils_ShowFloatingGamepadTextInput, uintptr(s), uintptr(keyboardMode), uintptr(textFieldXPosition), uintptr(textFieldYPosition), uintptr(textFieldWidth), uintptr(textFieldHeight))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUtils) GetAPICallResult(apiCall SteamAPICall_t, callbackExpected iCallbackExpected, callbaseSize int) (callback []byte, success bool, pbFailed bool) {
+	callback, success, pbFailed, err := s.apiCallResult(apiCall, callbackExpected, callbaseSize)
+	if err != nil {
+		handleError(err)
+	}
+	return callback, success, pbFailed
+}
+
+func (s steamUtils) apiCallResult(apiCall SteamAPICall_t, callbackExpected iCallbackExpected, callbaseSize int) (callback []byte, success bool, pbFailed bool, err error) {
+	callback = make([]byte, callbaseSize)
+	defer runtime.KeepAlive(callback)
+
+	v, err := theLib.call(funcType_Bool_Ptr_Int64_Ptr_Int32_Int32_Ptr, flatAPI_ISteamUtils_GetAPICallResult, uintptr(s), apiCall, unsafe.Pointer(&callback[0]), uintptr(callbaseSize), uintptr(callbackExpected), unsafe.Pointer(&pbFailed))
+	if err != nil {
+		return nil, false, false, err
+	}
+	success = byte(v) != 0
+	return
+}
+
+func (s steamUtils) GetAPICallFailureReason(apiCall SteamAPICall_t) ESteamAPICallFailure {
+	reason, err := s.apiCallFailureReason(apiCall)
+	if err != nil {
+		handleError(err)
+		return ESteamAPICallFailure_None
+	}
+	return reason
+}
+
+func (s steamUtils) apiCallFailureReason(apiCall SteamAPICall_t) (ESteamAPICallFailure, error) {
+	v, err := theLib.call(funcType_Int32_Ptr_Int64, flatAPI_ISteamUtils_GetAPICallFailureReason, uintptr(s), apiCall)
+	if err != nil {
+		return ESteamAPICallFailure_None, err
+	}
+	return ESteamAPICallFailure(int32(v)), nil
+}
+
+func (s steamUtils) GetImageSize(image int32) (width, height uint32, success bool) {
+	v, err := theLib.call(funcType_Bool_Ptr_Int32_Ptr_Ptr, flatAPI_ISteamUtils_GetImageSize, uintptr(s), uintptr(image), unsafe.Pointer(&width), unsafe.Pointer(&height))
+	if err != nil {
+		handleError(err)
+		return 0, 0, false
+	}
+	return width, height, byte(v) != 0
+}
+
+func (s steamUtils) GetImageRGBA(image int32, dest []byte) bool {
+	if len(dest) == 0 {
+		return false
+	}
+	defer runtime.KeepAlive(dest)
+
+	v, err := theLib.call(funcType_Bool_Ptr_Int32_Ptr_Int32, flatAPI_ISteamUtils_GetImageRGBA, uintptr(s), uintptr(image), unsafe.Pointer(&dest[0]), uintptr(len(dest)))
+	if err != nil {
+		handleError(err)
+		return false
 	}
 	return byte(v) != 0
 }
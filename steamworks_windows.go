@@ -5,7 +5,9 @@ package steamworks
 
 import (
 	"fmt"
+	"math"
 	"runtime"
+	"sync"
 	"time"
 	"unsafe"
 
@@ -28,18 +30,57 @@ func cStringToGoString(v uintptr, sizeHint int) string {
 }
 
 type dll struct {
-	d     *windows.LazyDLL
-	procs map[string]*windows.LazyProc
+	d     *windows.DLL
+	err   error
+	procs map[string]*windows.Proc
+	mu    sync.Mutex
 }
 
-func (d *dll) call(name string, args ...uintptr) (uintptr, error) {
+func (d *dll) proc(name string) (*windows.Proc, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
 	if d.procs == nil {
-		d.procs = map[string]*windows.LazyProc{}
+		d.procs = map[string]*windows.Proc{}
+	}
+	if p, ok := d.procs[name]; ok {
+		return p, nil
+	}
+	p, err := d.d.FindProc(name)
+	if err != nil {
+		return nil, err
+	}
+	d.procs[name] = p
+	return p, nil
+}
+
+// call calls the function name of the DLL, or the Backend set by SetBackend.
+// The arguments are those callArg takes. Pointers passed as unsafe.Pointer are kept alive and in place
+// for the duration of the call.
+func (d *dll) call(name string, args ...any) (uint64, error) {
+	escape(args)
+	defer runtime.KeepAlive(args)
+
+	if b := currentBackend(); b != nil {
+		return b.Call(name, callArgs(args)...)
 	}
-	if _, ok := d.procs[name]; !ok {
-		d.procs[name] = d.d.NewProc(name)
+	if err := d.ensureLoaded(); err != nil {
+		return 0, err
+	}
+	proc, err := d.proc(name)
+	if err != nil {
+		return 0, err
+	}
+	if err := checkInterface(name, args); err != nil {
+		return 0, err
+	}
+	r1, r2, err := proc.Call(callWords(args, is32Bit)...)
+	// 32-bit Windows returns a 64-bit integer in two registers. For smaller results, the caller's
+	// conversion drops the high word.
+	r := uint64(r1)
+	if is32Bit {
+		r |= uint64(r2) << 32
 	}
-	r, _, err := d.procs[name].Call(args...)
 	if err != nil {
 		errno, ok := err.(windows.Errno)
 		if !ok {
@@ -52,57 +93,157 @@ func (d *dll) call(name string, args ...uintptr) (uintptr, error) {
 	return r, nil
 }
 
-func loadDLL() (*dll, error) {
-	dllName := "steam_api.dll"
-	if !is32Bit {
-		dllName = "steam_api64.dll"
+func dllName() string {
+	if is32Bit {
+		return "steam_api.dll"
+	}
+	return "steam_api64.dll"
+}
+
+// openDLL opens the DLL found where opts says, or else the one Windows finds on its DLL search path.
+func openDLL(opts *LoadOptions) (*windows.DLL, error) {
+	path, err := opts.findLibrary(dllName())
+	if err == nil {
+		return windows.LoadDLL(path)
+	}
+	if opts.Path != "" {
+		return nil, err
+	}
+	d, err := windows.LoadDLL(dllName())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLibraryNotFound, err)
+	}
+	return d, nil
+}
+
+func (d *dll) load(opts *LoadOptions) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.d != nil {
+		return nil
 	}
 
-	return &dll{
-		d: windows.NewLazyDLL(dllName),
-	}, nil
+	dll, err := openDLL(opts)
+	if err != nil {
+		d.err = err
+		return err
+	}
+	if !opts.SkipVersionCheck {
+		for _, name := range versionedInterfaces {
+			if _, err := dll.FindProc(name); err != nil {
+				dll.Release()
+				d.err = fmt.Errorf("steamworks: %s does not export %s, which Steamworks SDK %s has", dll.Name, name, SDKVersion)
+				return d.err
+			}
+		}
+	}
+
+	d.d = dll
+	d.err = nil
+	return nil
 }
 
-var theDLL *dll
+// ensureLoaded loads the DLL with the default options, unless Load has already been called.
+func (d *dll) ensureLoaded() error {
+	d.mu.Lock()
+	loaded, err := d.d != nil, d.err
+	d.mu.Unlock()
 
-func init() {
-	dll, err := loadDLL()
+	if loaded {
+		return nil
+	}
 	if err != nil {
-		panic(err)
+		return err
 	}
-	theDLL = dll
+	return d.load(&LoadOptions{})
 }
 
+func loadLibrary(opts *LoadOptions) error {
+	return theDLL.load(opts)
+}
+
+var theDLL = &dll{}
+
 func RestartAppIfNecessary(appID uint32) bool {
 	v, err := theDLL.call(flatAPI_RestartAppIfNecessary, uintptr(appID))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
 	return byte(v) != 0
 }
 
 func Init() error {
 	var msg steamErrMsg
-	v, err := theDLL.call(flatAPI_InitFlat, uintptr(unsafe.Pointer(&msg[0])))
+	v, err := theDLL.call(flatAPI_InitFlat, unsafe.Pointer(&msg[0]))
 	if err != nil {
-		panic(err)
+		return err
 	}
 	if ESteamAPIInitResult(v) != ESteamAPIInitResult_OK {
 		return fmt.Errorf("steamworks: InitFlat failed: %d, %s", ESteamAPIInitResult(v), msg.String())
 	}
+	DefaultDispatcher().restart()
 	return nil
 }
 
-func RunCallbacks() {
-	if _, err := theDLL.call(flatAPI_RunCallbacks); err != nil {
-		panic(err)
+func shutdown() {
+	if _, err := theDLL.call(flatAPI_Shutdown); err != nil {
+		handleError(err)
+	}
+}
+
+func manualDispatchInit() error {
+	_, err := theDLL.call(flatAPI_ManualDispatch_Init)
+	return err
+}
+
+func getHSteamPipe() (HSteamPipe, error) {
+	v, err := theDLL.call(flatAPI_GetHSteamPipe)
+	if err != nil {
+		return 0, err
+	}
+	return HSteamPipe(v), nil
+}
+
+func manualDispatchRunFrame(pipe HSteamPipe) error {
+	_, err := theDLL.call(flatAPI_ManualDispatch_RunFrame, uintptr(pipe))
+	return err
+}
+
+func manualDispatchGetNextCallback(pipe HSteamPipe) (msg callbackMsg, success bool, err error) {
+	msgC := msg.CStruct()
+	v, err := theDLL.call(flatAPI_ManualDispatch_GetNextCallback, uintptr(pipe), unsafe.Pointer(&msgC))
+	if err != nil {
+		return msg, false, err
+	}
+	if byte(v) == 0 {
+		return msg, false, nil
+	}
+	return msg.FromCStruct(msgC), true, nil
+}
+
+func manualDispatchFreeLastCallback(pipe HSteamPipe) error {
+	_, err := theDLL.call(flatAPI_ManualDispatch_FreeLastCallback, uintptr(pipe))
+	return err
+}
+
+func manualDispatchGetAPICallResult(pipe HSteamPipe, apiCall SteamAPICall_t, callbackExpected iCallbackExpected, callbaseSize int) (callback []byte, success bool, pbFailed bool, err error) {
+	callback = make([]byte, callbaseSize+1)
+	v, err := theDLL.call(flatAPI_ManualDispatch_GetAPICallResult, uintptr(pipe), apiCall, unsafe.Pointer(&callback[0]), uintptr(callbaseSize), uintptr(callbackExpected), unsafe.Pointer(&pbFailed))
+	if err != nil {
+		return nil, false, false, err
 	}
+	callback = callback[:callbaseSize]
+	success = byte(v) != 0
+	return
 }
 
 func SteamApps() ISteamApps {
 	v, err := theDLL.call(flatAPI_SteamApps)
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return steamApps(0)
 	}
 	return steamApps(v)
 }
@@ -111,26 +252,29 @@ type steamApps uintptr
 
 func (s steamApps) BGetDLCDataByIndex(iDLC int) (appID AppId_t, available bool, pchName string, success bool) {
 	var name [4096]byte
-	v, err := theDLL.call(flatAPI_ISteamApps_BGetDLCDataByIndex, uintptr(s), uintptr(iDLC), uintptr(unsafe.Pointer(&appID)), uintptr(unsafe.Pointer(&available)), uintptr(unsafe.Pointer(&name[0])), uintptr(len(name)))
+	v, err := theDLL.call(flatAPI_ISteamApps_BGetDLCDataByIndex, uintptr(s), uintptr(iDLC), unsafe.Pointer(&appID), unsafe.Pointer(&available), unsafe.Pointer(&name[0]), uintptr(len(name)))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return
 	}
-	return appID, available, cStringToGoString(v, len(name)), byte(v) != 0
+	return appID, available, cBufferToString(name[:]), byte(v) != 0
 }
 
 func (s steamApps) BIsDlcInstalled(appID AppId_t) bool {
 	v, err := theDLL.call(flatAPI_ISteamApps_BIsDlcInstalled, uintptr(s), uintptr(appID))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
 	return byte(v) != 0
 }
 
 func (s steamApps) GetAppInstallDir(appID AppId_t) string {
 	var path [4096]byte
-	v, err := theDLL.call(flatAPI_ISteamApps_GetAppInstallDir, uintptr(s), uintptr(appID), uintptr(unsafe.Pointer(&path[0])), uintptr(len(path)))
+	v, err := theDLL.call(flatAPI_ISteamApps_GetAppInstallDir, uintptr(s), uintptr(appID), unsafe.Pointer(&path[0]), uintptr(len(path)))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return ""
 	}
 	return string(path[:uint32(v)-1])
 }
@@ -138,23 +282,180 @@ func (s steamApps) GetAppInstallDir(appID AppId_t) string {
 func (s steamApps) GetCurrentGameLanguage() string {
 	v, err := theDLL.call(flatAPI_ISteamApps_GetCurrentGameLanguage, uintptr(s))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return ""
 	}
-	return cStringToGoString(v, 256)
+	return cStringToGoString(uintptr(v), 256)
 }
 
 func (s steamApps) GetDLCCount() int32 {
 	v, err := theDLL.call(flatAPI_ISteamApps_GetDLCCount, uintptr(s))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return 0
+	}
+	return int32(v)
+}
+
+func (s steamApps) boolCall(name string) bool {
+	v, err := theDLL.call(name, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamApps) BIsSubscribed() bool {
+	return s.boolCall(flatAPI_ISteamApps_BIsSubscribed)
+}
+
+func (s steamApps) BIsSubscribedApp(appID AppId_t) bool {
+	v, err := theDLL.call(flatAPI_ISteamApps_BIsSubscribedApp, uintptr(s), uintptr(appID))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamApps) BIsLowViolence() bool {
+	return s.boolCall(flatAPI_ISteamApps_BIsLowViolence)
+}
+
+func (s steamApps) BIsCybercafe() bool {
+	return s.boolCall(flatAPI_ISteamApps_BIsCybercafe)
+}
+
+func (s steamApps) BIsVACBanned() bool {
+	return s.boolCall(flatAPI_ISteamApps_BIsVACBanned)
+}
+
+func (s steamApps) BIsSubscribedFromFreeWeekend() bool {
+	return s.boolCall(flatAPI_ISteamApps_BIsSubscribedFromFreeWeekend)
+}
+
+func (s steamApps) BIsTimedTrial() (allowed, played time.Duration, ok bool) {
+	var secondsAllowed, secondsPlayed uint32
+	v, err := theDLL.call(flatAPI_ISteamApps_BIsTimedTrial, uintptr(s), unsafe.Pointer(&secondsAllowed), unsafe.Pointer(&secondsPlayed))
+	if err != nil {
+		handleError(err)
+		return 0, 0, false
+	}
+	if byte(v) == 0 {
+		return 0, 0, false
+	}
+	return time.Duration(secondsAllowed) * time.Second, time.Duration(secondsPlayed) * time.Second, true
+}
+
+func (s steamApps) GetAvailableGameLanguages() []string {
+	v, err := theDLL.call(flatAPI_ISteamApps_GetAvailableGameLanguages, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return nil
+	}
+	return splitLanguages(cStringToGoString(uintptr(v), 4096))
+}
+
+func (s steamApps) GetEarliestPurchaseUnixTime(appID AppId_t) time.Time {
+	v, err := theDLL.call(flatAPI_ISteamApps_GetEarliestPurchaseUnixTime, uintptr(s), uintptr(appID))
+	if err != nil {
+		handleError(err)
+		return time.Time{}
+	}
+	return unixTime(int64(uint32(v)))
+}
+
+func (s steamApps) GetCurrentBetaName() (name string, ok bool) {
+	var buf [256]byte
+	v, err := theDLL.call(flatAPI_ISteamApps_GetCurrentBetaName, uintptr(s), unsafe.Pointer(&buf[0]), uintptr(len(buf)))
+	if err != nil {
+		handleError(err)
+		return "", false
+	}
+	if byte(v) == 0 {
+		return "", false
+	}
+	return cBufferToString(buf[:]), true
+}
+
+func (s steamApps) GetAppBuildId() int32 {
+	v, err := theDLL.call(flatAPI_ISteamApps_GetAppBuildId, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return 0
 	}
 	return int32(v)
 }
 
+func (s steamApps) GetAppOwner() CSteamID {
+	v, err := theDLL.call(flatAPI_ISteamApps_GetAppOwner, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return CSteamID(v)
+}
+
+func (s steamApps) GetLaunchQueryParam(key string) string {
+	cKey := append([]byte(key), 0)
+	v, err := theDLL.call(flatAPI_ISteamApps_GetLaunchQueryParam, uintptr(s), unsafe.Pointer(&cKey[0]))
+	runtime.KeepAlive(cKey)
+	if err != nil {
+		handleError(err)
+		return ""
+	}
+	return cStringToGoString(uintptr(v), 1024)
+}
+
+func (s steamApps) GetLaunchCommandLine() string {
+	var buf [4096]byte
+	v, err := theDLL.call(flatAPI_ISteamApps_GetLaunchCommandLine, uintptr(s), unsafe.Pointer(&buf[0]), uintptr(len(buf)))
+	if err != nil {
+		handleError(err)
+		return ""
+	}
+	if int32(v) <= 0 {
+		return ""
+	}
+	return cBufferToString(buf[:])
+}
+
+func (s steamApps) InstallDLC(appID AppId_t) {
+	if _, err := theDLL.call(flatAPI_ISteamApps_InstallDLC, uintptr(s), uintptr(appID)); err != nil {
+		handleError(err)
+	}
+}
+
+func (s steamApps) UninstallDLC(appID AppId_t) {
+	if _, err := theDLL.call(flatAPI_ISteamApps_UninstallDLC, uintptr(s), uintptr(appID)); err != nil {
+		handleError(err)
+	}
+}
+
+func (s steamApps) GetDlcDownloadProgress(appID AppId_t) (downloaded, total uint64, ok bool) {
+	v, err := theDLL.call(flatAPI_ISteamApps_GetDlcDownloadProgress, uintptr(s), uintptr(appID), unsafe.Pointer(&downloaded), unsafe.Pointer(&total))
+	if err != nil {
+		handleError(err)
+		return 0, 0, false
+	}
+	if byte(v) == 0 {
+		return 0, 0, false
+	}
+	return downloaded, total, true
+}
+
+func (s steamApps) RequestAppProofOfPurchaseKey(appID AppId_t) {
+	if _, err := theDLL.call(flatAPI_ISteamApps_RequestAppProofOfPurchaseKey, uintptr(s), uintptr(appID)); err != nil {
+		handleError(err)
+	}
+}
+
 func SteamFriends() ISteamFriends {
 	v, err := theDLL.call(flagAPI_SteamFriends)
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return steamFriends(0)
 	}
 	return steamFriends(v)
 }
@@ -164,9 +465,10 @@ type steamFriends uintptr
 func (s steamFriends) GetPersonaName() string {
 	v, err := theDLL.call(flatAPI_ISteamFriends_GetPersonaName, uintptr(s))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return ""
 	}
-	return cStringToGoString(v, 64)
+	return cStringToGoString(uintptr(v), 64)
 }
 
 func (s steamFriends) SetRichPresence(key, value string) bool {
@@ -175,20 +477,146 @@ func (s steamFriends) SetRichPresence(key, value string) bool {
 	cvalue := append([]byte(value), 0)
 	defer runtime.KeepAlive(cvalue)
 
-	v, err := theDLL.call(flatAPI_ISteamFriends_SetRichPresence, uintptr(s), uintptr(unsafe.Pointer(&ckey[0])), uintptr(unsafe.Pointer(&cvalue[0])))
+	v, err := theDLL.call(flatAPI_ISteamFriends_SetRichPresence, uintptr(s), unsafe.Pointer(&ckey[0]), unsafe.Pointer(&cvalue[0]))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
 	return byte(v) != 0
 }
-func (s steamFriends) ActivateGameOverlayToStore(appID uint32) {
-	theDLL.call(flatAPI_ISteamFriends_ActivateGameOverlayToStore, uintptr(s), uintptr(appID), uintptr(EOverlayToStoreFlag_None))
+func (s steamFriends) ActivateGameOverlayToStore(appID uint32, flag EOverlayToStoreFlag) {
+	if _, err := theDLL.call(flatAPI_ISteamFriends_ActivateGameOverlayToStore, uintptr(s), uintptr(appID), uintptr(flag)); err != nil {
+		handleError(err)
+	}
+}
+
+func (s steamFriends) ActivateGameOverlay(dialog OverlayDialog) {
+	cDialog := append([]byte(string(dialog)), 0)
+	defer runtime.KeepAlive(cDialog)
+	if _, err := theDLL.call(flatAPI_ISteamFriends_ActivateGameOverlay, uintptr(s), unsafe.Pointer(&cDialog[0])); err != nil {
+		handleError(err)
+	}
+}
+
+func (s steamFriends) ActivateGameOverlayToUser(dialog OverlayUserDialog, user CSteamID) {
+	cDialog := append([]byte(string(dialog)), 0)
+	defer runtime.KeepAlive(cDialog)
+	if _, err := theDLL.call(flatAPI_ISteamFriends_ActivateGameOverlayToUser, uintptr(s), unsafe.Pointer(&cDialog[0]), user); err != nil {
+		handleError(err)
+	}
+}
+
+func (s steamFriends) ActivateGameOverlayToWebPage(url string, mode EActivateGameOverlayToWebPageMode) {
+	cURL := append([]byte(url), 0)
+	defer runtime.KeepAlive(cURL)
+	if _, err := theDLL.call(flatAPI_ISteamFriends_ActivateGameOverlayToWebPage, uintptr(s), unsafe.Pointer(&cURL[0]), uintptr(mode)); err != nil {
+		handleError(err)
+	}
+}
+
+func (s steamFriends) ActivateGameOverlayInviteDialog(lobby CSteamID) {
+	if _, err := theDLL.call(flatAPI_ISteamFriends_ActivateGameOverlayInviteDialog, uintptr(s), lobby); err != nil {
+		handleError(err)
+	}
+}
+
+func (s steamFriends) GetFriendCount(flags EFriendFlags) int {
+	v, err := theDLL.call(flatAPI_ISteamFriends_GetFriendCount, uintptr(s), uintptr(flags))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return int(int32(v))
+}
+
+func (s steamFriends) GetFriendByIndex(index int, flags EFriendFlags) CSteamID {
+	v, err := theDLL.call(flatAPI_ISteamFriends_GetFriendByIndex, uintptr(s), uintptr(index), uintptr(flags))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return CSteamID(v)
+}
+
+func (s steamFriends) GetFriendPersonaName(friend CSteamID) string {
+	v, err := theDLL.call(flatAPI_ISteamFriends_GetFriendPersonaName, uintptr(s), friend)
+	if err != nil {
+		handleError(err)
+		return ""
+	}
+	return cStringToGoString(uintptr(v), 256)
+}
+
+func (s steamFriends) GetFriendPersonaState(friend CSteamID) EPersonaState {
+	v, err := theDLL.call(flatAPI_ISteamFriends_GetFriendPersonaState, uintptr(s), friend)
+	if err != nil {
+		handleError(err)
+		return EPersonaState_Offline
+	}
+	return EPersonaState(v)
+}
+
+func (s steamFriends) GetFriendGamePlayed(friend CSteamID) (info FriendGameInfo_t, ok bool) {
+	infoC := info.CStruct()
+	v, err := theDLL.call(flatAPI_ISteamFriends_GetFriendGamePlayed, uintptr(s), friend, unsafe.Pointer(&infoC))
+	if err != nil {
+		handleError(err)
+		return info, false
+	}
+	if byte(v) == 0 {
+		return info, false
+	}
+	return info.FromCStruct(infoC), true
+}
+
+func (s steamFriends) GetFriendRelationship(friend CSteamID) EFriendRelationship {
+	v, err := theDLL.call(flatAPI_ISteamFriends_GetFriendRelationship, uintptr(s), friend)
+	if err != nil {
+		handleError(err)
+		return EFriendRelationship_None
+	}
+	return EFriendRelationship(v)
+}
+
+func (s steamFriends) avatar(name string, friend CSteamID) int32 {
+	v, err := theDLL.call(name, uintptr(s), friend)
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return int32(v)
+}
+
+func (s steamFriends) GetSmallFriendAvatar(friend CSteamID) int32 {
+	return s.avatar(flatAPI_ISteamFriends_GetSmallFriendAvatar, friend)
+}
+
+func (s steamFriends) GetMediumFriendAvatar(friend CSteamID) int32 {
+	return s.avatar(flatAPI_ISteamFriends_GetMediumFriendAvatar, friend)
+}
+
+func (s steamFriends) GetLargeFriendAvatar(friend CSteamID) int32 {
+	return s.avatar(flatAPI_ISteamFriends_GetLargeFriendAvatar, friend)
+}
+
+func (s steamFriends) RequestUserInformation(user CSteamID, requireNameOnly bool) bool {
+	var bRequireNameOnly uintptr
+	if requireNameOnly {
+		bRequireNameOnly = 1
+	}
+	v, err := theDLL.call(flatAPI_ISteamFriends_RequestUserInformation, uintptr(s), user, bRequireNameOnly)
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
 }
 
 func SteamInput() ISteamInput {
 	v, err := theDLL.call(flatAPI_SteamInput)
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return steamInput(0)
 	}
 	return steamInput(v)
 }
@@ -197,17 +625,19 @@ type steamInput uintptr
 
 func (s steamInput) GetConnectedControllers() []InputHandle_t {
 	var handles [_STEAM_INPUT_MAX_COUNT]InputHandle_t
-	v, err := theDLL.call(flatAPI_ISteamInput_GetConnectedControllers, uintptr(s), uintptr(unsafe.Pointer(&handles[0])))
+	v, err := theDLL.call(flatAPI_ISteamInput_GetConnectedControllers, uintptr(s), unsafe.Pointer(&handles[0]))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return nil
 	}
 	return handles[:int(v)]
 }
 
 func (s steamInput) GetInputTypeForHandle(inputHandle InputHandle_t) ESteamInputType {
-	v, err := theDLL.call(flatAPI_ISteamInput_GetInputTypeForHandle, uintptr(s), uintptr(inputHandle))
+	v, err := theDLL.call(flatAPI_ISteamInput_GetInputTypeForHandle, uintptr(s), inputHandle)
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return 0
 	}
 	return ESteamInputType(v)
 }
@@ -224,14 +654,15 @@ func (s steamInput) Init(bExplicitlyCallRunFrame bool) bool {
 
 func (s steamInput) RunFrame() {
 	if _, err := theDLL.call(flatAPI_ISteamInput_RunFrame, uintptr(s), 0); err != nil {
-		panic(err)
+		handleError(err)
 	}
 }
 
 func SteamRemoteStorage() ISteamRemoteStorage {
 	v, err := theDLL.call(flatAPI_SteamRemoteStorage)
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return steamRemoteStorage(0)
 	}
 	return steamRemoteStorage(v)
 }
@@ -242,11 +673,17 @@ func (s steamRemoteStorage) FileWrite(file string, data []byte) bool {
 	cfile := append([]byte(file), 0)
 	defer runtime.KeepAlive(cfile)
 
-	defer runtime.KeepAlive(data)
+	// Steam wants a valid pointer even for an empty file.
+	buf := data
+	if len(buf) == 0 {
+		buf = make([]byte, 1)
+	}
+	defer runtime.KeepAlive(buf)
 
-	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_FileWrite, uintptr(s), uintptr(unsafe.Pointer(&cfile[0])), uintptr(unsafe.Pointer(&data[0])), uintptr(len(data)))
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_FileWrite, uintptr(s), unsafe.Pointer(&cfile[0]), unsafe.Pointer(&buf[0]), uintptr(len(data)))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
 
 	return byte(v) != 0
@@ -256,11 +693,17 @@ func (s steamRemoteStorage) FileRead(file string, data []byte) int32 {
 	cfile := append([]byte(file), 0)
 	defer runtime.KeepAlive(cfile)
 
-	defer runtime.KeepAlive(data)
+	// Steam wants a valid pointer even for an empty buffer.
+	buf := data
+	if len(buf) == 0 {
+		buf = make([]byte, 1)
+	}
+	defer runtime.KeepAlive(buf)
 
-	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_FileRead, uintptr(s), uintptr(unsafe.Pointer(&cfile[0])), uintptr(unsafe.Pointer(&data[0])), uintptr(len(data)))
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_FileRead, uintptr(s), unsafe.Pointer(&cfile[0]), unsafe.Pointer(&buf[0]), uintptr(len(data)))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return 0
 	}
 
 	return int32(v)
@@ -270,9 +713,10 @@ func (s steamRemoteStorage) FileDelete(file string) bool {
 	cfile := append([]byte(file), 0)
 	defer runtime.KeepAlive(cfile)
 
-	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_FileDelete, uintptr(s), uintptr(unsafe.Pointer(&cfile[0])))
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_FileDelete, uintptr(s), unsafe.Pointer(&cfile[0]))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
 
 	return byte(v) != 0
@@ -282,183 +726,850 @@ func (s steamRemoteStorage) GetFileSize(file string) int32 {
 	cfile := append([]byte(file), 0)
 	defer runtime.KeepAlive(cfile)
 
-	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_GetFileSize, uintptr(s), uintptr(unsafe.Pointer(&cfile[0])))
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_GetFileSize, uintptr(s), unsafe.Pointer(&cfile[0]))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return 0
 	}
 
 	return int32(v)
 }
 
-func SteamUser() ISteamUser {
-	v, err := theDLL.call(flatAPI_SteamUser)
+func (s steamRemoteStorage) BeginFileWriteBatch() bool {
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_BeginFileWriteBatch, uintptr(s))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
-	return steamUser(v)
+	return byte(v) != 0
 }
 
-type steamUser uintptr
-
-func (s steamUser) GetSteamID() CSteamID {
-	if is32Bit {
-		// On 32bit machines, syscall cannot treat a returned value as 64bit.
-		panic("GetSteamID is not implemented on 32bit Windows")
-	}
-	v, err := theDLL.call(flatAPI_ISteamUser_GetSteamID, uintptr(s))
+func (s steamRemoteStorage) EndFileWriteBatch() bool {
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_EndFileWriteBatch, uintptr(s))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
-	return CSteamID(v)
+	return byte(v) != 0
 }
 
-func SteamUserStats() ISteamUserStats {
-	v, err := theDLL.call(flatAPI_SteamUserStats)
+func (s steamRemoteStorage) FileWriteStreamOpen(file string) UGCFileWriteStreamHandle_t {
+	cfile := append([]byte(file), 0)
+	defer runtime.KeepAlive(cfile)
+
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_FileWriteStreamOpen, uintptr(s), unsafe.Pointer(&cfile[0]))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return 0
 	}
-	return steamUserStats(v)
+	return UGCFileWriteStreamHandle_t(v)
 }
 
-type steamUserStats uintptr
+func (s steamRemoteStorage) FileWriteStreamWriteChunk(stream UGCFileWriteStreamHandle_t, data []byte) bool {
+	if len(data) == 0 {
+		return true
+	}
+	defer runtime.KeepAlive(data)
 
-func (s steamUserStats) RequestCurrentStats() SteamAPICall_t {
-	steamID := SteamUser().GetSteamID()
-	v, err := theDLL.call(flatAPI_ISteamUserStats_RequestCurrentStats, uintptr(s), uintptr(steamID))
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_FileWriteStreamWriteChunk, uintptr(s), stream, unsafe.Pointer(&data[0]), uintptr(len(data)))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
-
-	return SteamAPICall_t(v)
+	return byte(v) != 0
 }
 
-func (s steamUserStats) requestGlobalStats(historDays int) SteamAPICall_t {
-	v, err := theDLL.call(flatAPI_ISteamUserStats_RequestGlobalStats, uintptr(s), uintptr(historDays))
+func (s steamRemoteStorage) FileWriteStreamClose(stream UGCFileWriteStreamHandle_t) bool {
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_FileWriteStreamClose, uintptr(s), stream)
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
-
-	return SteamAPICall_t(v)
+	return byte(v) != 0
 }
-func (s steamUserStats) getglobalStats(name string) (statCount int, success bool) {
-	cname := append([]byte(name), 0)
-	defer runtime.KeepAlive(cname)
 
-	v, err := theDLL.call(flatAPI_ISteamUserStats_GetGlobalStatInt, uintptr(s), uintptr(unsafe.Pointer(&cname[0])), uintptr(unsafe.Pointer(&statCount)))
+func (s steamRemoteStorage) FileWriteStreamCancel(stream UGCFileWriteStreamHandle_t) bool {
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_FileWriteStreamCancel, uintptr(s), stream)
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
+	return byte(v) != 0
+}
 
-	success = byte(v) != 0
-	return
+func (s steamRemoteStorage) FileReadAsync(file string, offset, size uint32) SteamAPICall_t {
+	cfile := append([]byte(file), 0)
+	defer runtime.KeepAlive(cfile)
 
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_FileReadAsync, uintptr(s), unsafe.Pointer(&cfile[0]), uintptr(offset), uintptr(size))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return SteamAPICall_t(v)
 }
 
-type GlobalStatsSuccessFunc func(values []GlobalStat)
-
-type GlobalStat struct {
-	Name  string
-	Value int
-}
-
-// 仅获取总量统计，不获取历史天数的数据
-func (s steamUserStats) GetGlobalStats(names []string, successFunc GlobalStatsSuccessFunc) {
-	callbackAPI := s.requestGlobalStats(0)
-	defaultCallbackCli().setCallback(&CallbackArgs{
-		CallbackAPI:      callbackAPI,
-		CallbackExpected: iCallbackExpected_GlobalStatsReceived_t,
-		CallbaseSize:     int(GlobalStatsReceived_t{}.Size()),
-		SuccessFunc: func(ret []byte) {
-			d := GlobalStatsReceived_t{}.FromByte(ret)
-			fmt.Printf("data:%+v\n", d)
-			values := []GlobalStat{}
-			for _, name := range names {
-				v, _ := s.getglobalStats(name)
-				ifget := false
-				for index, vv := range values {
-					if v >= vv.Value {
-						ifget = true
-						begin := append([]GlobalStat{}, values[:index]...)
-						end := append([]GlobalStat{{
-							Name:  name,
-							Value: v,
-						}}, values[index:]...)
-						values = append(begin, end...)
-						break
-					}
-				}
-				if !ifget {
-					values = append(values, GlobalStat{
-						Name:  name,
-						Value: v,
-					})
-				}
-			}
-			successFunc(values)
-		},
-		TimeoutFunc: func(callbackTime time.Time, callbackSpend time.Duration) {},
-	})
-}
-
-func (s steamUserStats) AddStat(name string) {
-	callbackAPI := s.RequestCurrentStats()
-	defaultCallbackCli().setCallback(&CallbackArgs{
-		CallbackAPI:      callbackAPI,
-		CallbackExpected: iCallbackExpected_UserStatsReceived_t,
-		CallbaseSize:     int(UserStatsReceived_t{}.Size()),
-		SuccessFunc: func(ret []byte) {
-			// _ = UserStatsReceived_t{}.FromByte(ret)
-			v, _ := s.getUserStat(SteamUser().GetSteamID(), name)
-			s.setUserStat(name, v+1)
-		},
-		TimeoutFunc: func(callbackTime time.Time, callbackSpend time.Duration) {},
-	})
-}
-
-func (s steamUserStats) setUserStat(name string, starCount int) bool {
-	cname := append([]byte(name), 0)
-	defer runtime.KeepAlive(cname)
+func (s steamRemoteStorage) FileReadAsyncComplete(call SteamAPICall_t, data []byte) bool {
+	if len(data) == 0 {
+		return true
+	}
+	defer runtime.KeepAlive(data)
 
-	v, err := theDLL.call(flatAPI_ISteamUserStats_SetStatInt, uintptr(s), uintptr(unsafe.Pointer(&cname[0])), uintptr(starCount))
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_FileReadAsyncComplete, uintptr(s), call, unsafe.Pointer(&data[0]), uintptr(len(data)))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
-
 	return byte(v) != 0
 }
-func (s steamUserStats) getUserStat(userID CSteamID, name string) (starCount int, success bool) {
-	cname := append([]byte(name), 0)
-	defer runtime.KeepAlive(cname)
 
-	v, err := theDLL.call(flatAPI_ISteamUserStats_GetStatInt, uintptr(s), uintptr(userID), uintptr(unsafe.Pointer(&cname[0])), uintptr(unsafe.Pointer(&starCount)))
-	if err != nil {
-		panic(err)
+func (s steamRemoteStorage) FileWriteAsync(file string, data []byte) SteamAPICall_t {
+	cfile := append([]byte(file), 0)
+	defer runtime.KeepAlive(cfile)
+
+	buf := data
+	if len(buf) == 0 {
+		buf = make([]byte, 1)
 	}
+	defer runtime.KeepAlive(buf)
 
-	success = byte(v) != 0
-	return
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_FileWriteAsync, uintptr(s), unsafe.Pointer(&cfile[0]), unsafe.Pointer(&buf[0]), uintptr(len(data)))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return SteamAPICall_t(v)
 }
 
-func (s steamUserStats) GetAchievement(name string) (achieved, success bool) {
-	cname := append([]byte(name), 0)
-	defer runtime.KeepAlive(cname)
+func (s steamRemoteStorage) FileExists(file string) bool {
+	cfile := append([]byte(file), 0)
+	defer runtime.KeepAlive(cfile)
 
-	v, err := theDLL.call(flatAPI_ISteamUserStats_GetAchievement, uintptr(s), uintptr(unsafe.Pointer(&cname[0])), uintptr(unsafe.Pointer(&achieved)))
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_FileExists, uintptr(s), unsafe.Pointer(&cfile[0]))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
-
-	success = byte(v) != 0
-	return
+	return byte(v) != 0
 }
 
-func (s steamUserStats) SetAchievement(name string) bool {
+func (s steamRemoteStorage) FilePersisted(file string) bool {
+	cfile := append([]byte(file), 0)
+	defer runtime.KeepAlive(cfile)
+
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_FilePersisted, uintptr(s), unsafe.Pointer(&cfile[0]))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamRemoteStorage) GetFileTimestamp(file string) time.Time {
+	cfile := append([]byte(file), 0)
+	defer runtime.KeepAlive(cfile)
+
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_GetFileTimestamp, uintptr(s), unsafe.Pointer(&cfile[0]))
+	if err != nil {
+		handleError(err)
+		return time.Time{}
+	}
+	return unixTime(int64(v))
+}
+
+func (s steamRemoteStorage) FileForget(file string) bool {
+	cfile := append([]byte(file), 0)
+	defer runtime.KeepAlive(cfile)
+
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_FileForget, uintptr(s), unsafe.Pointer(&cfile[0]))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamRemoteStorage) SetSyncPlatforms(file string, platforms ERemoteStoragePlatform) bool {
+	cfile := append([]byte(file), 0)
+	defer runtime.KeepAlive(cfile)
+
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_SetSyncPlatforms, uintptr(s), unsafe.Pointer(&cfile[0]), uintptr(platforms))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamRemoteStorage) GetFileCount() int32 {
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_GetFileCount, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return int32(v)
+}
+
+func (s steamRemoteStorage) GetFileNameAndSize(index int32) (name string, size int32) {
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_GetFileNameAndSize, uintptr(s), uintptr(index), unsafe.Pointer(&size))
+	if err != nil {
+		handleError(err)
+		return "", 0
+	}
+	if uintptr(v) == 0 {
+		return "", 0
+	}
+	return cStringToGoString(uintptr(v), 260), size
+}
+
+func (s steamRemoteStorage) GetQuota() (totalBytes, availableBytes uint64, success bool) {
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_GetQuota, uintptr(s), unsafe.Pointer(&totalBytes), unsafe.Pointer(&availableBytes))
+	if err != nil {
+		handleError(err)
+		return 0, 0, false
+	}
+	return totalBytes, availableBytes, byte(v) != 0
+}
+
+func (s steamRemoteStorage) IsCloudEnabledForAccount() bool {
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_IsCloudEnabledForAccount, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamRemoteStorage) IsCloudEnabledForApp() bool {
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_IsCloudEnabledForApp, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamRemoteStorage) SetCloudEnabledForApp(enabled bool) {
+	var bEnabled uintptr
+	if enabled {
+		bEnabled = 1
+	}
+	if _, err := theDLL.call(flatAPI_ISteamRemoteStorage_SetCloudEnabledForApp, uintptr(s), bEnabled); err != nil {
+		handleError(err)
+	}
+}
+
+func (s steamRemoteStorage) FileShare(file string) SteamAPICall_t {
+	cfile := append([]byte(file), 0)
+	defer runtime.KeepAlive(cfile)
+
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_FileShare, uintptr(s), unsafe.Pointer(&cfile[0]))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return SteamAPICall_t(v)
+}
+
+func (s steamRemoteStorage) UGCDownload(ugc UGCHandle_t, priority uint32) SteamAPICall_t {
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_UGCDownload, uintptr(s), ugc, uintptr(priority))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return SteamAPICall_t(v)
+}
+
+func (s steamRemoteStorage) UGCRead(ugc UGCHandle_t, data []byte, offset uint32, action EUGCReadAction) int32 {
+	if len(data) == 0 {
+		return 0
+	}
+	v, err := theDLL.call(flatAPI_ISteamRemoteStorage_UGCRead, uintptr(s), ugc, unsafe.Pointer(&data[0]), uintptr(len(data)), uintptr(offset), uintptr(action))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return int32(v)
+}
+
+func SteamUGC() ISteamUGC {
+	v, err := theDLL.call(flatAPI_SteamUGC)
+	if err != nil {
+		handleError(err)
+		return steamUGC(0)
+	}
+	return steamUGC(v)
+}
+
+type steamUGC uintptr
+
+func (s steamUGC) CreateQueryUserUGCRequest(account AccountID_t, listType EUserUGCList, matchingType EUGCMatchingUGCType, sortOrder EUserUGCListSortOrder, creatorAppID, consumerAppID AppId_t, page uint32) UGCQueryHandle_t {
+	v, err := theDLL.call(flatAPI_ISteamUGC_CreateQueryUserUGCRequest, uintptr(s), uintptr(account), uintptr(listType), uintptr(matchingType), uintptr(sortOrder), uintptr(creatorAppID), uintptr(consumerAppID), uintptr(page))
+	if err != nil {
+		handleError(err)
+		return ugcQueryHandleInvalid
+	}
+	return UGCQueryHandle_t(v)
+}
+
+func (s steamUGC) CreateQueryAllUGCRequest(queryType EUGCQuery, matchingType EUGCMatchingUGCType, creatorAppID, consumerAppID AppId_t, page uint32) UGCQueryHandle_t {
+	v, err := theDLL.call(flatAPI_ISteamUGC_CreateQueryAllUGCRequest, uintptr(s), uintptr(queryType), uintptr(matchingType), uintptr(creatorAppID), uintptr(consumerAppID), uintptr(page))
+	if err != nil {
+		handleError(err)
+		return ugcQueryHandleInvalid
+	}
+	return UGCQueryHandle_t(v)
+}
+
+func (s steamUGC) CreateQueryUGCDetailsRequest(ids []PublishedFileId_t) UGCQueryHandle_t {
+	ids = append(ids, 0)
+	defer runtime.KeepAlive(ids)
+
+	v, err := theDLL.call(flatAPI_ISteamUGC_CreateQueryUGCDetailsRequest, uintptr(s), unsafe.Pointer(&ids[0]), uintptr(len(ids)-1))
+	if err != nil {
+		handleError(err)
+		return ugcQueryHandleInvalid
+	}
+	return UGCQueryHandle_t(v)
+}
+
+func (s steamUGC) AddRequiredTag(query UGCQueryHandle_t, tag string) bool {
+	return s.callString(flatAPI_ISteamUGC_AddRequiredTag, uint64(query), tag)
+}
+
+func (s steamUGC) AddExcludedTag(query UGCQueryHandle_t, tag string) bool {
+	return s.callString(flatAPI_ISteamUGC_AddExcludedTag, uint64(query), tag)
+}
+
+func (s steamUGC) SetSearchText(query UGCQueryHandle_t, text string) bool {
+	return s.callString(flatAPI_ISteamUGC_SetSearchText, uint64(query), text)
+}
+
+func (s steamUGC) SetLanguage(query UGCQueryHandle_t, language string) bool {
+	return s.callString(flatAPI_ISteamUGC_SetLanguage, uint64(query), language)
+}
+
+// callString calls a query or update function that takes a string.
+func (s steamUGC) callString(name string, handle uint64, str string) bool {
+	cstr := append([]byte(str), 0)
+	defer runtime.KeepAlive(cstr)
+
+	v, err := theDLL.call(name, uintptr(s), handle, unsafe.Pointer(&cstr[0]))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUGC) SetMatchAnyTag(query UGCQueryHandle_t, matchAnyTag bool) bool {
+	var bMatchAnyTag uintptr
+	if matchAnyTag {
+		bMatchAnyTag = 1
+	}
+	v, err := theDLL.call(flatAPI_ISteamUGC_SetMatchAnyTag, uintptr(s), query, bMatchAnyTag)
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUGC) SetRankedByTrendDays(query UGCQueryHandle_t, days uint32) bool {
+	v, err := theDLL.call(flatAPI_ISteamUGC_SetRankedByTrendDays, uintptr(s), query, uintptr(days))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUGC) SetReturnLongDescription(query UGCQueryHandle_t, returnLongDescription bool) bool {
+	var bReturnLongDescription uintptr
+	if returnLongDescription {
+		bReturnLongDescription = 1
+	}
+	v, err := theDLL.call(flatAPI_ISteamUGC_SetReturnLongDescription, uintptr(s), query, bReturnLongDescription)
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUGC) SetReturnMetadata(query UGCQueryHandle_t, returnMetadata bool) bool {
+	var bReturnMetadata uintptr
+	if returnMetadata {
+		bReturnMetadata = 1
+	}
+	v, err := theDLL.call(flatAPI_ISteamUGC_SetReturnMetadata, uintptr(s), query, bReturnMetadata)
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUGC) SetAllowCachedResponse(query UGCQueryHandle_t, maxAgeSeconds uint32) bool {
+	v, err := theDLL.call(flatAPI_ISteamUGC_SetAllowCachedResponse, uintptr(s), query, uintptr(maxAgeSeconds))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUGC) SendQueryUGCRequest(query UGCQueryHandle_t) SteamAPICall_t {
+	v, err := theDLL.call(flatAPI_ISteamUGC_SendQueryUGCRequest, uintptr(s), query)
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return SteamAPICall_t(v)
+}
+
+func (s steamUGC) GetQueryUGCResult(query UGCQueryHandle_t, index uint32) (details SteamUGCDetails_t, success bool) {
+	detailsC := details.CStruct()
+	v, err := theDLL.call(flatAPI_ISteamUGC_GetQueryUGCResult, uintptr(s), query, uintptr(index), unsafe.Pointer(&detailsC))
+	if err != nil {
+		handleError(err)
+		return details, false
+	}
+	return details.FromCStruct(detailsC), byte(v) != 0
+}
+
+func (s steamUGC) GetQueryUGCPreviewURL(query UGCQueryHandle_t, index uint32) string {
+	return s.queryString(flatAPI_ISteamUGC_GetQueryUGCPreviewURL, query, index, 1024)
+}
+
+func (s steamUGC) GetQueryUGCMetadata(query UGCQueryHandle_t, index uint32) string {
+	return s.queryString(flatAPI_ISteamUGC_GetQueryUGCMetadata, query, index, ugcMetadataMax)
+}
+
+// queryString calls a query function that copies a string of up to size bytes out.
+func (s steamUGC) queryString(name string, query UGCQueryHandle_t, index uint32, size int) string {
+	buf := make([]byte, size)
+	defer runtime.KeepAlive(buf)
+
+	v, err := theDLL.call(name, uintptr(s), query, uintptr(index), unsafe.Pointer(&buf[0]), uintptr(len(buf)))
+	if err != nil {
+		handleError(err)
+		return ""
+	}
+	if byte(v) == 0 {
+		return ""
+	}
+	return cBufferToString(buf)
+}
+
+func (s steamUGC) GetQueryUGCStatistic(query UGCQueryHandle_t, index uint32, statistic EItemStatistic) (value uint64, success bool) {
+	v, err := theDLL.call(flatAPI_ISteamUGC_GetQueryUGCStatistic, uintptr(s), query, uintptr(index), uintptr(statistic), unsafe.Pointer(&value))
+	if err != nil {
+		handleError(err)
+		return 0, false
+	}
+	return value, byte(v) != 0
+}
+
+func (s steamUGC) ReleaseQueryUGCRequest(query UGCQueryHandle_t) bool {
+	v, err := theDLL.call(flatAPI_ISteamUGC_ReleaseQueryUGCRequest, uintptr(s), query)
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUGC) SubscribeItem(id PublishedFileId_t) SteamAPICall_t {
+	v, err := theDLL.call(flatAPI_ISteamUGC_SubscribeItem, uintptr(s), id)
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return SteamAPICall_t(v)
+}
+
+func (s steamUGC) UnsubscribeItem(id PublishedFileId_t) SteamAPICall_t {
+	v, err := theDLL.call(flatAPI_ISteamUGC_UnsubscribeItem, uintptr(s), id)
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return SteamAPICall_t(v)
+}
+
+// GetNumSubscribedItems and GetSubscribedItems pass false for bIncludeLocallyDisabled,
+// which libraries from before it was added ignore.
+func (s steamUGC) GetNumSubscribedItems() uint32 {
+	v, err := theDLL.call(flatAPI_ISteamUGC_GetNumSubscribedItems, uintptr(s), 0)
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return uint32(v)
+}
+
+func (s steamUGC) GetSubscribedItems() []PublishedFileId_t {
+	n := s.GetNumSubscribedItems()
+	if n == 0 {
+		return nil
+	}
+	ids := make([]PublishedFileId_t, n)
+	defer runtime.KeepAlive(ids)
+
+	v, err := theDLL.call(flatAPI_ISteamUGC_GetSubscribedItems, uintptr(s), unsafe.Pointer(&ids[0]), uintptr(len(ids)), 0)
+	if err != nil {
+		handleError(err)
+		return nil
+	}
+	return ids[:min(uint32(v), n)]
+}
+
+func (s steamUGC) GetItemState(id PublishedFileId_t) EItemState {
+	v, err := theDLL.call(flatAPI_ISteamUGC_GetItemState, uintptr(s), id)
+	if err != nil {
+		handleError(err)
+		return EItemState_None
+	}
+	return EItemState(v)
+}
+
+func (s steamUGC) GetItemInstallInfo(id PublishedFileId_t) (sizeOnDisk uint64, folder string, timestamp time.Time, success bool) {
+	var path [4096]byte
+	var t uint32
+	v, err := theDLL.call(flatAPI_ISteamUGC_GetItemInstallInfo, uintptr(s), id, unsafe.Pointer(&sizeOnDisk), unsafe.Pointer(&path[0]), uintptr(len(path)), unsafe.Pointer(&t))
+	if err != nil {
+		handleError(err)
+		return 0, "", time.Time{}, false
+	}
+	if byte(v) == 0 {
+		return 0, "", time.Time{}, false
+	}
+	return sizeOnDisk, cBufferToString(path[:]), unixTime(int64(t)), true
+}
+
+func (s steamUGC) GetItemDownloadInfo(id PublishedFileId_t) (downloaded, total uint64, success bool) {
+	v, err := theDLL.call(flatAPI_ISteamUGC_GetItemDownloadInfo, uintptr(s), id, unsafe.Pointer(&downloaded), unsafe.Pointer(&total))
+	if err != nil {
+		handleError(err)
+		return 0, 0, false
+	}
+	return downloaded, total, byte(v) != 0
+}
+
+func (s steamUGC) DownloadItem(id PublishedFileId_t, highPriority bool) bool {
+	var bHighPriority uintptr
+	if highPriority {
+		bHighPriority = 1
+	}
+	v, err := theDLL.call(flatAPI_ISteamUGC_DownloadItem, uintptr(s), id, bHighPriority)
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUGC) CreateItem(consumerAppID AppId_t, fileType EWorkshopFileType) SteamAPICall_t {
+	v, err := theDLL.call(flatAPI_ISteamUGC_CreateItem, uintptr(s), uintptr(consumerAppID), uintptr(fileType))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return SteamAPICall_t(v)
+}
+
+func (s steamUGC) StartItemUpdate(consumerAppID AppId_t, id PublishedFileId_t) UGCUpdateHandle_t {
+	v, err := theDLL.call(flatAPI_ISteamUGC_StartItemUpdate, uintptr(s), uintptr(consumerAppID), id)
+	if err != nil {
+		handleError(err)
+		return ugcUpdateHandleInvalid
+	}
+	return UGCUpdateHandle_t(v)
+}
+
+func (s steamUGC) SetItemTitle(update UGCUpdateHandle_t, title string) bool {
+	return s.callString(flatAPI_ISteamUGC_SetItemTitle, uint64(update), title)
+}
+
+func (s steamUGC) SetItemDescription(update UGCUpdateHandle_t, description string) bool {
+	return s.callString(flatAPI_ISteamUGC_SetItemDescription, uint64(update), description)
+}
+
+func (s steamUGC) SetItemContent(update UGCUpdateHandle_t, folder string) bool {
+	return s.callString(flatAPI_ISteamUGC_SetItemContent, uint64(update), folder)
+}
+
+func (s steamUGC) SetItemPreview(update UGCUpdateHandle_t, file string) bool {
+	return s.callString(flatAPI_ISteamUGC_SetItemPreview, uint64(update), file)
+}
+
+// SetItemTags passes false for bAllowAdminTags, which libraries from before it was added ignore.
+func (s steamUGC) SetItemTags(update UGCUpdateHandle_t, tags []string) bool {
+	ctags := make([][]byte, len(tags))
+	ptrs := make([]uintptr, len(tags)+1)
+	for i, tag := range tags {
+		ctags[i] = append([]byte(tag), 0)
+		ptrs[i] = uintptr(unsafe.Pointer(&ctags[i][0]))
+	}
+	defer runtime.KeepAlive(ctags)
+	defer runtime.KeepAlive(ptrs)
+
+	array := steamParamStringArray{strings: uintptr(unsafe.Pointer(&ptrs[0])), num: int32(len(tags))}
+	v, err := theDLL.call(flatAPI_ISteamUGC_SetItemTags, uintptr(s), update, unsafe.Pointer(&array), 0)
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUGC) SetItemVisibility(update UGCUpdateHandle_t, visibility ERemoteStoragePublishedFileVisibility) bool {
+	v, err := theDLL.call(flatAPI_ISteamUGC_SetItemVisibility, uintptr(s), update, uintptr(visibility))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUGC) SubmitItemUpdate(update UGCUpdateHandle_t, changeNote string) SteamAPICall_t {
+	cchangeNote := append([]byte(changeNote), 0)
+	defer runtime.KeepAlive(cchangeNote)
+
+	v, err := theDLL.call(flatAPI_ISteamUGC_SubmitItemUpdate, uintptr(s), update, unsafe.Pointer(&cchangeNote[0]))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return SteamAPICall_t(v)
+}
+
+func (s steamUGC) GetItemUpdateProgress(update UGCUpdateHandle_t) (status EItemUpdateStatus, bytesProcessed, bytesTotal uint64) {
+	v, err := theDLL.call(flatAPI_ISteamUGC_GetItemUpdateProgress, uintptr(s), update, unsafe.Pointer(&bytesProcessed), unsafe.Pointer(&bytesTotal))
+	if err != nil {
+		handleError(err)
+		return EItemUpdateStatus_Invalid, 0, 0
+	}
+	return EItemUpdateStatus(v), bytesProcessed, bytesTotal
+}
+
+func SteamUser() ISteamUser {
+	v, err := theDLL.call(flatAPI_SteamUser)
+	if err != nil {
+		handleError(err)
+		return steamUser(0)
+	}
+	return steamUser(v)
+}
+
+type steamUser uintptr
+
+func (s steamUser) GetSteamID() CSteamID {
+	v, err := theDLL.call(flatAPI_ISteamUser_GetSteamID, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return CSteamID(v)
+}
+
+func SteamUserStats() ISteamUserStats {
+	v, err := theDLL.call(flatAPI_SteamUserStats)
+	if err != nil {
+		handleError(err)
+		return steamUserStats(0)
+	}
+	return steamUserStats(v)
+}
+
+type steamUserStats uintptr
+
+func (s steamUserStats) RequestCurrentStats() SteamAPICall_t {
+	return s.RequestUserStats(SteamUser().GetSteamID())
+}
+
+func (s steamUserStats) RequestUserStats(steamID CSteamID) SteamAPICall_t {
+	v, err := theDLL.call(flatAPI_ISteamUserStats_RequestUserStats, uintptr(s), steamID)
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+
+	return SteamAPICall_t(v)
+}
+
+func (s steamUserStats) requestGlobalStats(historDays int) SteamAPICall_t {
+	v, err := theDLL.call(flatAPI_ISteamUserStats_RequestGlobalStats, uintptr(s), uintptr(historDays))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+
+	return SteamAPICall_t(v)
+}
+func (s steamUserStats) getglobalStats(name string) (statCount int, success bool) {
+	cname := append([]byte(name), 0)
+	defer runtime.KeepAlive(cname)
+
+	v, err := theDLL.call(flatAPI_ISteamUserStats_GetGlobalStatInt, uintptr(s), unsafe.Pointer(&cname[0]), unsafe.Pointer(&statCount))
+	if err != nil {
+		handleError(err)
+		return
+	}
+
+	success = byte(v) != 0
+	return
+
+}
+
+func (s steamUserStats) GetStatInt32(name string) (value int32, success bool) {
+	cname := append([]byte(name), 0)
+	defer runtime.KeepAlive(cname)
+
+	v, err := theDLL.call(flatAPI_ISteamUserStats_GetStatInt32, uintptr(s), unsafe.Pointer(&cname[0]), unsafe.Pointer(&value))
+	if err != nil {
+		handleError(err)
+		return
+	}
+
+	success = byte(v) != 0
+	return
+}
+
+func (s steamUserStats) GetStatFloat(name string) (value float32, success bool) {
+	cname := append([]byte(name), 0)
+	defer runtime.KeepAlive(cname)
+
+	v, err := theDLL.call(flatAPI_ISteamUserStats_GetStatFloat, uintptr(s), unsafe.Pointer(&cname[0]), unsafe.Pointer(&value))
+	if err != nil {
+		handleError(err)
+		return
+	}
+
+	success = byte(v) != 0
+	return
+}
+
+func (s steamUserStats) SetStatInt32(name string, value int32) bool {
+	cname := append([]byte(name), 0)
+	defer runtime.KeepAlive(cname)
+
+	v, err := theDLL.call(flatAPI_ISteamUserStats_SetStatInt32, uintptr(s), unsafe.Pointer(&cname[0]), uintptr(value))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+
+	return byte(v) != 0
+}
+
+func (s steamUserStats) SetStatFloat(name string, value float32) bool {
+	cname := append([]byte(name), 0)
+	defer runtime.KeepAlive(cname)
+
+	// The syscall passes the first four arguments in the XMM registers too, so a float goes as its bits.
+	v, err := theDLL.call(flatAPI_ISteamUserStats_SetStatFloat, uintptr(s), unsafe.Pointer(&cname[0]), uintptr(math.Float32bits(value)))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+
+	return byte(v) != 0
+}
+
+func (s steamUserStats) UpdateAvgRateStat(name string, countThisSession float32, sessionLength float64) bool {
+	cname := append([]byte(name), 0)
+	defer runtime.KeepAlive(cname)
+
+	// Passed as a uint64, the double takes two words on the stack on 32-bit Windows.
+	v, err := theDLL.call(flatAPI_ISteamUserStats_UpdateAvgRateStat, uintptr(s), unsafe.Pointer(&cname[0]), uintptr(math.Float32bits(countThisSession)), math.Float64bits(sessionLength))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+
+	return byte(v) != 0
+}
+
+func (s steamUserStats) GetUserStatInt32(steamID CSteamID, name string) (value int32, success bool) {
+	cname := append([]byte(name), 0)
+	defer runtime.KeepAlive(cname)
+
+	v, err := theDLL.call(flatAPI_ISteamUserStats_GetUserStatInt32, uintptr(s), steamID, unsafe.Pointer(&cname[0]), unsafe.Pointer(&value))
+	if err != nil {
+		handleError(err)
+		return
+	}
+
+	success = byte(v) != 0
+	return
+}
+
+func (s steamUserStats) GetUserStatFloat(steamID CSteamID, name string) (value float32, success bool) {
+	cname := append([]byte(name), 0)
+	defer runtime.KeepAlive(cname)
+
+	v, err := theDLL.call(flatAPI_ISteamUserStats_GetUserStatFloat, uintptr(s), steamID, unsafe.Pointer(&cname[0]), unsafe.Pointer(&value))
+	if err != nil {
+		handleError(err)
+		return
+	}
+
+	success = byte(v) != 0
+	return
+}
+
+func (s steamUserStats) GetUserAchievement(steamID CSteamID, name string) (achieved, success bool) {
+	cname := append([]byte(name), 0)
+	defer runtime.KeepAlive(cname)
+
+	v, err := theDLL.call(flatAPI_ISteamUserStats_GetUserAchievement, uintptr(s), steamID, unsafe.Pointer(&cname[0]), unsafe.Pointer(&achieved))
+	if err != nil {
+		handleError(err)
+		return
+	}
+
+	success = byte(v) != 0
+	return
+}
+
+func (s steamUserStats) ResetAllStats(achievementsToo bool) bool {
+	var bAchievementsToo uintptr
+	if achievementsToo {
+		bAchievementsToo = 1
+	}
+	v, err := theDLL.call(flatAPI_ISteamUserStats_ResetAllStats, uintptr(s), bAchievementsToo)
+	if err != nil {
+		handleError(err)
+		return false
+	}
+
+	return byte(v) != 0
+}
+
+func (s steamUserStats) GetAchievement(name string) (achieved, success bool) {
+	cname := append([]byte(name), 0)
+	defer runtime.KeepAlive(cname)
+
+	v, err := theDLL.call(flatAPI_ISteamUserStats_GetAchievement, uintptr(s), unsafe.Pointer(&cname[0]), unsafe.Pointer(&achieved))
+	if err != nil {
+		handleError(err)
+		return
+	}
+
+	success = byte(v) != 0
+	return
+}
+
+func (s steamUserStats) SetAchievement(name string) bool {
 	cname := append([]byte(name), 0)
 	defer runtime.KeepAlive(cname)
 
-	v, err := theDLL.call(flatAPI_ISteamUserStats_SetAchievement, uintptr(s), uintptr(unsafe.Pointer(&cname[0])))
+	v, err := theDLL.call(flatAPI_ISteamUserStats_SetAchievement, uintptr(s), unsafe.Pointer(&cname[0]))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
 
 	return byte(v) != 0
@@ -468,9 +1579,10 @@ func (s steamUserStats) ClearAchievement(name string) bool {
 	cname := append([]byte(name), 0)
 	defer runtime.KeepAlive(cname)
 
-	v, err := theDLL.call(flatAPI_ISteamUserStats_ClearAchievement, uintptr(s), uintptr(unsafe.Pointer(&cname[0])))
+	v, err := theDLL.call(flatAPI_ISteamUserStats_ClearAchievement, uintptr(s), unsafe.Pointer(&cname[0]))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
 
 	return byte(v) != 0
@@ -479,18 +1591,169 @@ func (s steamUserStats) ClearAchievement(name string) bool {
 func (s steamUserStats) StoreStats() bool {
 	v, err := theDLL.call(flatAPI_ISteamUserStats_StoreStats, uintptr(s))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
+	}
+
+	return byte(v) != 0
+}
+
+func (s steamUserStats) GetNumAchievements() uint32 {
+	v, err := theDLL.call(flatAPI_ISteamUserStats_GetNumAchievements, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+
+	return uint32(v)
+}
+
+func (s steamUserStats) GetAchievementName(index uint32) string {
+	v, err := theDLL.call(flatAPI_ISteamUserStats_GetAchievementName, uintptr(s), uintptr(index))
+	if err != nil {
+		handleError(err)
+		return ""
+	}
+
+	return cStringToGoString(uintptr(v), 128)
+}
+
+func (s steamUserStats) GetAchievementDisplayAttribute(name, key string) string {
+	cname := append([]byte(name), 0)
+	defer runtime.KeepAlive(cname)
+	ckey := append([]byte(key), 0)
+	defer runtime.KeepAlive(ckey)
+
+	v, err := theDLL.call(flatAPI_ISteamUserStats_GetAchievementDisplayAttribute, uintptr(s), unsafe.Pointer(&cname[0]), unsafe.Pointer(&ckey[0]))
+	if err != nil {
+		handleError(err)
+		return ""
+	}
+
+	return cStringToGoString(uintptr(v), 128)
+}
+
+func (s steamUserStats) GetAchievementAndUnlockTime(name string) (achieved bool, unlockTime time.Time, success bool) {
+	cname := append([]byte(name), 0)
+	defer runtime.KeepAlive(cname)
+
+	var t uint32
+	v, err := theDLL.call(flatAPI_ISteamUserStats_GetAchievementAndUnlockTime, uintptr(s), unsafe.Pointer(&cname[0]), unsafe.Pointer(&achieved), unsafe.Pointer(&t))
+	if err != nil {
+		handleError(err)
+		return
+	}
+
+	return achieved, unixTime(int64(t)), byte(v) != 0
+}
+
+func (s steamUserStats) IndicateAchievementProgress(name string, curProgress, maxProgress uint32) bool {
+	cname := append([]byte(name), 0)
+	defer runtime.KeepAlive(cname)
+
+	v, err := theDLL.call(flatAPI_ISteamUserStats_IndicateAchievementProgress, uintptr(s), unsafe.Pointer(&cname[0]), uintptr(curProgress), uintptr(maxProgress))
+	if err != nil {
+		handleError(err)
+		return false
 	}
 
 	return byte(v) != 0
 }
 
+func (s steamUserStats) GetAchievementProgressLimitsInt32(name string) (minProgress, maxProgress int32, success bool) {
+	cname := append([]byte(name), 0)
+	defer runtime.KeepAlive(cname)
+
+	v, err := theDLL.call(flatAPI_ISteamUserStats_GetAchievementProgressLimitsInt32, uintptr(s), unsafe.Pointer(&cname[0]), unsafe.Pointer(&minProgress), unsafe.Pointer(&maxProgress))
+	if err != nil {
+		handleError(err)
+		return
+	}
+
+	success = byte(v) != 0
+	return
+}
+
+func (s steamUserStats) GetAchievementProgressLimitsFloat(name string) (minProgress, maxProgress float32, success bool) {
+	cname := append([]byte(name), 0)
+	defer runtime.KeepAlive(cname)
+
+	v, err := theDLL.call(flatAPI_ISteamUserStats_GetAchievementProgressLimitsFloat, uintptr(s), unsafe.Pointer(&cname[0]), unsafe.Pointer(&minProgress), unsafe.Pointer(&maxProgress))
+	if err != nil {
+		handleError(err)
+		return
+	}
+
+	success = byte(v) != 0
+	return
+}
+
+func (s steamUserStats) RequestGlobalAchievementPercentages() SteamAPICall_t {
+	v, err := theDLL.call(flatAPI_ISteamUserStats_RequestGlobalAchievementPercentages, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+
+	return SteamAPICall_t(v)
+}
+
+func (s steamUserStats) GetAchievementAchievedPercent(name string) (percent float32, success bool) {
+	cname := append([]byte(name), 0)
+	defer runtime.KeepAlive(cname)
+
+	v, err := theDLL.call(flatAPI_ISteamUserStats_GetAchievementAchievedPercent, uintptr(s), unsafe.Pointer(&cname[0]), unsafe.Pointer(&percent))
+	if err != nil {
+		handleError(err)
+		return
+	}
+
+	success = byte(v) != 0
+	return
+}
+
+func (s steamUserStats) GetMostAchievedAchievementInfo() (iterator int32, name string, percent float32, achieved bool) {
+	var buf [achievementNameMax]byte
+	v, err := theDLL.call(flatAPI_ISteamUserStats_GetMostAchievedAchievementInfo, uintptr(s), unsafe.Pointer(&buf[0]), uintptr(len(buf)), unsafe.Pointer(&percent), unsafe.Pointer(&achieved))
+	if err != nil {
+		handleError(err)
+		return -1, "", 0, false
+	}
+
+	return int32(v), cBufferToString(buf[:]), percent, achieved
+}
+
+func (s steamUserStats) GetNextMostAchievedAchievementInfo(iteratorPrevious int32) (iterator int32, name string, percent float32, achieved bool) {
+	var buf [achievementNameMax]byte
+	v, err := theDLL.call(flatAPI_ISteamUserStats_GetNextMostAchievedAchievementInfo, uintptr(s), uintptr(iteratorPrevious), unsafe.Pointer(&buf[0]), uintptr(len(buf)), unsafe.Pointer(&percent), unsafe.Pointer(&achieved))
+	if err != nil {
+		handleError(err)
+		return -1, "", 0, false
+	}
+
+	return int32(v), cBufferToString(buf[:]), percent, achieved
+}
+
+func (s steamUserStats) GetAchievementIcon(name string) int32 {
+	cname := append([]byte(name), 0)
+	defer runtime.KeepAlive(cname)
+
+	v, err := theDLL.call(flatAPI_ISteamUserStats_GetAchievementIcon, uintptr(s), unsafe.Pointer(&cname[0]))
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+
+	return int32(v)
+}
+
 func (s steamUserStats) findLeaderboard(leaderboardName string) SteamAPICall_t {
 	cname := append([]byte(leaderboardName), 0)
 	defer runtime.KeepAlive(cname)
-	v, err := theDLL.call(flatAPI_ISteamUserStats_FindLeaderboard, uintptr(s), uintptr(unsafe.Pointer(&cname[0])))
+	v, err := theDLL.call(flatAPI_ISteamUserStats_FindLeaderboard, uintptr(s), unsafe.Pointer(&cname[0]))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return 0
 	}
 
 	return SteamAPICall_t(v)
@@ -499,25 +1762,64 @@ func (s steamUserStats) findLeaderboard(leaderboardName string) SteamAPICall_t {
 func (s steamUserStats) findOrCreateLeaderboard(leaderboardName string, sortMethod ELeaderboardSortMethod, displayType ELeaderboardDisplayType) SteamAPICall_t {
 	cname := append([]byte(leaderboardName), 0)
 	defer runtime.KeepAlive(cname)
-	v, err := theDLL.call(flatAPI_ISteamUserStats_FindOrCreateLeaderboard, uintptr(s), uintptr(unsafe.Pointer(&cname[0])), uintptr(sortMethod), uintptr(displayType))
+	v, err := theDLL.call(flatAPI_ISteamUserStats_FindOrCreateLeaderboard, uintptr(s), unsafe.Pointer(&cname[0]), uintptr(sortMethod), uintptr(displayType))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return 0
 	}
 
 	return SteamAPICall_t(v)
 }
 func (s steamUserStats) GetLeaderboardName(leaderboard SteamLeaderboard_t) string {
-	v, err := theDLL.call(flatAPI_ISteamUserStats_GetLeaderboardName, uintptr(s), uintptr(leaderboard))
+	v, err := theDLL.call(flatAPI_ISteamUserStats_GetLeaderboardName, uintptr(s), leaderboard)
+	if err != nil {
+		handleError(err)
+		return ""
+	}
+	return cStringToGoString(uintptr(v), 64)
+}
+
+func (s steamUserStats) GetLeaderboardEntryCount(leaderboard SteamLeaderboard_t) int32 {
+	v, err := theDLL.call(flatAPI_ISteamUserStats_GetLeaderboardEntryCount, uintptr(s), leaderboard)
+	if err != nil {
+		handleError(err)
+		return 0
+	}
+	return int32(v)
+}
+
+func (s steamUserStats) GetLeaderboardSortMethod(leaderboard SteamLeaderboard_t) ELeaderboardSortMethod {
+	v, err := theDLL.call(flatAPI_ISteamUserStats_GetLeaderboardSortMethod, uintptr(s), leaderboard)
+	if err != nil {
+		handleError(err)
+		return ELeaderboardSortMethod_None
+	}
+	return ELeaderboardSortMethod(int32(v))
+}
+
+func (s steamUserStats) GetLeaderboardDisplayType(leaderboard SteamLeaderboard_t) ELeaderboardDisplayType {
+	v, err := theDLL.call(flatAPI_ISteamUserStats_GetLeaderboardDisplayType, uintptr(s), leaderboard)
+	if err != nil {
+		handleError(err)
+		return ELeaderboardDisplayType_None
+	}
+	return ELeaderboardDisplayType(int32(v))
+}
+
+func (s steamUserStats) AttachLeaderboardUGC(leaderboard SteamLeaderboard_t, ugc UGCHandle_t) SteamAPICall_t {
+	v, err := theDLL.call(flatAPI_ISteamUserStats_AttachLeaderboardUGC, uintptr(s), leaderboard, ugc)
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return 0
 	}
-	return cStringToGoString(v, 64)
+	return SteamAPICall_t(v)
 }
 
 func (s steamUserStats) downloadLeaderboardEntries(leaderboard SteamLeaderboard_t, dataRequest ELeaderboardDataRequest, rangeStart, rangeEnd int) SteamAPICall_t {
-	v, err := theDLL.call(flatAPI_ISteamUserStats_DownloadLeaderboardEntries, uintptr(s), uintptr(leaderboard), uintptr(dataRequest), uintptr(rangeStart), uintptr(rangeEnd))
+	v, err := theDLL.call(flatAPI_ISteamUserStats_DownloadLeaderboardEntries, uintptr(s), leaderboard, uintptr(dataRequest), uintptr(rangeStart), uintptr(rangeEnd))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return 0
 	}
 
 	return SteamAPICall_t(v)
@@ -526,9 +1828,10 @@ func (s steamUserStats) downloadLeaderboardEntries(leaderboard SteamLeaderboard_
 func (s steamUserStats) downloadLeaderboardEntriesForUsers(leaderboard SteamLeaderboard_t, prgUsers []CSteamID) SteamAPICall_t {
 	prgUsers = append(prgUsers, 0)
 	defer runtime.KeepAlive(prgUsers)
-	v, err := theDLL.call(flatAPI_ISteamUserStats_DownloadLeaderboardEntriesForUsers, uintptr(s), uintptr(leaderboard), uintptr(unsafe.Pointer(&prgUsers[0])), uintptr(len(prgUsers)-1))
+	v, err := theDLL.call(flatAPI_ISteamUserStats_DownloadLeaderboardEntriesForUsers, uintptr(s), leaderboard, unsafe.Pointer(&prgUsers[0]), uintptr(len(prgUsers)-1))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return 0
 	}
 	return SteamAPICall_t(v)
 }
@@ -537,9 +1840,10 @@ func (s steamUserStats) getDownloadedLeaderboardEntry(entries SteamLeaderboardEn
 	details = make([]int32, detailsMax+1)
 	defer runtime.KeepAlive(details)
 	entryC := entry.CStruct()
-	v, err := theDLL.call(flatAPI_ISteamUserStats_GetDownloadedLeaderboardEntry, uintptr(s), uintptr(entries), uintptr(index), uintptr(unsafe.Pointer(&entryC)), uintptr(unsafe.Pointer(&details[0])), uintptr(detailsMax))
+	v, err := theDLL.call(flatAPI_ISteamUserStats_GetDownloadedLeaderboardEntry, uintptr(s), entries, uintptr(index), unsafe.Pointer(&entryC), unsafe.Pointer(&details[0]), uintptr(detailsMax))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return
 	}
 	entry = entry.FromCStruct(entryC)
 	details = details[:detailsMax]
@@ -547,99 +1851,80 @@ func (s steamUserStats) getDownloadedLeaderboardEntry(entries SteamLeaderboardEn
 	return
 }
 
-type DealLeaderboardFunc func(entry LeaderboardEntry_t, entryIndex int, entryCount int, details ...int32)
-type ReadTimeoutFunc func(readTime time.Time, readSpend time.Duration)
-
-func (s steamUserStats) ReadLeadboard(leaderboardName string, dataRequest ELeaderboardDataRequest, rangeStart, rangeEnd int, successFunc DealLeaderboardFunc, timeoutFunc ReadTimeoutFunc, detailsMax int) {
-	callbackAPI := s.findLeaderboard(leaderboardName)
-	defaultCallbackCli().setCallback(&CallbackArgs{
-		CallbackAPI:      callbackAPI,
-		CallbackExpected: iCallbackExpected_LeaderboardFindResult_t,
-		CallbaseSize:     int(LeaderboardFindResult_t{}.Size()),
-		SuccessFunc: func(ret []byte) {
-			data := LeaderboardFindResult_t{}.FromByte(ret)
-			downCall := s.downloadLeaderboardEntries(data.SteamLeaderboard, dataRequest, rangeStart, rangeEnd)
-			defaultCallbackCli().setCallback(&CallbackArgs{
-				CallbackAPI:      downCall,
-				CallbackExpected: iCallbackExpected_LeaderboardScoresDownloaded_t,
-				CallbaseSize:     int(LeaderboardScoresDownloaded_t{}.Size()),
-				SuccessFunc: func(ret []byte) {
-					l := LeaderboardScoresDownloaded_t{}.FromByte(ret)
-					steamLeaderboardEntries := l.SteamLeaderboardEntries
-					entryCount := l.EntryCount
-					if entryCount == 0 {
-						successFunc(LeaderboardEntry_t{}, -1, entryCount)
-						return
-					}
-					for i := 0; i < entryCount; i++ {
-						_, entry, details := s.getDownloadedLeaderboardEntry(steamLeaderboardEntries, i, detailsMax)
-						successFunc(entry, i, entryCount, details...)
-					}
-				},
-				TimeoutFunc: func(callbackTime time.Time, callbackSpend time.Duration) {
-					timeoutFunc(callbackTime, callbackSpend)
-				},
-			})
-		},
-		TimeoutFunc: func(callbackTime time.Time, callbackSpend time.Duration) {
-			timeoutFunc(callbackTime, callbackSpend)
-		},
-	})
-}
-
 func (s steamUserStats) uploadLeaderboardScore(leaderboard SteamLeaderboard_t, uploadScoreMethod ELeaderboardUploadScoreMethod, score int32, scoreDetails ...int32) SteamAPICall_t {
 	scoreDetails = append(scoreDetails, 0)
 	defer runtime.KeepAlive(scoreDetails)
-	v, err := theDLL.call(flatAPI_ISteamUserStats_UploadLeaderboardScore, uintptr(s), uintptr(leaderboard), uintptr(uploadScoreMethod), uintptr(score), uintptr(unsafe.Pointer(&scoreDetails[0])), uintptr(len(scoreDetails)-1))
+	v, err := theDLL.call(flatAPI_ISteamUserStats_UploadLeaderboardScore, uintptr(s), leaderboard, uintptr(uploadScoreMethod), uintptr(score), unsafe.Pointer(&scoreDetails[0]), uintptr(len(scoreDetails)-1))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return 0
 	}
 
 	return SteamAPICall_t(v)
 }
 
-type UploadRetFunc func(ret LeaderboardScoreUploaded_t)
-
-func (s steamUserStats) UploadLeaderboardScore(leaderboardName string, uploadScoreMethod ELeaderboardUploadScoreMethod, retFunc UploadRetFunc, timeoutFunc ReadTimeoutFunc, score int32, scoreDetails ...int32) {
-	callbackAPI := s.findLeaderboard(leaderboardName)
-	defaultCallbackCli().setCallback(&CallbackArgs{
-		CallbackAPI:      callbackAPI,
-		CallbackExpected: iCallbackExpected_LeaderboardFindResult_t,
-		CallbaseSize:     int(LeaderboardFindResult_t{}.Size()),
-		SuccessFunc: func(ret []byte) {
-			data := LeaderboardFindResult_t{}.FromByte(ret)
-			uploadCall := s.uploadLeaderboardScore(data.SteamLeaderboard, uploadScoreMethod, score, scoreDetails...)
-			defaultCallbackCli().setCallback(&CallbackArgs{
-				CallbackAPI:      uploadCall,
-				CallbackExpected: iCallbackExpected_LeaderboardScoreUploaded_t,
-				CallbaseSize:     int(LeaderboardScoreUploaded_t{}.Size()),
-				SuccessFunc: func(ret []byte) {
-					retFunc(LeaderboardScoreUploaded_t{}.FromByte(ret))
-				},
-				TimeoutFunc: func(callbackTime time.Time, callbackSpend time.Duration) {
-					timeoutFunc(callbackTime, callbackSpend)
-				},
-			})
-		},
-		TimeoutFunc: func(callbackTime time.Time, callbackSpend time.Duration) {
-			timeoutFunc(callbackTime, callbackSpend)
-		},
-	})
-}
 func SteamUtils() ISteamUtils {
+	s, err := getSteamUtils()
+	if err != nil {
+		handleError(err)
+	}
+	return s
+}
+
+func getSteamUtils() (steamUtils, error) {
 	v, err := theDLL.call(flatAPI_SteamUtils)
 	if err != nil {
-		panic(err)
+		return 0, err
 	}
-	return steamUtils(v)
+	return steamUtils(v), nil
 }
 
 type steamUtils uintptr
 
+func (s steamUtils) IsOverlayEnabled() bool {
+	v, err := theDLL.call(flatAPI_ISteamUtils_IsOverlayEnabled, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUtils) BOverlayNeedsPresent() bool {
+	v, err := theDLL.call(flatAPI_ISteamUtils_BOverlayNeedsPresent, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
+
+func (s steamUtils) SetOverlayNotificationPosition(position ENotificationPosition) {
+	if _, err := theDLL.call(flatAPI_ISteamUtils_SetOverlayNotificationPosition, uintptr(s), uintptr(position)); err != nil {
+		handleError(err)
+	}
+}
+
+func (s steamUtils) SetOverlayNotificationInset(horizontal, vertical int32) {
+	if _, err := theDLL.call(flatAPI_ISteamUtils_SetOverlayNotificationInset, uintptr(s), uintptr(horizontal), uintptr(vertical)); err != nil {
+		handleError(err)
+	}
+}
+
+func (s steamUtils) GetSteamUILanguage() string {
+	v, err := theDLL.call(flatAPI_ISteamUtils_GetSteamUILanguage, uintptr(s))
+	if err != nil {
+		handleError(err)
+		return ""
+	}
+	return cStringToGoString(uintptr(v), 256)
+}
+
 func (s steamUtils) IsSteamRunningOnSteamDeck() bool {
 	v, err := theDLL.call(flatAPI_ISteamUtils_IsSteamRunningOnSteamDeck, uintptr(s))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
 
 	return byte(v) != 0
@@ -648,17 +1933,64 @@ func (s steamUtils) IsSteamRunningOnSteamDeck() bool {
 func (s steamUtils) ShowFloatingGamepadTextInput(keyboardMode EFloatingGamepadTextInputMode, textFieldXPosition, textFieldYPosition, textFieldWidth, textFieldHeight int32) bool {
 	v, err := theDLL.call(flatAPI_ISteamUtils_ShowFloatingGamepadTextInput, uintptr(s), uintptr(keyboardMode), uintptr(textFieldXPosition), uintptr(textFieldYPosition), uintptr(textFieldWidth), uintptr(textFieldHeight))
 	if err != nil {
-		panic(err)
+		handleError(err)
+		return false
 	}
 	return byte(v) != 0
 }
 
 func (s steamUtils) GetAPICallResult(apiCall SteamAPICall_t, callbackExpected iCallbackExpected, callbaseSize int) (callback []byte, success bool, pbFailed bool) {
+	callback, success, pbFailed, err := s.apiCallResult(apiCall, callbackExpected, callbaseSize)
+	if err != nil {
+		handleError(err)
+	}
+	return callback, success, pbFailed
+}
+
+func (s steamUtils) apiCallResult(apiCall SteamAPICall_t, callbackExpected iCallbackExpected, callbaseSize int) (callback []byte, success bool, pbFailed bool, err error) {
 	callback = make([]byte, callbaseSize)
-	v, err := theDLL.call(flatAPI_ISteamUtils_GetAPICallResult, uintptr(s), uintptr(apiCall), uintptr(unsafe.Pointer(&callback[0])), uintptr(callbaseSize), uintptr(callbackExpected), uintptr(unsafe.Pointer(&pbFailed)))
+	v, err := theDLL.call(flatAPI_ISteamUtils_GetAPICallResult, uintptr(s), apiCall, unsafe.Pointer(&callback[0]), uintptr(callbaseSize), uintptr(callbackExpected), unsafe.Pointer(&pbFailed))
 	if err != nil {
-		panic(err)
+		return nil, false, false, err
 	}
 	success = byte(v) != 0
 	return
 }
+
+func (s steamUtils) GetAPICallFailureReason(apiCall SteamAPICall_t) ESteamAPICallFailure {
+	reason, err := s.apiCallFailureReason(apiCall)
+	if err != nil {
+		handleError(err)
+		return ESteamAPICallFailure_None
+	}
+	return reason
+}
+
+func (s steamUtils) apiCallFailureReason(apiCall SteamAPICall_t) (ESteamAPICallFailure, error) {
+	v, err := theDLL.call(flatAPI_ISteamUtils_GetAPICallFailureReason, uintptr(s), apiCall)
+	if err != nil {
+		return ESteamAPICallFailure_None, err
+	}
+	return ESteamAPICallFailure(int32(v)), nil
+}
+
+func (s steamUtils) GetImageSize(image int32) (width, height uint32, success bool) {
+	v, err := theDLL.call(flatAPI_ISteamUtils_GetImageSize, uintptr(s), uintptr(image), unsafe.Pointer(&width), unsafe.Pointer(&height))
+	if err != nil {
+		handleError(err)
+		return 0, 0, false
+	}
+	return width, height, byte(v) != 0
+}
+
+func (s steamUtils) GetImageRGBA(image int32, dest []byte) bool {
+	if len(dest) == 0 {
+		return false
+	}
+	v, err := theDLL.call(flatAPI_ISteamUtils_GetImageRGBA, uintptr(s), uintptr(image), unsafe.Pointer(&dest[0]), uintptr(len(dest)))
+	if err != nil {
+		handleError(err)
+		return false
+	}
+	return byte(v) != 0
+}
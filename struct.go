@@ -2,21 +2,38 @@ package steamworks
 
 import (
 	"reflect"
+	"strings"
+	"time"
 	"unsafe"
 )
 
 /*
-typedef unsigned long long int SteamLeaderboard_t;
-typedef unsigned long long int SteamLeaderboardEntries_t;
+// Steam packs its callback structs to 4 bytes on Linux and macOS and to 8 bytes
+// on Windows. 64-bit members are split into halves so that cgo can represent
+// them at either alignment.
+#if defined(_WIN32)
+typedef struct __attribute__((aligned(8))) {
+	unsigned int lo;
+	unsigned int hi;
+} uint64_steam;
+#else
+typedef struct {
+	unsigned int lo;
+	unsigned int hi;
+} uint64_steam;
+#endif
+
+typedef uint64_steam SteamLeaderboard_t;
+typedef uint64_steam SteamLeaderboardEntries_t;
 typedef unsigned char uint8;
 typedef unsigned int EResult;
 
 typedef struct {
-	unsigned long int m_steamIDUser;
+	uint64_steam m_steamIDUser;
 	int m_nGlobalRank;
 	int m_nScore;
 	int m_cDetails;
-	unsigned long int m_hUGC;
+	uint64_steam m_hUGC;
 } LeaderboardEntry_t;
 
 typedef struct {
@@ -40,19 +57,227 @@ typedef struct{
 }LeaderboardScoreUploaded_t;
 
 typedef struct {
-	unsigned long long int m_nGameID;
+	uint64_steam m_nGameID;
 	EResult m_eResult;
-	unsigned long long int m_steamIDUser;
+	uint64_steam m_steamIDUser;
 }UserStatsReceived_t;
 
 
 typedef struct {
-	unsigned long long int m_nGameID;
+	uint64_steam m_nGameID;
 	EResult m_eResult;
 }GlobalStatsReceived_t;
+
+typedef struct {
+	uint64_steam m_nGameID;
+	EResult m_eResult;
+} UserStatsStored_t;
+
+typedef struct {
+	EResult m_eResult;
+	SteamLeaderboard_t m_hSteamLeaderboard;
+} LeaderboardUGCSet_t;
+
+typedef struct {
+	EResult m_eResult;
+	uint64_steam m_hFile;
+	char m_rgchFilename[260];
+} RemoteStorageFileShareResult_t;
+
+typedef struct {
+	EResult m_eResult;
+	uint64_steam m_hFile;
+	unsigned int m_nAppID;
+	int m_nSizeInBytes;
+	char m_pchFileName[260];
+	uint64_steam m_ulSteamIDOwner;
+} RemoteStorageDownloadUGCResult_t;
+
+typedef struct {
+	uint64_steam m_hFileReadAsync;
+	EResult m_eResult;
+	unsigned int m_nOffset;
+	unsigned int m_cubRead;
+} RemoteStorageFileReadAsyncComplete_t;
+
+typedef struct {
+	EResult m_eResult;
+} RemoteStorageFileWriteAsyncComplete_t;
+
+typedef struct {
+	uint64_steam m_nGameID;
+	char m_rgchAchievementName[128];
+	uint8 m_bAchieved;
+	int m_nIconHandle;
+} UserAchievementIconFetched_t;
+
+typedef struct {
+	uint64_steam m_nGameID;
+	EResult m_eResult;
+} GlobalAchievementPercentagesReady_t;
+
+typedef struct {
+	int m_hSteamUser;
+	int m_iCallback;
+	uint8 *m_pubParam;
+	int m_cubParam;
+} CallbackMsg_t;
+
+typedef struct {
+	uint64_steam m_hAsyncCall;
+	int m_iCallback;
+	unsigned int m_cubParam;
+} SteamAPICallCompleted_t;
+
+typedef struct {
+	uint64_steam m_ulSteamID;
+	int m_nChangeFlags;
+} PersonaStateChange_t;
+
+typedef struct {
+	uint8 m_bActive;
+	uint8 m_bUserInitiated;
+	unsigned int m_nAppID;
+	unsigned int m_dwOverlayPID;
+} GameOverlayActivated_t;
+
+typedef struct {
+	uint64_steam m_steamID;
+	int m_iImage;
+	int m_iWide;
+	int m_iTall;
+} AvatarImageLoaded_t;
+
+typedef struct {
+	uint64_steam m_gameID;
+	unsigned int m_unGameIP;
+	unsigned short m_usGamePort;
+	unsigned short m_usQueryPort;
+	uint64_steam m_steamIDLobby;
+} FriendGameInfo_t;
+
+typedef struct {
+	uint8 m_bSubmitted;
+	unsigned int m_unSubmittedText;
+	unsigned int m_unAppID;
+} GamepadTextInputDismissed_t;
+
+typedef struct {
+	unsigned int m_nAppID;
+} DlcInstalled_t;
+
+typedef struct {
+	uint8 m_unused;
+} NewUrlLaunchParameters_t;
+
+typedef struct {
+	EResult m_eResult;
+	unsigned int m_nAppID;
+	unsigned int m_cchKeyLength;
+	char m_rgchKey[240];
+} AppProofOfPurchaseKeyResponse_t;
+
+typedef struct {
+	uint64_steam m_nPublishedFileId;
+	EResult m_eResult;
+	int m_eFileType;
+	unsigned int m_nCreatorAppID;
+	unsigned int m_nConsumerAppID;
+	char m_rgchTitle[129];
+	char m_rgchDescription[8000];
+	uint64_steam m_ulSteamIDOwner;
+	unsigned int m_rtimeCreated;
+	unsigned int m_rtimeUpdated;
+	unsigned int m_rtimeAddedToUserList;
+	int m_eVisibility;
+	uint8 m_bBanned;
+	uint8 m_bAcceptedForUse;
+	uint8 m_bTagsTruncated;
+	char m_rgchTags[1025];
+	uint64_steam m_hFile;
+	uint64_steam m_hPreviewFile;
+	char m_pchFileName[260];
+	int m_nFileSize;
+	int m_nPreviewFileSize;
+	char m_rgchURL[256];
+	unsigned int m_unVotesUp;
+	unsigned int m_unVotesDown;
+	float m_flScore;
+	unsigned int m_unNumChildren;
+	uint64_steam m_ulTotalFilesSize;
+} SteamUGCDetails_t;
+
+typedef struct {
+	uint64_steam m_handle;
+	EResult m_eResult;
+	unsigned int m_unNumResultsReturned;
+	unsigned int m_unTotalMatchingResults;
+	uint8 m_bCachedData;
+	char m_rgchNextCursor[256];
+} SteamUGCQueryCompleted_t;
+
+typedef struct {
+	EResult m_eResult;
+	uint64_steam m_nPublishedFileId;
+} RemoteStorageSubscribePublishedFileResult_t;
+
+typedef struct {
+	EResult m_eResult;
+	uint64_steam m_nPublishedFileId;
+} RemoteStorageUnsubscribePublishedFileResult_t;
+
+typedef struct {
+	EResult m_eResult;
+	uint64_steam m_nPublishedFileId;
+	uint8 m_bUserNeedsToAcceptWorkshopLegalAgreement;
+} CreateItemResult_t;
+
+typedef struct {
+	EResult m_eResult;
+	uint8 m_bUserNeedsToAcceptWorkshopLegalAgreement;
+	uint64_steam m_nPublishedFileId;
+} SubmitItemUpdateResult_t;
+
+typedef struct {
+	unsigned int m_unAppID;
+	uint64_steam m_nPublishedFileId;
+} ItemInstalled_t;
+
+typedef struct {
+	unsigned int m_unAppID;
+	uint64_steam m_nPublishedFileId;
+	EResult m_eResult;
+} DownloadItemResult_t;
 */
 import "C"
 
+func uint64FromC(v C.uint64_steam) uint64 {
+	return uint64(v.lo) | uint64(v.hi)<<32
+}
+
+func uint64ToC(v uint64) C.uint64_steam {
+	return C.uint64_steam{lo: C.uint(v), hi: C.uint(v >> 32)}
+}
+
+func boolToC(x bool) C.uint8 {
+	if x {
+		return 1
+	}
+	return 0
+}
+
+// cBytes copies a C struct into a byte slice, the way Steam hands callbacks out.
+func cBytes(p unsafe.Pointer, size uintptr) []byte {
+	return C.GoBytes(p, C.int(size))
+}
+
+// putCString copies s into the char array dst, truncating it to leave room for the terminating NUL.
+func putCString(dst []C.char, s string) {
+	for i := 0; i < len(s) && i < len(dst)-1; i++ {
+		dst[i] = C.char(s[i])
+	}
+}
+
 type IStruct interface {
 	Size() uintptr
 	CStructPtr() uintptr
@@ -70,7 +295,7 @@ type LeaderboardScoreUploaded_t struct {
 func (l LeaderboardScoreUploaded_t) FromCStruct(cstruct C.LeaderboardScoreUploaded_t) LeaderboardScoreUploaded_t {
 	return LeaderboardScoreUploaded_t{
 		Success:            cstruct.m_bSuccess != 0,
-		SteamLeaderboard:   SteamLeaderboard_t(cstruct.m_hSteamLeaderboard),
+		SteamLeaderboard:   SteamLeaderboard_t(uint64FromC(cstruct.m_hSteamLeaderboard)),
 		Score:              int(cstruct.m_nScore),
 		ScoreChanged:       cstruct.m_bScoreChanged != 0,
 		GlobalRankNew:      int(cstruct.m_nGlobalRankNew),
@@ -90,6 +315,249 @@ func (l LeaderboardScoreUploaded_t) Size() uintptr {
 	return reflect.TypeOf(l.CStruct()).Size()
 }
 
+func (l LeaderboardScoreUploaded_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_LeaderboardScoreUploaded_t
+}
+
+func (l LeaderboardScoreUploaded_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l LeaderboardScoreUploaded_t) encode() []byte {
+	c := C.LeaderboardScoreUploaded_t{
+		m_bSuccess:            boolToC(l.Success),
+		m_hSteamLeaderboard:   uint64ToC(uint64(l.SteamLeaderboard)),
+		m_nScore:              C.int(l.Score),
+		m_bScoreChanged:       boolToC(l.ScoreChanged),
+		m_nGlobalRankNew:      C.int(l.GlobalRankNew),
+		m_nGlobalRankPrevious: C.int(l.GlobalRankPrevious),
+	}
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
+// LeaderboardUGCSet_t is the result of AttachLeaderboardUGC.
+type LeaderboardUGCSet_t struct {
+	Result           EResult
+	SteamLeaderboard SteamLeaderboard_t
+}
+
+func (l LeaderboardUGCSet_t) FromByte(b []byte) LeaderboardUGCSet_t {
+	return l.FromCStruct(**(**C.LeaderboardUGCSet_t)(unsafe.Pointer(&b)))
+}
+
+func (l LeaderboardUGCSet_t) FromCStruct(cstruct C.LeaderboardUGCSet_t) LeaderboardUGCSet_t {
+	return LeaderboardUGCSet_t{
+		Result:           EResult(cstruct.m_eResult),
+		SteamLeaderboard: SteamLeaderboard_t(uint64FromC(cstruct.m_hSteamLeaderboard)),
+	}
+}
+
+func (l LeaderboardUGCSet_t) CStruct() C.LeaderboardUGCSet_t {
+	return C.LeaderboardUGCSet_t{}
+}
+
+func (l LeaderboardUGCSet_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+func (l LeaderboardUGCSet_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_LeaderboardUGCSet_t
+}
+
+func (l LeaderboardUGCSet_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l LeaderboardUGCSet_t) encode() []byte {
+	c := C.LeaderboardUGCSet_t{
+		m_eResult:           C.EResult(l.Result),
+		m_hSteamLeaderboard: uint64ToC(uint64(l.SteamLeaderboard)),
+	}
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
+// RemoteStorageFileReadAsyncComplete_t is the result of FileReadAsync.
+type RemoteStorageFileReadAsyncComplete_t struct {
+	FileReadAsync SteamAPICall_t
+	Result        EResult
+	Offset        uint32
+	Read          uint32
+}
+
+func (l RemoteStorageFileReadAsyncComplete_t) FromByte(b []byte) RemoteStorageFileReadAsyncComplete_t {
+	return l.FromCStruct(**(**C.RemoteStorageFileReadAsyncComplete_t)(unsafe.Pointer(&b)))
+}
+
+func (l RemoteStorageFileReadAsyncComplete_t) FromCStruct(cstruct C.RemoteStorageFileReadAsyncComplete_t) RemoteStorageFileReadAsyncComplete_t {
+	return RemoteStorageFileReadAsyncComplete_t{
+		FileReadAsync: SteamAPICall_t(uint64FromC(cstruct.m_hFileReadAsync)),
+		Result:        EResult(cstruct.m_eResult),
+		Offset:        uint32(cstruct.m_nOffset),
+		Read:          uint32(cstruct.m_cubRead),
+	}
+}
+
+func (l RemoteStorageFileReadAsyncComplete_t) CStruct() C.RemoteStorageFileReadAsyncComplete_t {
+	return C.RemoteStorageFileReadAsyncComplete_t{}
+}
+
+func (l RemoteStorageFileReadAsyncComplete_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+func (l RemoteStorageFileReadAsyncComplete_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_RemoteStorageFileReadAsyncComplete_t
+}
+
+func (l RemoteStorageFileReadAsyncComplete_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l RemoteStorageFileReadAsyncComplete_t) encode() []byte {
+	c := C.RemoteStorageFileReadAsyncComplete_t{
+		m_hFileReadAsync: uint64ToC(uint64(l.FileReadAsync)),
+		m_eResult:        C.EResult(l.Result),
+		m_nOffset:        C.uint(l.Offset),
+		m_cubRead:        C.uint(l.Read),
+	}
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
+// RemoteStorageFileWriteAsyncComplete_t is the result of FileWriteAsync.
+type RemoteStorageFileWriteAsyncComplete_t struct {
+	Result EResult
+}
+
+func (l RemoteStorageFileWriteAsyncComplete_t) FromByte(b []byte) RemoteStorageFileWriteAsyncComplete_t {
+	return l.FromCStruct(**(**C.RemoteStorageFileWriteAsyncComplete_t)(unsafe.Pointer(&b)))
+}
+
+func (l RemoteStorageFileWriteAsyncComplete_t) FromCStruct(cstruct C.RemoteStorageFileWriteAsyncComplete_t) RemoteStorageFileWriteAsyncComplete_t {
+	return RemoteStorageFileWriteAsyncComplete_t{
+		Result: EResult(cstruct.m_eResult),
+	}
+}
+
+func (l RemoteStorageFileWriteAsyncComplete_t) CStruct() C.RemoteStorageFileWriteAsyncComplete_t {
+	return C.RemoteStorageFileWriteAsyncComplete_t{}
+}
+
+func (l RemoteStorageFileWriteAsyncComplete_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+func (l RemoteStorageFileWriteAsyncComplete_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_RemoteStorageFileWriteAsyncComplete_t
+}
+
+func (l RemoteStorageFileWriteAsyncComplete_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l RemoteStorageFileWriteAsyncComplete_t) encode() []byte {
+	c := C.RemoteStorageFileWriteAsyncComplete_t{
+		m_eResult: C.EResult(l.Result),
+	}
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
+// RemoteStorageFileShareResult_t is the result of FileShare.
+type RemoteStorageFileShareResult_t struct {
+	Result   EResult
+	File     UGCHandle_t
+	Filename string
+}
+
+func (l RemoteStorageFileShareResult_t) FromByte(b []byte) RemoteStorageFileShareResult_t {
+	return l.FromCStruct(**(**C.RemoteStorageFileShareResult_t)(unsafe.Pointer(&b)))
+}
+
+func (l RemoteStorageFileShareResult_t) FromCStruct(cstruct C.RemoteStorageFileShareResult_t) RemoteStorageFileShareResult_t {
+	return RemoteStorageFileShareResult_t{
+		Result:   EResult(cstruct.m_eResult),
+		File:     UGCHandle_t(uint64FromC(cstruct.m_hFile)),
+		Filename: C.GoString(&cstruct.m_rgchFilename[0]),
+	}
+}
+
+func (l RemoteStorageFileShareResult_t) CStruct() C.RemoteStorageFileShareResult_t {
+	return C.RemoteStorageFileShareResult_t{}
+}
+
+func (l RemoteStorageFileShareResult_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+func (l RemoteStorageFileShareResult_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_RemoteStorageFileShareResult_t
+}
+
+func (l RemoteStorageFileShareResult_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l RemoteStorageFileShareResult_t) encode() []byte {
+	c := C.RemoteStorageFileShareResult_t{
+		m_eResult: C.EResult(l.Result),
+		m_hFile:   uint64ToC(uint64(l.File)),
+	}
+	putCString(c.m_rgchFilename[:], l.Filename)
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
+// RemoteStorageDownloadUGCResult_t is the result of UGCDownload.
+type RemoteStorageDownloadUGCResult_t struct {
+	Result       EResult
+	File         UGCHandle_t
+	AppID        AppId_t
+	SizeInBytes  int32
+	FileName     string
+	SteamIDOwner CSteamID
+}
+
+func (l RemoteStorageDownloadUGCResult_t) FromByte(b []byte) RemoteStorageDownloadUGCResult_t {
+	return l.FromCStruct(**(**C.RemoteStorageDownloadUGCResult_t)(unsafe.Pointer(&b)))
+}
+
+func (l RemoteStorageDownloadUGCResult_t) FromCStruct(cstruct C.RemoteStorageDownloadUGCResult_t) RemoteStorageDownloadUGCResult_t {
+	return RemoteStorageDownloadUGCResult_t{
+		Result:       EResult(cstruct.m_eResult),
+		File:         UGCHandle_t(uint64FromC(cstruct.m_hFile)),
+		AppID:        AppId_t(cstruct.m_nAppID),
+		SizeInBytes:  int32(cstruct.m_nSizeInBytes),
+		FileName:     C.GoString(&cstruct.m_pchFileName[0]),
+		SteamIDOwner: CSteamID(uint64FromC(cstruct.m_ulSteamIDOwner)),
+	}
+}
+
+func (l RemoteStorageDownloadUGCResult_t) CStruct() C.RemoteStorageDownloadUGCResult_t {
+	return C.RemoteStorageDownloadUGCResult_t{}
+}
+
+func (l RemoteStorageDownloadUGCResult_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+func (l RemoteStorageDownloadUGCResult_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_RemoteStorageDownloadUGCResult_t
+}
+
+func (l RemoteStorageDownloadUGCResult_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l RemoteStorageDownloadUGCResult_t) encode() []byte {
+	c := C.RemoteStorageDownloadUGCResult_t{
+		m_eResult:        C.EResult(l.Result),
+		m_hFile:          uint64ToC(uint64(l.File)),
+		m_nAppID:         C.uint(l.AppID),
+		m_nSizeInBytes:   C.int(l.SizeInBytes),
+		m_ulSteamIDOwner: uint64ToC(uint64(l.SteamIDOwner)),
+	}
+	putCString(c.m_pchFileName[:], l.FileName)
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
 type LeaderboardScoresDownloaded_t struct {
 	SteamLeaderboard        SteamLeaderboard_t
 	SteamLeaderboardEntries SteamLeaderboardEntries_t
@@ -98,8 +566,8 @@ type LeaderboardScoresDownloaded_t struct {
 
 func (l LeaderboardScoresDownloaded_t) FromCStruct(cstruct C.LeaderboardScoresDownloaded_t) LeaderboardScoresDownloaded_t {
 	return LeaderboardScoresDownloaded_t{
-		SteamLeaderboard:        SteamLeaderboard_t(cstruct.m_hSteamLeaderboard),
-		SteamLeaderboardEntries: SteamLeaderboardEntries_t(cstruct.m_hSteamLeaderboardEntries),
+		SteamLeaderboard:        SteamLeaderboard_t(uint64FromC(cstruct.m_hSteamLeaderboard)),
+		SteamLeaderboardEntries: SteamLeaderboardEntries_t(uint64FromC(cstruct.m_hSteamLeaderboardEntries)),
 		EntryCount:              int(cstruct.m_cEntryCount),
 	}
 }
@@ -116,6 +584,23 @@ func (l LeaderboardScoresDownloaded_t) Size() uintptr {
 	return reflect.TypeOf(l.CStruct()).Size()
 }
 
+func (l LeaderboardScoresDownloaded_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_LeaderboardScoresDownloaded_t
+}
+
+func (l LeaderboardScoresDownloaded_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l LeaderboardScoresDownloaded_t) encode() []byte {
+	c := C.LeaderboardScoresDownloaded_t{
+		m_hSteamLeaderboard:        uint64ToC(uint64(l.SteamLeaderboard)),
+		m_hSteamLeaderboardEntries: uint64ToC(uint64(l.SteamLeaderboardEntries)),
+		m_cEntryCount:              C.int(l.EntryCount),
+	}
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
 type LeaderboardFindResult_t struct {
 	SteamLeaderboard SteamLeaderboard_t
 	LeaderboardFound bool
@@ -127,7 +612,7 @@ func (l LeaderboardFindResult_t) FromByte(b []byte) LeaderboardFindResult_t {
 
 func (l LeaderboardFindResult_t) FromCStruct(cstruct C.LeaderboardFindResult_t) LeaderboardFindResult_t {
 	return LeaderboardFindResult_t{
-		SteamLeaderboard: SteamLeaderboard_t(cstruct.m_hSteamLeaderboard),
+		SteamLeaderboard: SteamLeaderboard_t(uint64FromC(cstruct.m_hSteamLeaderboard)),
 		LeaderboardFound: cstruct.m_bLeaderboardFound != 0,
 	}
 }
@@ -140,7 +625,36 @@ func (l LeaderboardFindResult_t) Size() uintptr {
 	return reflect.TypeOf(l.CStruct()).Size()
 }
 
+func (l LeaderboardFindResult_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_LeaderboardFindResult_t
+}
+
+func (l LeaderboardFindResult_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l LeaderboardFindResult_t) encode() []byte {
+	c := C.LeaderboardFindResult_t{
+		m_hSteamLeaderboard: uint64ToC(uint64(l.SteamLeaderboard)),
+		m_bLeaderboardFound: boolToC(l.LeaderboardFound),
+	}
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
 type EResult int
+
+const (
+	EResult_OK            EResult = 1
+	EResult_Fail          EResult = 2
+	EResult_NoConnection  EResult = 3
+	EResult_InvalidParam  EResult = 8
+	EResult_FileNotFound  EResult = 9
+	EResult_Busy          EResult = 10
+	EResult_AccessDenied  EResult = 15
+	EResult_Timeout       EResult = 16
+	EResult_LimitExceeded EResult = 25
+)
+
 type UserStatsReceived_t struct {
 	GameID  int
 	Result  EResult
@@ -153,9 +667,9 @@ func (l UserStatsReceived_t) FromByte(b []byte) UserStatsReceived_t {
 
 func (l UserStatsReceived_t) FromCStruct(cstruct C.UserStatsReceived_t) UserStatsReceived_t {
 	return UserStatsReceived_t{
-		GameID:  int(cstruct.m_nGameID),
+		GameID:  int(uint64FromC(cstruct.m_nGameID)),
 		Result:  EResult(cstruct.m_eResult),
-		SteamID: CSteamID(cstruct.m_steamIDUser),
+		SteamID: CSteamID(uint64FromC(cstruct.m_steamIDUser)),
 	}
 }
 
@@ -167,52 +681,1077 @@ func (l UserStatsReceived_t) Size() uintptr {
 	return reflect.TypeOf(l.CStruct()).Size()
 }
 
-type GlobalStatsReceived_t struct {
+func (l UserStatsReceived_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_UserStatsReceived_t
+}
+
+func (l UserStatsReceived_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l UserStatsReceived_t) encode() []byte {
+	c := C.UserStatsReceived_t{
+		m_nGameID:     uint64ToC(uint64(l.GameID)),
+		m_eResult:     C.EResult(l.Result),
+		m_steamIDUser: uint64ToC(uint64(l.SteamID)),
+	}
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
+// UserStatsStored_t is posted after StoreStats. Subscribe to it to know whether the stats were saved.
+type UserStatsStored_t struct {
 	GameID int
 	Result EResult
 }
 
-func (l GlobalStatsReceived_t) FromByte(b []byte) GlobalStatsReceived_t {
-	return l.FromCStruct(**(**C.GlobalStatsReceived_t)(unsafe.Pointer(&b)))
+func (l UserStatsStored_t) FromByte(b []byte) UserStatsStored_t {
+	return l.FromCStruct(**(**C.UserStatsStored_t)(unsafe.Pointer(&b)))
 }
 
-func (l GlobalStatsReceived_t) FromCStruct(cstruct C.GlobalStatsReceived_t) GlobalStatsReceived_t {
-	return GlobalStatsReceived_t{
-		GameID: int(cstruct.m_nGameID),
+func (l UserStatsStored_t) FromCStruct(cstruct C.UserStatsStored_t) UserStatsStored_t {
+	return UserStatsStored_t{
+		GameID: int(uint64FromC(cstruct.m_nGameID)),
 		Result: EResult(cstruct.m_eResult),
 	}
 }
 
-func (l GlobalStatsReceived_t) CStruct() C.GlobalStatsReceived_t {
-	return C.GlobalStatsReceived_t{}
+func (l UserStatsStored_t) CStruct() C.UserStatsStored_t {
+	return C.UserStatsStored_t{}
 }
 
-func (l GlobalStatsReceived_t) Size() uintptr {
+func (l UserStatsStored_t) Size() uintptr {
 	return reflect.TypeOf(l.CStruct()).Size()
 }
 
-type LeaderboardEntry_t struct {
-	SteamIDUser CSteamID
-	GlobalRank  int
-	Score       int
-	Details     int
-	UGC         UGCHandle_t
+func (l UserStatsStored_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_UserStatsStored_t
 }
 
-func (l LeaderboardEntry_t) FromCStruct(cstruct C.LeaderboardEntry_t) LeaderboardEntry_t {
-	return LeaderboardEntry_t{
-		SteamIDUser: CSteamID(cstruct.m_steamIDUser),
-		GlobalRank:  int(cstruct.m_nGlobalRank),
-		Score:       int(cstruct.m_nScore),
-		Details:     int(cstruct.m_cDetails),
-		UGC:         UGCHandle_t(cstruct.m_hUGC),
+func (l UserStatsStored_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l UserStatsStored_t) encode() []byte {
+	c := C.UserStatsStored_t{
+		m_nGameID: uint64ToC(uint64(l.GameID)),
+		m_eResult: C.EResult(l.Result),
 	}
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
 }
 
-func (l LeaderboardEntry_t) CStruct() C.LeaderboardEntry_t {
-	return C.LeaderboardEntry_t{}
+// UserAchievementIconFetched_t is posted when an icon requested by GetAchievementIcon has been fetched.
+type UserAchievementIconFetched_t struct {
+	GameID          int
+	AchievementName string
+	Achieved        bool
+	IconHandle      int32
 }
 
-func (l LeaderboardEntry_t) Size() uintptr {
+func (l UserAchievementIconFetched_t) FromByte(b []byte) UserAchievementIconFetched_t {
+	return l.FromCStruct(**(**C.UserAchievementIconFetched_t)(unsafe.Pointer(&b)))
+}
+
+func (l UserAchievementIconFetched_t) FromCStruct(cstruct C.UserAchievementIconFetched_t) UserAchievementIconFetched_t {
+	return UserAchievementIconFetched_t{
+		GameID:          int(uint64FromC(cstruct.m_nGameID)),
+		AchievementName: C.GoString(&cstruct.m_rgchAchievementName[0]),
+		Achieved:        cstruct.m_bAchieved != 0,
+		IconHandle:      int32(cstruct.m_nIconHandle),
+	}
+}
+
+func (l UserAchievementIconFetched_t) CStruct() C.UserAchievementIconFetched_t {
+	return C.UserAchievementIconFetched_t{}
+}
+
+func (l UserAchievementIconFetched_t) Size() uintptr {
 	return reflect.TypeOf(l.CStruct()).Size()
 }
+
+func (l UserAchievementIconFetched_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_UserAchievementIconFetched_t
+}
+
+func (l UserAchievementIconFetched_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l UserAchievementIconFetched_t) encode() []byte {
+	c := C.UserAchievementIconFetched_t{
+		m_nGameID:     uint64ToC(uint64(l.GameID)),
+		m_bAchieved:   boolToC(l.Achieved),
+		m_nIconHandle: C.int(l.IconHandle),
+	}
+	putCString(c.m_rgchAchievementName[:], l.AchievementName)
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
+type GlobalAchievementPercentagesReady_t struct {
+	GameID int
+	Result EResult
+}
+
+func (l GlobalAchievementPercentagesReady_t) FromByte(b []byte) GlobalAchievementPercentagesReady_t {
+	return l.FromCStruct(**(**C.GlobalAchievementPercentagesReady_t)(unsafe.Pointer(&b)))
+}
+
+func (l GlobalAchievementPercentagesReady_t) FromCStruct(cstruct C.GlobalAchievementPercentagesReady_t) GlobalAchievementPercentagesReady_t {
+	return GlobalAchievementPercentagesReady_t{
+		GameID: int(uint64FromC(cstruct.m_nGameID)),
+		Result: EResult(cstruct.m_eResult),
+	}
+}
+
+func (l GlobalAchievementPercentagesReady_t) CStruct() C.GlobalAchievementPercentagesReady_t {
+	return C.GlobalAchievementPercentagesReady_t{}
+}
+
+func (l GlobalAchievementPercentagesReady_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+func (l GlobalAchievementPercentagesReady_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_GlobalAchievementPercentagesReady_t
+}
+
+func (l GlobalAchievementPercentagesReady_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l GlobalAchievementPercentagesReady_t) encode() []byte {
+	c := C.GlobalAchievementPercentagesReady_t{
+		m_nGameID: uint64ToC(uint64(l.GameID)),
+		m_eResult: C.EResult(l.Result),
+	}
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
+type GlobalStatsReceived_t struct {
+	GameID int
+	Result EResult
+}
+
+func (l GlobalStatsReceived_t) FromByte(b []byte) GlobalStatsReceived_t {
+	return l.FromCStruct(**(**C.GlobalStatsReceived_t)(unsafe.Pointer(&b)))
+}
+
+func (l GlobalStatsReceived_t) FromCStruct(cstruct C.GlobalStatsReceived_t) GlobalStatsReceived_t {
+	return GlobalStatsReceived_t{
+		GameID: int(uint64FromC(cstruct.m_nGameID)),
+		Result: EResult(cstruct.m_eResult),
+	}
+}
+
+func (l GlobalStatsReceived_t) CStruct() C.GlobalStatsReceived_t {
+	return C.GlobalStatsReceived_t{}
+}
+
+func (l GlobalStatsReceived_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+func (l GlobalStatsReceived_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_GlobalStatsReceived_t
+}
+
+func (l GlobalStatsReceived_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l GlobalStatsReceived_t) encode() []byte {
+	c := C.GlobalStatsReceived_t{
+		m_nGameID: uint64ToC(uint64(l.GameID)),
+		m_eResult: C.EResult(l.Result),
+	}
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
+type LeaderboardEntry_t struct {
+	SteamIDUser CSteamID
+	GlobalRank  int
+	Score       int
+	Details     int
+	UGC         UGCHandle_t
+}
+
+func (l LeaderboardEntry_t) FromCStruct(cstruct C.LeaderboardEntry_t) LeaderboardEntry_t {
+	return LeaderboardEntry_t{
+		SteamIDUser: CSteamID(uint64FromC(cstruct.m_steamIDUser)),
+		GlobalRank:  int(cstruct.m_nGlobalRank),
+		Score:       int(cstruct.m_nScore),
+		Details:     int(cstruct.m_cDetails),
+		UGC:         UGCHandle_t(uint64FromC(cstruct.m_hUGC)),
+	}
+}
+
+func (l LeaderboardEntry_t) CStruct() C.LeaderboardEntry_t {
+	return C.LeaderboardEntry_t{}
+}
+
+func (l LeaderboardEntry_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+// put stores l at p, a C LeaderboardEntry_t.
+func (l LeaderboardEntry_t) put(p unsafe.Pointer) {
+	*(*C.LeaderboardEntry_t)(p) = C.LeaderboardEntry_t{
+		m_steamIDUser: uint64ToC(uint64(l.SteamIDUser)),
+		m_nGlobalRank: C.int(l.GlobalRank),
+		m_nScore:      C.int(l.Score),
+		m_cDetails:    C.int(l.Details),
+		m_hUGC:        uint64ToC(uint64(l.UGC)),
+	}
+}
+
+// callbackMsg is a callback pulled by manual dispatch. Param is a copy, so it stays valid after FreeLastCallback.
+type callbackMsg struct {
+	ID    iCallbackExpected
+	Param []byte
+}
+
+func (l callbackMsg) FromCStruct(cstruct C.CallbackMsg_t) callbackMsg {
+	return callbackMsg{
+		ID:    iCallbackExpected(cstruct.m_iCallback),
+		Param: C.GoBytes(unsafe.Pointer(cstruct.m_pubParam), cstruct.m_cubParam),
+	}
+}
+
+func (l callbackMsg) CStruct() C.CallbackMsg_t {
+	return C.CallbackMsg_t{}
+}
+
+// put stores l at p, a C CallbackMsg_t. Param must stay alive until the message is freed.
+func (l callbackMsg) put(p unsafe.Pointer) {
+	c := (*C.CallbackMsg_t)(p)
+	c.m_iCallback = C.int(l.ID)
+	c.m_pubParam = nil
+	if len(l.Param) > 0 {
+		c.m_pubParam = (*C.uint8)(unsafe.Pointer(&l.Param[0]))
+	}
+	c.m_cubParam = C.int(len(l.Param))
+}
+
+type SteamAPICallCompleted_t struct {
+	AsyncCall SteamAPICall_t
+	Callback  iCallbackExpected
+	ParamSize uint32
+}
+
+func (l SteamAPICallCompleted_t) FromByte(b []byte) SteamAPICallCompleted_t {
+	return l.FromCStruct(**(**C.SteamAPICallCompleted_t)(unsafe.Pointer(&b)))
+}
+
+func (l SteamAPICallCompleted_t) FromCStruct(cstruct C.SteamAPICallCompleted_t) SteamAPICallCompleted_t {
+	return SteamAPICallCompleted_t{
+		AsyncCall: SteamAPICall_t(uint64FromC(cstruct.m_hAsyncCall)),
+		Callback:  iCallbackExpected(cstruct.m_iCallback),
+		ParamSize: uint32(cstruct.m_cubParam),
+	}
+}
+
+func (l SteamAPICallCompleted_t) CStruct() C.SteamAPICallCompleted_t {
+	return C.SteamAPICallCompleted_t{}
+}
+
+func (l SteamAPICallCompleted_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+func (l SteamAPICallCompleted_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_SteamAPICallCompleted_t
+}
+
+func (l SteamAPICallCompleted_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l SteamAPICallCompleted_t) encode() []byte {
+	c := C.SteamAPICallCompleted_t{
+		m_hAsyncCall: uint64ToC(uint64(l.AsyncCall)),
+		m_iCallback:  C.int(l.Callback),
+		m_cubParam:   C.uint(l.ParamSize),
+	}
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
+type PersonaStateChange_t struct {
+	SteamID     CSteamID
+	ChangeFlags EPersonaChange
+}
+
+func (l PersonaStateChange_t) FromByte(b []byte) PersonaStateChange_t {
+	return l.FromCStruct(**(**C.PersonaStateChange_t)(unsafe.Pointer(&b)))
+}
+
+func (l PersonaStateChange_t) FromCStruct(cstruct C.PersonaStateChange_t) PersonaStateChange_t {
+	return PersonaStateChange_t{
+		SteamID:     CSteamID(uint64FromC(cstruct.m_ulSteamID)),
+		ChangeFlags: EPersonaChange(cstruct.m_nChangeFlags),
+	}
+}
+
+func (l PersonaStateChange_t) CStruct() C.PersonaStateChange_t {
+	return C.PersonaStateChange_t{}
+}
+
+func (l PersonaStateChange_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+func (l PersonaStateChange_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_PersonaStateChange_t
+}
+
+func (l PersonaStateChange_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l PersonaStateChange_t) encode() []byte {
+	c := C.PersonaStateChange_t{
+		m_ulSteamID:    uint64ToC(uint64(l.SteamID)),
+		m_nChangeFlags: C.int(l.ChangeFlags),
+	}
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
+// AvatarImageLoaded_t is posted when an avatar GetLargeFriendAvatar returned -1 for has loaded.
+type AvatarImageLoaded_t struct {
+	SteamID CSteamID
+	// Image is the avatar's image handle for ImageRGBA.
+	Image         int32
+	Width, Height int32
+}
+
+func (l AvatarImageLoaded_t) FromByte(b []byte) AvatarImageLoaded_t {
+	return l.FromCStruct(**(**C.AvatarImageLoaded_t)(unsafe.Pointer(&b)))
+}
+
+func (l AvatarImageLoaded_t) FromCStruct(cstruct C.AvatarImageLoaded_t) AvatarImageLoaded_t {
+	return AvatarImageLoaded_t{
+		SteamID: CSteamID(uint64FromC(cstruct.m_steamID)),
+		Image:   int32(cstruct.m_iImage),
+		Width:   int32(cstruct.m_iWide),
+		Height:  int32(cstruct.m_iTall),
+	}
+}
+
+func (l AvatarImageLoaded_t) CStruct() C.AvatarImageLoaded_t {
+	return C.AvatarImageLoaded_t{}
+}
+
+func (l AvatarImageLoaded_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+func (l AvatarImageLoaded_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_AvatarImageLoaded_t
+}
+
+func (l AvatarImageLoaded_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l AvatarImageLoaded_t) encode() []byte {
+	c := C.AvatarImageLoaded_t{
+		m_steamID: uint64ToC(uint64(l.SteamID)),
+		m_iImage:  C.int(l.Image),
+		m_iWide:   C.int(l.Width),
+		m_iTall:   C.int(l.Height),
+	}
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
+// FriendGameInfo_t describes the game a friend is playing, as returned by GetFriendGamePlayed.
+type FriendGameInfo_t struct {
+	// GameID is the game's CGameID. AppID returns its app.
+	GameID    uint64
+	GameIP    uint32
+	GamePort  uint16
+	QueryPort uint16
+	// SteamIDLobby is the lobby the friend is in, or 0.
+	SteamIDLobby CSteamID
+}
+
+// AppID returns the app of the game.
+func (l FriendGameInfo_t) AppID() AppId_t {
+	return AppId_t(l.GameID & 0xffffff)
+}
+
+func (l FriendGameInfo_t) FromCStruct(cstruct C.FriendGameInfo_t) FriendGameInfo_t {
+	return FriendGameInfo_t{
+		GameID:       uint64FromC(cstruct.m_gameID),
+		GameIP:       uint32(cstruct.m_unGameIP),
+		GamePort:     uint16(cstruct.m_usGamePort),
+		QueryPort:    uint16(cstruct.m_usQueryPort),
+		SteamIDLobby: CSteamID(uint64FromC(cstruct.m_steamIDLobby)),
+	}
+}
+
+func (l FriendGameInfo_t) CStruct() C.FriendGameInfo_t {
+	return C.FriendGameInfo_t{}
+}
+
+// put stores l at p, a C FriendGameInfo_t.
+func (l FriendGameInfo_t) put(p unsafe.Pointer) {
+	c := C.FriendGameInfo_t{
+		m_gameID:       uint64ToC(l.GameID),
+		m_unGameIP:     C.uint(l.GameIP),
+		m_usGamePort:   C.ushort(l.GamePort),
+		m_usQueryPort:  C.ushort(l.QueryPort),
+		m_steamIDLobby: uint64ToC(uint64(l.SteamIDLobby)),
+	}
+	*(*C.FriendGameInfo_t)(p) = c
+}
+
+type GameOverlayActivated_t struct {
+	Active        bool
+	UserInitiated bool
+	AppID         AppId_t
+	OverlayPID    uint32
+}
+
+func (l GameOverlayActivated_t) FromByte(b []byte) GameOverlayActivated_t {
+	return l.FromCStruct(**(**C.GameOverlayActivated_t)(unsafe.Pointer(&b)))
+}
+
+func (l GameOverlayActivated_t) FromCStruct(cstruct C.GameOverlayActivated_t) GameOverlayActivated_t {
+	return GameOverlayActivated_t{
+		Active:        cstruct.m_bActive != 0,
+		UserInitiated: cstruct.m_bUserInitiated != 0,
+		AppID:         AppId_t(cstruct.m_nAppID),
+		OverlayPID:    uint32(cstruct.m_dwOverlayPID),
+	}
+}
+
+func (l GameOverlayActivated_t) CStruct() C.GameOverlayActivated_t {
+	return C.GameOverlayActivated_t{}
+}
+
+func (l GameOverlayActivated_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+func (l GameOverlayActivated_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_GameOverlayActivated_t
+}
+
+func (l GameOverlayActivated_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l GameOverlayActivated_t) encode() []byte {
+	c := C.GameOverlayActivated_t{
+		m_bActive:        boolToC(l.Active),
+		m_bUserInitiated: boolToC(l.UserInitiated),
+		m_nAppID:         C.uint(l.AppID),
+		m_dwOverlayPID:   C.uint(l.OverlayPID),
+	}
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
+// SteamShutdown_t is posted when Steam wants the game to shut down. It carries no data.
+type SteamShutdown_t struct{}
+
+func (l SteamShutdown_t) FromByte(b []byte) SteamShutdown_t {
+	return SteamShutdown_t{}
+}
+
+// Size is 1, as an empty struct has in C++.
+func (l SteamShutdown_t) Size() uintptr {
+	return 1
+}
+
+func (l SteamShutdown_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_SteamShutdown_t
+}
+
+func (l SteamShutdown_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l SteamShutdown_t) encode() []byte {
+	return make([]byte, l.Size())
+}
+
+type GamepadTextInputDismissed_t struct {
+	Submitted bool
+	// SubmittedText is the length of the submitted text in bytes.
+	SubmittedText uint32
+	AppID         AppId_t
+}
+
+func (l GamepadTextInputDismissed_t) FromByte(b []byte) GamepadTextInputDismissed_t {
+	return l.FromCStruct(**(**C.GamepadTextInputDismissed_t)(unsafe.Pointer(&b)))
+}
+
+func (l GamepadTextInputDismissed_t) FromCStruct(cstruct C.GamepadTextInputDismissed_t) GamepadTextInputDismissed_t {
+	return GamepadTextInputDismissed_t{
+		Submitted:     cstruct.m_bSubmitted != 0,
+		SubmittedText: uint32(cstruct.m_unSubmittedText),
+		AppID:         AppId_t(cstruct.m_unAppID),
+	}
+}
+
+func (l GamepadTextInputDismissed_t) CStruct() C.GamepadTextInputDismissed_t {
+	return C.GamepadTextInputDismissed_t{}
+}
+
+func (l GamepadTextInputDismissed_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+func (l GamepadTextInputDismissed_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_GamepadTextInputDismissed_t
+}
+
+func (l GamepadTextInputDismissed_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l GamepadTextInputDismissed_t) encode() []byte {
+	c := C.GamepadTextInputDismissed_t{
+		m_bSubmitted:      boolToC(l.Submitted),
+		m_unSubmittedText: C.uint(l.SubmittedText),
+		m_unAppID:         C.uint(l.AppID),
+	}
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
+type DlcInstalled_t struct {
+	AppID AppId_t
+}
+
+func (l DlcInstalled_t) FromByte(b []byte) DlcInstalled_t {
+	return l.FromCStruct(**(**C.DlcInstalled_t)(unsafe.Pointer(&b)))
+}
+
+func (l DlcInstalled_t) FromCStruct(cstruct C.DlcInstalled_t) DlcInstalled_t {
+	return DlcInstalled_t{
+		AppID: AppId_t(cstruct.m_nAppID),
+	}
+}
+
+func (l DlcInstalled_t) CStruct() C.DlcInstalled_t {
+	return C.DlcInstalled_t{}
+}
+
+func (l DlcInstalled_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+func (l DlcInstalled_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_DlcInstalled_t
+}
+
+func (l DlcInstalled_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l DlcInstalled_t) encode() []byte {
+	c := C.DlcInstalled_t{
+		m_nAppID: C.uint(l.AppID),
+	}
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
+// NewUrlLaunchParameters_t is posted when the running app is launched again through a steam://run URL.
+// GetLaunchQueryParam and GetLaunchCommandLine return the new parameters.
+type NewUrlLaunchParameters_t struct{}
+
+func (l NewUrlLaunchParameters_t) FromByte(b []byte) NewUrlLaunchParameters_t {
+	return NewUrlLaunchParameters_t{}
+}
+
+func (l NewUrlLaunchParameters_t) FromCStruct(cstruct C.NewUrlLaunchParameters_t) NewUrlLaunchParameters_t {
+	return NewUrlLaunchParameters_t{}
+}
+
+func (l NewUrlLaunchParameters_t) CStruct() C.NewUrlLaunchParameters_t {
+	return C.NewUrlLaunchParameters_t{}
+}
+
+func (l NewUrlLaunchParameters_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+func (l NewUrlLaunchParameters_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_NewUrlLaunchParameters_t
+}
+
+func (l NewUrlLaunchParameters_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l NewUrlLaunchParameters_t) encode() []byte {
+	c := l.CStruct()
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
+// AppProofOfPurchaseKeyResponse_t is posted with the key RequestAppProofOfPurchaseKey requested.
+type AppProofOfPurchaseKeyResponse_t struct {
+	Result EResult
+	AppID  AppId_t
+	Key    string
+}
+
+func (l AppProofOfPurchaseKeyResponse_t) FromByte(b []byte) AppProofOfPurchaseKeyResponse_t {
+	return l.FromCStruct(**(**C.AppProofOfPurchaseKeyResponse_t)(unsafe.Pointer(&b)))
+}
+
+func (l AppProofOfPurchaseKeyResponse_t) FromCStruct(cstruct C.AppProofOfPurchaseKeyResponse_t) AppProofOfPurchaseKeyResponse_t {
+	return AppProofOfPurchaseKeyResponse_t{
+		Result: EResult(cstruct.m_eResult),
+		AppID:  AppId_t(cstruct.m_nAppID),
+		Key:    C.GoString(&cstruct.m_rgchKey[0]),
+	}
+}
+
+func (l AppProofOfPurchaseKeyResponse_t) CStruct() C.AppProofOfPurchaseKeyResponse_t {
+	return C.AppProofOfPurchaseKeyResponse_t{}
+}
+
+func (l AppProofOfPurchaseKeyResponse_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+func (l AppProofOfPurchaseKeyResponse_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_AppProofOfPurchaseKeyResponse_t
+}
+
+func (l AppProofOfPurchaseKeyResponse_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l AppProofOfPurchaseKeyResponse_t) encode() []byte {
+	c := C.AppProofOfPurchaseKeyResponse_t{
+		m_eResult:      C.EResult(l.Result),
+		m_nAppID:       C.uint(l.AppID),
+		m_cchKeyLength: C.uint(len(l.Key)),
+	}
+	putCString(c.m_rgchKey[:], l.Key)
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
+// SteamUGCDetails_t describes a Workshop item, as returned by GetQueryUGCResult.
+type SteamUGCDetails_t struct {
+	PublishedFileID     PublishedFileId_t
+	Result              EResult
+	FileType            EWorkshopFileType
+	CreatorAppID        AppId_t
+	ConsumerAppID       AppId_t
+	Title               string
+	Description         string
+	SteamIDOwner        CSteamID
+	TimeCreated         time.Time
+	TimeUpdated         time.Time
+	TimeAddedToUserList time.Time
+	Visibility          ERemoteStoragePublishedFileVisibility
+	Banned              bool
+	AcceptedForUse      bool
+	TagsTruncated       bool
+	// Tags is a comma-separated list. TagList splits it.
+	Tags            string
+	File            UGCHandle_t
+	PreviewFile     UGCHandle_t
+	FileName        string
+	FileSize        int32
+	PreviewFileSize int32
+	URL             string
+	VotesUp         uint32
+	VotesDown       uint32
+	// Score is the share of votes that are up, from 0 to 1.
+	Score          float32
+	NumChildren    uint32
+	TotalFilesSize uint64
+}
+
+func (l SteamUGCDetails_t) FromCStruct(cstruct C.SteamUGCDetails_t) SteamUGCDetails_t {
+	return SteamUGCDetails_t{
+		PublishedFileID:     PublishedFileId_t(uint64FromC(cstruct.m_nPublishedFileId)),
+		Result:              EResult(cstruct.m_eResult),
+		FileType:            EWorkshopFileType(cstruct.m_eFileType),
+		CreatorAppID:        AppId_t(cstruct.m_nCreatorAppID),
+		ConsumerAppID:       AppId_t(cstruct.m_nConsumerAppID),
+		Title:               C.GoString(&cstruct.m_rgchTitle[0]),
+		Description:         C.GoString(&cstruct.m_rgchDescription[0]),
+		SteamIDOwner:        CSteamID(uint64FromC(cstruct.m_ulSteamIDOwner)),
+		TimeCreated:         unixTime(int64(cstruct.m_rtimeCreated)),
+		TimeUpdated:         unixTime(int64(cstruct.m_rtimeUpdated)),
+		TimeAddedToUserList: unixTime(int64(cstruct.m_rtimeAddedToUserList)),
+		Visibility:          ERemoteStoragePublishedFileVisibility(cstruct.m_eVisibility),
+		Banned:              cstruct.m_bBanned != 0,
+		AcceptedForUse:      cstruct.m_bAcceptedForUse != 0,
+		TagsTruncated:       cstruct.m_bTagsTruncated != 0,
+		Tags:                C.GoString(&cstruct.m_rgchTags[0]),
+		File:                UGCHandle_t(uint64FromC(cstruct.m_hFile)),
+		PreviewFile:         UGCHandle_t(uint64FromC(cstruct.m_hPreviewFile)),
+		FileName:            C.GoString(&cstruct.m_pchFileName[0]),
+		FileSize:            int32(cstruct.m_nFileSize),
+		PreviewFileSize:     int32(cstruct.m_nPreviewFileSize),
+		URL:                 C.GoString(&cstruct.m_rgchURL[0]),
+		VotesUp:             uint32(cstruct.m_unVotesUp),
+		VotesDown:           uint32(cstruct.m_unVotesDown),
+		Score:               float32(cstruct.m_flScore),
+		NumChildren:         uint32(cstruct.m_unNumChildren),
+		TotalFilesSize:      uint64FromC(cstruct.m_ulTotalFilesSize),
+	}
+}
+
+func (l SteamUGCDetails_t) CStruct() C.SteamUGCDetails_t {
+	return C.SteamUGCDetails_t{}
+}
+
+func (l SteamUGCDetails_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+// put stores l at p, a C SteamUGCDetails_t.
+func (l SteamUGCDetails_t) put(p unsafe.Pointer) {
+	c := C.SteamUGCDetails_t{
+		m_nPublishedFileId:     uint64ToC(uint64(l.PublishedFileID)),
+		m_eResult:              C.EResult(l.Result),
+		m_eFileType:            C.int(l.FileType),
+		m_nCreatorAppID:        C.uint(l.CreatorAppID),
+		m_nConsumerAppID:       C.uint(l.ConsumerAppID),
+		m_ulSteamIDOwner:       uint64ToC(uint64(l.SteamIDOwner)),
+		m_rtimeCreated:         C.uint(unixSeconds(l.TimeCreated)),
+		m_rtimeUpdated:         C.uint(unixSeconds(l.TimeUpdated)),
+		m_rtimeAddedToUserList: C.uint(unixSeconds(l.TimeAddedToUserList)),
+		m_eVisibility:          C.int(l.Visibility),
+		m_bBanned:              boolToC(l.Banned),
+		m_bAcceptedForUse:      boolToC(l.AcceptedForUse),
+		m_bTagsTruncated:       boolToC(l.TagsTruncated),
+		m_hFile:                uint64ToC(uint64(l.File)),
+		m_hPreviewFile:         uint64ToC(uint64(l.PreviewFile)),
+		m_nFileSize:            C.int(l.FileSize),
+		m_nPreviewFileSize:     C.int(l.PreviewFileSize),
+		m_unVotesUp:            C.uint(l.VotesUp),
+		m_unVotesDown:          C.uint(l.VotesDown),
+		m_flScore:              C.float(l.Score),
+		m_unNumChildren:        C.uint(l.NumChildren),
+		m_ulTotalFilesSize:     uint64ToC(l.TotalFilesSize),
+	}
+	putCString(c.m_rgchTitle[:], l.Title)
+	putCString(c.m_rgchDescription[:], l.Description)
+	putCString(c.m_rgchTags[:], l.Tags)
+	putCString(c.m_pchFileName[:], l.FileName)
+	putCString(c.m_rgchURL[:], l.URL)
+	*(*C.SteamUGCDetails_t)(p) = c
+}
+
+// TagList returns the tags of the item.
+func (l SteamUGCDetails_t) TagList() []string {
+	if l.Tags == "" {
+		return nil
+	}
+	return strings.Split(l.Tags, ",")
+}
+
+// SteamUGCQueryCompleted_t is the result of SendQueryUGCRequest.
+type SteamUGCQueryCompleted_t struct {
+	Handle               UGCQueryHandle_t
+	Result               EResult
+	NumResultsReturned   uint32
+	TotalMatchingResults uint32
+	CachedData           bool
+}
+
+func (l SteamUGCQueryCompleted_t) FromByte(b []byte) SteamUGCQueryCompleted_t {
+	return l.FromCStruct(**(**C.SteamUGCQueryCompleted_t)(unsafe.Pointer(&b)))
+}
+
+func (l SteamUGCQueryCompleted_t) FromCStruct(cstruct C.SteamUGCQueryCompleted_t) SteamUGCQueryCompleted_t {
+	return SteamUGCQueryCompleted_t{
+		Handle:               UGCQueryHandle_t(uint64FromC(cstruct.m_handle)),
+		Result:               EResult(cstruct.m_eResult),
+		NumResultsReturned:   uint32(cstruct.m_unNumResultsReturned),
+		TotalMatchingResults: uint32(cstruct.m_unTotalMatchingResults),
+		CachedData:           cstruct.m_bCachedData != 0,
+	}
+}
+
+func (l SteamUGCQueryCompleted_t) CStruct() C.SteamUGCQueryCompleted_t {
+	return C.SteamUGCQueryCompleted_t{}
+}
+
+func (l SteamUGCQueryCompleted_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+func (l SteamUGCQueryCompleted_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_SteamUGCQueryCompleted_t
+}
+
+func (l SteamUGCQueryCompleted_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l SteamUGCQueryCompleted_t) encode() []byte {
+	c := C.SteamUGCQueryCompleted_t{
+		m_handle:                 uint64ToC(uint64(l.Handle)),
+		m_eResult:                C.EResult(l.Result),
+		m_unNumResultsReturned:   C.uint(l.NumResultsReturned),
+		m_unTotalMatchingResults: C.uint(l.TotalMatchingResults),
+		m_bCachedData:            boolToC(l.CachedData),
+	}
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
+// RemoteStorageSubscribePublishedFileResult_t is the result of SubscribeItem.
+type RemoteStorageSubscribePublishedFileResult_t struct {
+	Result          EResult
+	PublishedFileID PublishedFileId_t
+}
+
+func (l RemoteStorageSubscribePublishedFileResult_t) FromByte(b []byte) RemoteStorageSubscribePublishedFileResult_t {
+	return l.FromCStruct(**(**C.RemoteStorageSubscribePublishedFileResult_t)(unsafe.Pointer(&b)))
+}
+
+func (l RemoteStorageSubscribePublishedFileResult_t) FromCStruct(cstruct C.RemoteStorageSubscribePublishedFileResult_t) RemoteStorageSubscribePublishedFileResult_t {
+	return RemoteStorageSubscribePublishedFileResult_t{
+		Result:          EResult(cstruct.m_eResult),
+		PublishedFileID: PublishedFileId_t(uint64FromC(cstruct.m_nPublishedFileId)),
+	}
+}
+
+func (l RemoteStorageSubscribePublishedFileResult_t) CStruct() C.RemoteStorageSubscribePublishedFileResult_t {
+	return C.RemoteStorageSubscribePublishedFileResult_t{}
+}
+
+func (l RemoteStorageSubscribePublishedFileResult_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+func (l RemoteStorageSubscribePublishedFileResult_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_RemoteStorageSubscribePublishedFileResult_t
+}
+
+func (l RemoteStorageSubscribePublishedFileResult_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l RemoteStorageSubscribePublishedFileResult_t) encode() []byte {
+	c := C.RemoteStorageSubscribePublishedFileResult_t{
+		m_eResult:          C.EResult(l.Result),
+		m_nPublishedFileId: uint64ToC(uint64(l.PublishedFileID)),
+	}
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
+// RemoteStorageUnsubscribePublishedFileResult_t is the result of UnsubscribeItem.
+type RemoteStorageUnsubscribePublishedFileResult_t struct {
+	Result          EResult
+	PublishedFileID PublishedFileId_t
+}
+
+func (l RemoteStorageUnsubscribePublishedFileResult_t) FromByte(b []byte) RemoteStorageUnsubscribePublishedFileResult_t {
+	return l.FromCStruct(**(**C.RemoteStorageUnsubscribePublishedFileResult_t)(unsafe.Pointer(&b)))
+}
+
+func (l RemoteStorageUnsubscribePublishedFileResult_t) FromCStruct(cstruct C.RemoteStorageUnsubscribePublishedFileResult_t) RemoteStorageUnsubscribePublishedFileResult_t {
+	return RemoteStorageUnsubscribePublishedFileResult_t{
+		Result:          EResult(cstruct.m_eResult),
+		PublishedFileID: PublishedFileId_t(uint64FromC(cstruct.m_nPublishedFileId)),
+	}
+}
+
+func (l RemoteStorageUnsubscribePublishedFileResult_t) CStruct() C.RemoteStorageUnsubscribePublishedFileResult_t {
+	return C.RemoteStorageUnsubscribePublishedFileResult_t{}
+}
+
+func (l RemoteStorageUnsubscribePublishedFileResult_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+func (l RemoteStorageUnsubscribePublishedFileResult_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_RemoteStorageUnsubscribePublishedFileResult_t
+}
+
+func (l RemoteStorageUnsubscribePublishedFileResult_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l RemoteStorageUnsubscribePublishedFileResult_t) encode() []byte {
+	c := C.RemoteStorageUnsubscribePublishedFileResult_t{
+		m_eResult:          C.EResult(l.Result),
+		m_nPublishedFileId: uint64ToC(uint64(l.PublishedFileID)),
+	}
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
+// CreateItemResult_t is the result of CreateItem.
+type CreateItemResult_t struct {
+	Result          EResult
+	PublishedFileID PublishedFileId_t
+	// UserNeedsToAcceptWorkshopLegalAgreement is set if the item stays hidden until the user
+	// accepts the Workshop legal agreement on the item's page.
+	UserNeedsToAcceptWorkshopLegalAgreement bool
+}
+
+func (l CreateItemResult_t) FromByte(b []byte) CreateItemResult_t {
+	return l.FromCStruct(**(**C.CreateItemResult_t)(unsafe.Pointer(&b)))
+}
+
+func (l CreateItemResult_t) FromCStruct(cstruct C.CreateItemResult_t) CreateItemResult_t {
+	return CreateItemResult_t{
+		Result:                                  EResult(cstruct.m_eResult),
+		PublishedFileID:                         PublishedFileId_t(uint64FromC(cstruct.m_nPublishedFileId)),
+		UserNeedsToAcceptWorkshopLegalAgreement: cstruct.m_bUserNeedsToAcceptWorkshopLegalAgreement != 0,
+	}
+}
+
+func (l CreateItemResult_t) CStruct() C.CreateItemResult_t {
+	return C.CreateItemResult_t{}
+}
+
+func (l CreateItemResult_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+func (l CreateItemResult_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_CreateItemResult_t
+}
+
+func (l CreateItemResult_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l CreateItemResult_t) encode() []byte {
+	c := C.CreateItemResult_t{
+		m_eResult:          C.EResult(l.Result),
+		m_nPublishedFileId: uint64ToC(uint64(l.PublishedFileID)),
+		m_bUserNeedsToAcceptWorkshopLegalAgreement: boolToC(l.UserNeedsToAcceptWorkshopLegalAgreement),
+	}
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
+// SubmitItemUpdateResult_t is the result of SubmitItemUpdate.
+type SubmitItemUpdateResult_t struct {
+	Result          EResult
+	PublishedFileID PublishedFileId_t
+	// UserNeedsToAcceptWorkshopLegalAgreement is set if the item stays hidden until the user
+	// accepts the Workshop legal agreement on the item's page.
+	UserNeedsToAcceptWorkshopLegalAgreement bool
+}
+
+func (l SubmitItemUpdateResult_t) FromByte(b []byte) SubmitItemUpdateResult_t {
+	return l.FromCStruct(**(**C.SubmitItemUpdateResult_t)(unsafe.Pointer(&b)))
+}
+
+func (l SubmitItemUpdateResult_t) FromCStruct(cstruct C.SubmitItemUpdateResult_t) SubmitItemUpdateResult_t {
+	return SubmitItemUpdateResult_t{
+		Result:                                  EResult(cstruct.m_eResult),
+		PublishedFileID:                         PublishedFileId_t(uint64FromC(cstruct.m_nPublishedFileId)),
+		UserNeedsToAcceptWorkshopLegalAgreement: cstruct.m_bUserNeedsToAcceptWorkshopLegalAgreement != 0,
+	}
+}
+
+func (l SubmitItemUpdateResult_t) CStruct() C.SubmitItemUpdateResult_t {
+	return C.SubmitItemUpdateResult_t{}
+}
+
+func (l SubmitItemUpdateResult_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+func (l SubmitItemUpdateResult_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_SubmitItemUpdateResult_t
+}
+
+func (l SubmitItemUpdateResult_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l SubmitItemUpdateResult_t) encode() []byte {
+	c := C.SubmitItemUpdateResult_t{
+		m_eResult:          C.EResult(l.Result),
+		m_nPublishedFileId: uint64ToC(uint64(l.PublishedFileID)),
+		m_bUserNeedsToAcceptWorkshopLegalAgreement: boolToC(l.UserNeedsToAcceptWorkshopLegalAgreement),
+	}
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
+// ItemInstalled_t is posted when a Workshop item of any app has been installed or updated.
+type ItemInstalled_t struct {
+	AppID           AppId_t
+	PublishedFileID PublishedFileId_t
+}
+
+func (l ItemInstalled_t) FromByte(b []byte) ItemInstalled_t {
+	return l.FromCStruct(**(**C.ItemInstalled_t)(unsafe.Pointer(&b)))
+}
+
+func (l ItemInstalled_t) FromCStruct(cstruct C.ItemInstalled_t) ItemInstalled_t {
+	return ItemInstalled_t{
+		AppID:           AppId_t(cstruct.m_unAppID),
+		PublishedFileID: PublishedFileId_t(uint64FromC(cstruct.m_nPublishedFileId)),
+	}
+}
+
+func (l ItemInstalled_t) CStruct() C.ItemInstalled_t {
+	return C.ItemInstalled_t{}
+}
+
+func (l ItemInstalled_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+func (l ItemInstalled_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_ItemInstalled_t
+}
+
+func (l ItemInstalled_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l ItemInstalled_t) encode() []byte {
+	c := C.ItemInstalled_t{
+		m_unAppID:          C.uint(l.AppID),
+		m_nPublishedFileId: uint64ToC(uint64(l.PublishedFileID)),
+	}
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
+
+// DownloadItemResult_t is posted when a download started by DownloadItem has finished.
+type DownloadItemResult_t struct {
+	AppID           AppId_t
+	PublishedFileID PublishedFileId_t
+	Result          EResult
+}
+
+func (l DownloadItemResult_t) FromByte(b []byte) DownloadItemResult_t {
+	return l.FromCStruct(**(**C.DownloadItemResult_t)(unsafe.Pointer(&b)))
+}
+
+func (l DownloadItemResult_t) FromCStruct(cstruct C.DownloadItemResult_t) DownloadItemResult_t {
+	return DownloadItemResult_t{
+		AppID:           AppId_t(cstruct.m_unAppID),
+		PublishedFileID: PublishedFileId_t(uint64FromC(cstruct.m_nPublishedFileId)),
+		Result:          EResult(cstruct.m_eResult),
+	}
+}
+
+func (l DownloadItemResult_t) CStruct() C.DownloadItemResult_t {
+	return C.DownloadItemResult_t{}
+}
+
+func (l DownloadItemResult_t) Size() uintptr {
+	return reflect.TypeOf(l.CStruct()).Size()
+}
+
+func (l DownloadItemResult_t) callbackExpected() iCallbackExpected {
+	return iCallbackExpected_DownloadItemResult_t
+}
+
+func (l DownloadItemResult_t) decode(b []byte) Callback {
+	return l.FromByte(b)
+}
+
+func (l DownloadItemResult_t) encode() []byte {
+	c := C.DownloadItemResult_t{
+		m_unAppID:          C.uint(l.AppID),
+		m_nPublishedFileId: uint64ToC(uint64(l.PublishedFileID)),
+		m_eResult:          C.EResult(l.Result),
+	}
+	return cBytes(unsafe.Pointer(&c), unsafe.Sizeof(c))
+}
@@ -0,0 +1,438 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// NumUGCResultsPerPage is the number of items in a page of a Workshop query.
+const NumUGCResultsPerPage = 50
+
+// ugcMetadataMax is the size of an item's metadata, including the terminating NUL.
+const ugcMetadataMax = 5000
+
+// ugcQueryHandleInvalid is returned by the CreateQuery functions when they fail.
+const ugcQueryHandleInvalid UGCQueryHandle_t = 0xffffffffffffffff
+
+// ugcUpdateHandleInvalid is returned by StartItemUpdate when it fails.
+const ugcUpdateHandleInvalid UGCUpdateHandle_t = 0xffffffffffffffff
+
+// itemUpdateInterval is how often Submit polls the progress of an upload.
+const itemUpdateInterval = 100 * time.Millisecond
+
+// UGCQuery describes a query of Workshop items for Query.
+//
+//	result, err := steamworks.SteamUGC().Query(ctx, &steamworks.UGCQuery{
+//		Ranking:       steamworks.EUGCQuery_RankedByTrend,
+//		CreatorAppID:  appID,
+//		ConsumerAppID: appID,
+//		RequiredTags:  []string{"Maps"},
+//	})
+type UGCQuery struct {
+	// User, if not 0, makes the query list the items in List of that user, e.g. the items they published
+	// or subscribed to, in SortOrder. Otherwise the query finds all items, in the order of Ranking.
+	User      AccountID_t
+	List      EUserUGCList
+	SortOrder EUserUGCListSortOrder
+	Ranking   EUGCQuery
+
+	MatchingType EUGCMatchingUGCType
+	// CreatorAppID is the app the items were made with and ConsumerAppID the app that uses them.
+	// For most games both are the game's app ID.
+	CreatorAppID  AppId_t
+	ConsumerAppID AppId_t
+	// Page is the page of NumUGCResultsPerPage items to return, counted from 1. 0 means 1.
+	Page uint32
+
+	// RequiredTags are the tags the items must all have, or any of if MatchAnyTag is set.
+	RequiredTags []string
+	ExcludedTags []string
+	MatchAnyTag  bool
+	SearchText   string
+	// TrendDays is the period of EUGCQuery_RankedByTrend. 0 means Steam's default.
+	TrendDays uint32
+
+	// LongDescription returns the full descriptions instead of their first 255 bytes.
+	LongDescription bool
+	// Metadata returns the items' metadata in UGCItem.Metadata.
+	Metadata bool
+	// Language is the language of the titles and descriptions, e.g. "english". Empty means the user's.
+	Language string
+	// MaxCacheAge allows results that Steam cached up to this long ago.
+	MaxCacheAge time.Duration
+}
+
+// UGCItem is a Workshop item returned by Query or QueryItems.
+type UGCItem struct {
+	SteamUGCDetails_t
+	PreviewURL    string
+	Metadata      string
+	Subscriptions uint64
+}
+
+// UGCQueryResult is a page of the items matching a UGCQuery.
+type UGCQueryResult struct {
+	Items []UGCItem
+	// TotalMatching is the number of items on all the pages.
+	TotalMatching uint32
+	// Cached reports whether the result came from Steam's cache.
+	Cached bool
+}
+
+// Pages returns the number of pages of the query.
+func (r *UGCQueryResult) Pages() int {
+	return int((r.TotalMatching + NumUGCResultsPerPage - 1) / NumUGCResultsPerPage)
+}
+
+// Query returns a page of the Workshop items matching q.
+func (s steamUGC) Query(ctx context.Context, q *UGCQuery) (*UGCQueryResult, error) {
+	page := max(q.Page, 1)
+	var query UGCQueryHandle_t
+	if q.User != 0 {
+		query = s.CreateQueryUserUGCRequest(q.User, q.List, q.MatchingType, q.SortOrder, q.CreatorAppID, q.ConsumerAppID, page)
+	} else {
+		query = s.CreateQueryAllUGCRequest(q.Ranking, q.MatchingType, q.CreatorAppID, q.ConsumerAppID, page)
+	}
+	if query == ugcQueryHandleInvalid {
+		return nil, errors.New("steamworks: creating a Workshop query failed")
+	}
+	defer s.ReleaseQueryUGCRequest(query)
+
+	if err := s.filter(query, q); err != nil {
+		return nil, err
+	}
+	return s.send(ctx, query, q.Metadata)
+}
+
+// QueryItems returns the Workshop items ids, with their full descriptions and metadata.
+// Items that do not exist are left out.
+func (s steamUGC) QueryItems(ctx context.Context, ids []PublishedFileId_t) ([]UGCItem, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	query := s.CreateQueryUGCDetailsRequest(ids)
+	if query == ugcQueryHandleInvalid {
+		return nil, errors.New("steamworks: creating a Workshop query failed")
+	}
+	defer s.ReleaseQueryUGCRequest(query)
+
+	if !s.SetReturnLongDescription(query, true) || !s.SetReturnMetadata(query, true) {
+		return nil, errors.New("steamworks: setting up a Workshop query failed")
+	}
+	result, err := s.send(ctx, query, true)
+	if err != nil {
+		return nil, err
+	}
+	return result.Items, nil
+}
+
+func (s steamUGC) filter(query UGCQueryHandle_t, q *UGCQuery) error {
+	for _, tag := range q.RequiredTags {
+		if !s.AddRequiredTag(query, tag) {
+			return fmt.Errorf("steamworks: AddRequiredTag failed for %q", tag)
+		}
+	}
+	for _, tag := range q.ExcludedTags {
+		if !s.AddExcludedTag(query, tag) {
+			return fmt.Errorf("steamworks: AddExcludedTag failed for %q", tag)
+		}
+	}
+	if q.MatchAnyTag && !s.SetMatchAnyTag(query, true) {
+		return errors.New("steamworks: SetMatchAnyTag failed")
+	}
+	if q.SearchText != "" && !s.SetSearchText(query, q.SearchText) {
+		return errors.New("steamworks: SetSearchText failed")
+	}
+	if q.TrendDays != 0 && !s.SetRankedByTrendDays(query, q.TrendDays) {
+		return errors.New("steamworks: SetRankedByTrendDays failed")
+	}
+	if q.LongDescription && !s.SetReturnLongDescription(query, true) {
+		return errors.New("steamworks: SetReturnLongDescription failed")
+	}
+	if q.Metadata && !s.SetReturnMetadata(query, true) {
+		return errors.New("steamworks: SetReturnMetadata failed")
+	}
+	if q.Language != "" && !s.SetLanguage(query, q.Language) {
+		return errors.New("steamworks: SetLanguage failed")
+	}
+	if q.MaxCacheAge > 0 && !s.SetAllowCachedResponse(query, uint32(q.MaxCacheAge/time.Second)) {
+		return errors.New("steamworks: SetAllowCachedResponse failed")
+	}
+	return nil
+}
+
+func (s steamUGC) send(ctx context.Context, query UGCQueryHandle_t, metadata bool) (*UGCQueryResult, error) {
+	completed, err := Await[SteamUGCQueryCompleted_t](ctx, s.SendQueryUGCRequest(query))
+	if err != nil {
+		return nil, err
+	}
+	if completed.Result != EResult_OK {
+		return nil, fmt.Errorf("steamworks: Workshop query failed: %d", completed.Result)
+	}
+
+	result := &UGCQueryResult{
+		Items:         make([]UGCItem, 0, completed.NumResultsReturned),
+		TotalMatching: completed.TotalMatchingResults,
+		Cached:        completed.CachedData,
+	}
+	for i := uint32(0); i < completed.NumResultsReturned; i++ {
+		details, ok := s.GetQueryUGCResult(query, i)
+		if !ok || details.Result != EResult_OK {
+			continue
+		}
+		item := UGCItem{
+			SteamUGCDetails_t: details,
+			PreviewURL:        s.GetQueryUGCPreviewURL(query, i),
+		}
+		if metadata {
+			item.Metadata = s.GetQueryUGCMetadata(query, i)
+		}
+		item.Subscriptions, _ = s.GetQueryUGCStatistic(query, i, EItemStatistic_NumSubscriptions)
+		result.Items = append(result.Items, item)
+	}
+	return result, nil
+}
+
+// Subscribe subscribes the user to the Workshop item id. Steam then installs it in the background.
+func (s steamUGC) Subscribe(ctx context.Context, id PublishedFileId_t) error {
+	subscribed, err := Await[RemoteStorageSubscribePublishedFileResult_t](ctx, s.SubscribeItem(id))
+	if err != nil {
+		return err
+	}
+	if subscribed.Result != EResult_OK {
+		return fmt.Errorf("steamworks: subscribing to Workshop item %d failed: %d", id, subscribed.Result)
+	}
+	return nil
+}
+
+// Unsubscribe unsubscribes the user from the Workshop item id. Steam uninstalls it when the game exits.
+func (s steamUGC) Unsubscribe(ctx context.Context, id PublishedFileId_t) error {
+	unsubscribed, err := Await[RemoteStorageUnsubscribePublishedFileResult_t](ctx, s.UnsubscribeItem(id))
+	if err != nil {
+		return err
+	}
+	if unsubscribed.Result != EResult_OK {
+		return fmt.Errorf("steamworks: unsubscribing from Workshop item %d failed: %d", id, unsubscribed.Result)
+	}
+	return nil
+}
+
+// WorkshopItemUpdate creates or updates a Workshop item owned by the user. Only the fields that are set
+// change; an update of an existing item leaves the rest as they are.
+//
+//	progress := steamworks.SteamUGC().CreateWorkshopItem(appID, steamworks.EWorkshopFileType_Community).
+//		Title("Castle").
+//		Tags("Maps").
+//		Content(dir).
+//		Preview(filepath.Join(dir, "preview.png")).
+//		Visibility(steamworks.ERemoteStoragePublishedFileVisibility_Public).
+//		Submit(ctx, "First version")
+//	for p := range progress {
+//		if p.Done {
+//			id, err = p.PublishedFileID, p.Err
+//		}
+//	}
+type WorkshopItemUpdate struct {
+	ugc      steamUGC
+	appID    AppId_t
+	id       PublishedFileId_t
+	fileType EWorkshopFileType
+
+	title       *string
+	description *string
+	tags        []string
+	setTags     bool
+	visibility  *ERemoteStoragePublishedFileVisibility
+	content     string
+	preview     string
+}
+
+// ItemUpdateProgress is sent by WorkshopItemUpdate.Submit while the item uploads.
+type ItemUpdateProgress struct {
+	Status         EItemUpdateStatus
+	BytesProcessed uint64
+	BytesTotal     uint64
+
+	// Done is set on the last progress, which reports how the update ended.
+	Done bool
+	// PublishedFileID is the item, known once it has been created. It is set on the last progress
+	// even if the update failed after the item was created, so the update can be retried.
+	PublishedFileID PublishedFileId_t
+	// UserNeedsToAcceptWorkshopLegalAgreement is set if the item stays hidden until the user
+	// accepts the Workshop legal agreement.
+	UserNeedsToAcceptWorkshopLegalAgreement bool
+	Err                                     error
+}
+
+// CreateWorkshopItem returns an update that creates a new item when it is submitted.
+func (s steamUGC) CreateWorkshopItem(consumerAppID AppId_t, fileType EWorkshopFileType) *WorkshopItemUpdate {
+	return &WorkshopItemUpdate{ugc: s, appID: consumerAppID, fileType: fileType}
+}
+
+// UpdateWorkshopItem returns an update of the existing item id.
+func (s steamUGC) UpdateWorkshopItem(consumerAppID AppId_t, id PublishedFileId_t) *WorkshopItemUpdate {
+	return &WorkshopItemUpdate{ugc: s, appID: consumerAppID, id: id}
+}
+
+// Title sets the title of the item.
+func (u *WorkshopItemUpdate) Title(title string) *WorkshopItemUpdate {
+	u.title = &title
+	return u
+}
+
+// Description sets the description of the item.
+func (u *WorkshopItemUpdate) Description(description string) *WorkshopItemUpdate {
+	u.description = &description
+	return u
+}
+
+// Tags replaces the tags of the item. No tags removes them all.
+func (u *WorkshopItemUpdate) Tags(tags ...string) *WorkshopItemUpdate {
+	u.tags = tags
+	u.setTags = true
+	return u
+}
+
+// Visibility sets who can see the item.
+func (u *WorkshopItemUpdate) Visibility(visibility ERemoteStoragePublishedFileVisibility) *WorkshopItemUpdate {
+	u.visibility = &visibility
+	return u
+}
+
+// Content sets the folder whose files are uploaded as the item's content.
+func (u *WorkshopItemUpdate) Content(folder string) *WorkshopItemUpdate {
+	u.content = folder
+	return u
+}
+
+// Preview sets the preview image, a file smaller than 1 MB.
+func (u *WorkshopItemUpdate) Preview(file string) *WorkshopItemUpdate {
+	u.preview = file
+	return u
+}
+
+// Submit creates the item if needed and uploads the update. It returns a channel that receives the
+// progress of the upload and is closed after the last progress, whose Done is set.
+// Progress that is not read in time is dropped, but the last progress is always kept,
+// so the caller can stop reading at any time.
+//
+// Cancelling ctx stops waiting for the update, but not the upload. Once the item has been created,
+// submitting the update again, e.g. after a failed upload, updates that item rather than creating another.
+// Submit must not be called again before the channel is closed.
+func (u *WorkshopItemUpdate) Submit(ctx context.Context, changeNote string) <-chan ItemUpdateProgress {
+	ch := make(chan ItemUpdateProgress, 1)
+	go func() {
+		defer close(ch)
+		last := u.submit(ctx, changeNote, ch)
+		last.Done = true
+		// This goroutine is the only sender, so once an unread progress is dropped the buffer has room
+		// and the send cannot block, even if nobody reads the channel any more.
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- last
+	}()
+	return ch
+}
+
+func (u *WorkshopItemUpdate) submit(ctx context.Context, changeNote string, ch chan<- ItemUpdateProgress) ItemUpdateProgress {
+	var result ItemUpdateProgress
+	id := u.id
+	if id == 0 {
+		created, err := Await[CreateItemResult_t](ctx, u.ugc.CreateItem(u.appID, u.fileType))
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		if created.Result != EResult_OK {
+			result.Err = fmt.Errorf("steamworks: creating a Workshop item failed: %d", created.Result)
+			return result
+		}
+		id = created.PublishedFileID
+		// A retry updates the item created here instead of creating another.
+		u.id = id
+		result.UserNeedsToAcceptWorkshopLegalAgreement = created.UserNeedsToAcceptWorkshopLegalAgreement
+	}
+	result.PublishedFileID = id
+
+	update := u.ugc.StartItemUpdate(u.appID, id)
+	if update == ugcUpdateHandleInvalid {
+		result.Err = fmt.Errorf("steamworks: StartItemUpdate failed for Workshop item %d", id)
+		return result
+	}
+	if err := u.apply(update); err != nil {
+		result.Err = err
+		return result
+	}
+
+	call := u.ugc.SubmitItemUpdate(update, changeNote)
+	type submitted struct {
+		result SubmitItemUpdateResult_t
+		err    error
+	}
+	done := make(chan submitted, 1)
+	go func() {
+		r, err := Await[SubmitItemUpdateResult_t](ctx, call)
+		done <- submitted{result: r, err: err}
+	}()
+
+	ticker := time.NewTicker(itemUpdateInterval)
+	defer ticker.Stop()
+	var sent ItemUpdateProgress
+	for {
+		select {
+		case d := <-done:
+			if d.err != nil {
+				result.Err = d.err
+				return result
+			}
+			if d.result.Result != EResult_OK {
+				result.Err = fmt.Errorf("steamworks: updating Workshop item %d failed: %d", id, d.result.Result)
+				return result
+			}
+			result.UserNeedsToAcceptWorkshopLegalAgreement = result.UserNeedsToAcceptWorkshopLegalAgreement || d.result.UserNeedsToAcceptWorkshopLegalAgreement
+			result.Status = EItemUpdateStatus_Invalid
+			result.BytesProcessed, result.BytesTotal = sent.BytesTotal, sent.BytesTotal
+			return result
+		case <-ticker.C:
+			p := ItemUpdateProgress{PublishedFileID: id}
+			p.Status, p.BytesProcessed, p.BytesTotal = u.ugc.GetItemUpdateProgress(update)
+			if p == sent || p.Status == EItemUpdateStatus_Invalid {
+				continue
+			}
+			select {
+			case ch <- p:
+				sent = p
+			default:
+			}
+		}
+	}
+}
+
+func (u *WorkshopItemUpdate) apply(update UGCUpdateHandle_t) error {
+	if u.title != nil && !u.ugc.SetItemTitle(update, *u.title) {
+		return errors.New("steamworks: SetItemTitle failed")
+	}
+	if u.description != nil && !u.ugc.SetItemDescription(update, *u.description) {
+		return errors.New("steamworks: SetItemDescription failed")
+	}
+	if u.setTags && !u.ugc.SetItemTags(update, u.tags) {
+		return errors.New("steamworks: SetItemTags failed")
+	}
+	if u.visibility != nil && !u.ugc.SetItemVisibility(update, *u.visibility) {
+		return errors.New("steamworks: SetItemVisibility failed")
+	}
+	if u.content != "" && !u.ugc.SetItemContent(update, u.content) {
+		return fmt.Errorf("steamworks: SetItemContent failed for %s", u.content)
+	}
+	if u.preview != "" && !u.ugc.SetItemPreview(update, u.preview) {
+		return fmt.Errorf("steamworks: SetItemPreview failed for %s", u.preview)
+	}
+	return nil
+}
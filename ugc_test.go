@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TaiJiYu/go-steamworks"
+)
+
+func TestUGCQuery(t *testing.T) {
+	fake := steamworks.NewFake()
+	fake.InstallDir = "/game"
+	other := steamworks.CSteamID(76561197960265999)
+	for i := 0; i < 60; i++ {
+		tags := []string{"Maps"}
+		if i%2 == 0 {
+			tags = append(tags, "Hard")
+		}
+		fake.AddWorkshopItem(steamworks.FakeWorkshopItem{Title: fmt.Sprint("Map ", i), Owner: other, Tags: tags, Metadata: "meta"})
+	}
+	mine := fake.AddWorkshopItem(steamworks.FakeWorkshopItem{Title: "Mine", Owner: fake.SteamID, Tags: []string{"Mod"}})
+	startFake(t, fake)
+	ctx := context.Background()
+	ugc := steamworks.SteamUGC()
+
+	r, err := ugc.Query(ctx, &steamworks.UGCQuery{RequiredTags: []string{"Maps"}, Page: 2, Metadata: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.TotalMatching != 60 || len(r.Items) != 10 || r.Pages() != 2 || r.Items[0].Metadata != "meta" {
+		t.Errorf("page 2 has %d of %d items over %d pages", len(r.Items), r.TotalMatching, r.Pages())
+	}
+
+	r, err = ugc.Query(ctx, &steamworks.UGCQuery{RequiredTags: []string{"Hard", "Mod"}, MatchAnyTag: true, ExcludedTags: []string{"Maps"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Items) != 1 || r.Items[0].PublishedFileID != mine {
+		t.Errorf("items = %+v, want only %d", r.Items, mine)
+	}
+
+	items, err := ugc.QueryItems(ctx, []steamworks.PublishedFileId_t{3, 999, mine})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 {
+		t.Errorf("QueryItems() returned %d items, want 2", len(items))
+	}
+}
+
+func TestUGCSubscribe(t *testing.T) {
+	fake := steamworks.NewFake()
+	fake.InstallDir = "/game"
+	id := fake.AddWorkshopItem(steamworks.FakeWorkshopItem{Title: "Map"})
+	startFake(t, fake)
+	ctx := context.Background()
+	ugc := steamworks.SteamUGC()
+
+	if err := ugc.Subscribe(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if err := ugc.Subscribe(ctx, 999); err == nil {
+		t.Error("subscribed to a missing item")
+	}
+	if state := ugc.GetItemState(id); state != steamworks.EItemState_Subscribed|steamworks.EItemState_Installed {
+		t.Errorf("GetItemState() = %v, want subscribed and installed", state)
+	}
+	if _, folder, _, ok := ugc.GetItemInstallInfo(id); !ok || folder != filepath.Join("/game", "workshop", fmt.Sprint(id)) {
+		t.Errorf("GetItemInstallInfo() = %q, %v", folder, ok)
+	}
+
+	if err := ugc.Unsubscribe(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if n := ugc.GetNumSubscribedItems(); n != 0 {
+		t.Errorf("GetNumSubscribedItems() = %d after unsubscribing", n)
+	}
+}
+
+func TestSubmitWorkshopItem(t *testing.T) {
+	fake := steamworks.NewFake()
+	startFake(t, fake)
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "map.bin"), make([]byte, 5000), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	ugc := steamworks.SteamUGC()
+
+	var last steamworks.ItemUpdateProgress
+	for p := range ugc.CreateWorkshopItem(fake.AppID, steamworks.EWorkshopFileType_Community).Title("My map").Tags("Maps", "Hard").Content(dir).Submit(ctx, "first") {
+		last = p
+	}
+	if !last.Done || last.Err != nil || last.PublishedFileID == 0 {
+		t.Fatalf("last progress = %+v", last)
+	}
+	item, ok := fake.WorkshopItem(last.PublishedFileID)
+	if !ok || item.Title != "My map" || item.Size != 5000 || len(item.Tags) != 2 || item.Owner != fake.SteamID {
+		t.Errorf("item = %+v", item)
+	}
+
+	for p := range ugc.UpdateWorkshopItem(fake.AppID, last.PublishedFileID).Content(filepath.Join(dir, "missing")).Submit(ctx, "broken") {
+		last = p
+	}
+	if last.Err == nil {
+		t.Error("submitted a missing content folder")
+	}
+	for p := range ugc.UpdateWorkshopItem(fake.AppID, 9999).Title("x").Submit(ctx, "missing") {
+		last = p
+	}
+	if last.Err == nil {
+		t.Error("updated a missing item")
+	}
+}
+
+func TestSubmitWorkshopItemRetry(t *testing.T) {
+	fake := steamworks.NewFake()
+	startFake(t, fake)
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	update := steamworks.SteamUGC().CreateWorkshopItem(fake.AppID, steamworks.EWorkshopFileType_Community).Title("Retried").Content(filepath.Join(dir, "missing"))
+	var first steamworks.ItemUpdateProgress
+	for p := range update.Submit(ctx, "first") {
+		first = p
+	}
+	if first.Err == nil || first.PublishedFileID == 0 {
+		t.Fatalf("first progress = %+v, want a failure after the item was created", first)
+	}
+
+	var retry steamworks.ItemUpdateProgress
+	for p := range update.Content(dir).Submit(ctx, "retry") {
+		retry = p
+	}
+	if retry.Err != nil || retry.PublishedFileID != first.PublishedFileID {
+		t.Fatalf("retry = %+v, want an update of item %d", retry, first.PublishedFileID)
+	}
+	if _, ok := fake.WorkshopItem(first.PublishedFileID + 1); ok {
+		t.Error("the retry created another item")
+	}
+}
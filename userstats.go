@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks
+
+import (
+	"time"
+)
+
+type GlobalStatsSuccessFunc func(values []GlobalStat)
+
+type GlobalStat struct {
+	Name  string
+	Value int
+}
+
+// 仅获取总量统计，不获取历史天数的数据
+func (s steamUserStats) GetGlobalStats(names []string, successFunc GlobalStatsSuccessFunc) {
+	callbackAPI := s.requestGlobalStats(0)
+	DefaultDispatcher().setCallback(&CallbackArgs{
+		CallbackAPI:      callbackAPI,
+		CallbackExpected: iCallbackExpected_GlobalStatsReceived_t,
+		CallbaseSize:     int(GlobalStatsReceived_t{}.Size()),
+		SuccessFunc: func(ret []byte) {
+			values := []GlobalStat{}
+			for _, name := range names {
+				v, _ := s.getglobalStats(name)
+				ifget := false
+				for index, vv := range values {
+					if v >= vv.Value {
+						ifget = true
+						begin := append([]GlobalStat{}, values[:index]...)
+						end := append([]GlobalStat{{
+							Name:  name,
+							Value: v,
+						}}, values[index:]...)
+						values = append(begin, end...)
+						break
+					}
+				}
+				if !ifget {
+					values = append(values, GlobalStat{
+						Name:  name,
+						Value: v,
+					})
+				}
+			}
+			successFunc(values)
+		},
+		TimeoutFunc: func(callbackTime time.Time, callbackSpend time.Duration) {},
+	})
+}
+
+func (s steamUserStats) AddStat(name string) {
+	v, _ := s.GetStatInt32(name)
+	s.SetStatInt32(name, v+1)
+}
+
+type DealLeaderboardFunc func(entry LeaderboardEntry_t, entryIndex int, entryCount int, details ...int32)
+type ReadTimeoutFunc func(readTime time.Time, readSpend time.Duration)
+
+func (s steamUserStats) ReadLeadboard(leaderboardName string, dataRequest ELeaderboardDataRequest, rangeStart, rangeEnd int, successFunc DealLeaderboardFunc, timeoutFunc ReadTimeoutFunc, detailsMax int) {
+	callbackAPI := s.findLeaderboard(leaderboardName)
+	DefaultDispatcher().setCallback(&CallbackArgs{
+		CallbackAPI:      callbackAPI,
+		CallbackExpected: iCallbackExpected_LeaderboardFindResult_t,
+		CallbaseSize:     int(LeaderboardFindResult_t{}.Size()),
+		SuccessFunc: func(ret []byte) {
+			data := LeaderboardFindResult_t{}.FromByte(ret)
+			downCall := s.downloadLeaderboardEntries(data.SteamLeaderboard, dataRequest, rangeStart, rangeEnd)
+			DefaultDispatcher().setCallback(&CallbackArgs{
+				CallbackAPI:      downCall,
+				CallbackExpected: iCallbackExpected_LeaderboardScoresDownloaded_t,
+				CallbaseSize:     int(LeaderboardScoresDownloaded_t{}.Size()),
+				SuccessFunc: func(ret []byte) {
+					l := LeaderboardScoresDownloaded_t{}.FromByte(ret)
+					steamLeaderboardEntries := l.SteamLeaderboardEntries
+					entryCount := l.EntryCount
+					if entryCount == 0 {
+						successFunc(LeaderboardEntry_t{}, -1, entryCount)
+						return
+					}
+					for i := 0; i < entryCount; i++ {
+						_, entry, details := s.getDownloadedLeaderboardEntry(steamLeaderboardEntries, i, detailsMax)
+						successFunc(entry, i, entryCount, details...)
+					}
+				},
+				TimeoutFunc: func(callbackTime time.Time, callbackSpend time.Duration) {
+					timeoutFunc(callbackTime, callbackSpend)
+				},
+			})
+		},
+		TimeoutFunc: func(callbackTime time.Time, callbackSpend time.Duration) {
+			timeoutFunc(callbackTime, callbackSpend)
+		},
+	})
+}
+
+type UploadRetFunc func(ret LeaderboardScoreUploaded_t)
+
+func (s steamUserStats) UploadLeaderboardScore(leaderboardName string, uploadScoreMethod ELeaderboardUploadScoreMethod, retFunc UploadRetFunc, timeoutFunc ReadTimeoutFunc, score int32, scoreDetails ...int32) {
+	callbackAPI := s.findLeaderboard(leaderboardName)
+	DefaultDispatcher().setCallback(&CallbackArgs{
+		CallbackAPI:      callbackAPI,
+		CallbackExpected: iCallbackExpected_LeaderboardFindResult_t,
+		CallbaseSize:     int(LeaderboardFindResult_t{}.Size()),
+		SuccessFunc: func(ret []byte) {
+			data := LeaderboardFindResult_t{}.FromByte(ret)
+			uploadCall := s.uploadLeaderboardScore(data.SteamLeaderboard, uploadScoreMethod, score, scoreDetails...)
+			DefaultDispatcher().setCallback(&CallbackArgs{
+				CallbackAPI:      uploadCall,
+				CallbackExpected: iCallbackExpected_LeaderboardScoreUploaded_t,
+				CallbaseSize:     int(LeaderboardScoreUploaded_t{}.Size()),
+				SuccessFunc: func(ret []byte) {
+					retFunc(LeaderboardScoreUploaded_t{}.FromByte(ret))
+				},
+				TimeoutFunc: func(callbackTime time.Time, callbackSpend time.Duration) {
+					timeoutFunc(callbackTime, callbackSpend)
+				},
+			})
+		},
+		TimeoutFunc: func(callbackTime time.Time, callbackSpend time.Duration) {
+			timeoutFunc(callbackTime, callbackSpend)
+		},
+	})
+}
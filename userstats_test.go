@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2021 The go-steamworks Authors
+
+package steamworks_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TaiJiYu/go-steamworks"
+)
+
+func TestStats(t *testing.T) {
+	fake := steamworks.NewFake()
+	fake.SetStat("kills", 4)
+	startFake(t, fake)
+	stats := steamworks.SteamUserStats()
+	if _, err := steamworks.Await[steamworks.UserStatsReceived_t](context.Background(), stats.RequestCurrentStats()); err != nil {
+		t.Fatal(err)
+	}
+
+	// AddStat works on the received stats, without requesting them again.
+	stats.AddStat("kills")
+	stats.AddStat("kills")
+	if v, ok := fake.Stat("kills"); !ok || v != 6 {
+		t.Errorf("kills = %d, %v, want 6", v, ok)
+	}
+	if v, ok := stats.GetStatInt32("kills"); !ok || v != 6 {
+		t.Errorf("GetStatInt32(kills) = %d, %v, want 6", v, ok)
+	}
+	if _, ok := stats.GetStatInt32("missing"); ok {
+		t.Error("GetStatInt32 succeeded for a stat that does not exist")
+	}
+
+	if !stats.SetStatFloat("distance", 1.5) {
+		t.Fatal("SetStatFloat failed")
+	}
+	if v, ok := stats.GetStatFloat("distance"); !ok || v != 1.5 {
+		t.Errorf("GetStatFloat(distance) = %v, %v, want 1.5", v, ok)
+	}
+	stats.UpdateAvgRateStat("rate", 10, 2)
+	stats.UpdateAvgRateStat("rate", 2, 2)
+	if v, ok := stats.GetStatFloat("rate"); !ok || v != 3 {
+		t.Errorf("GetStatFloat(rate) = %v, %v, want 3", v, ok)
+	}
+
+	stored := make(chan steamworks.UserStatsStored_t, 1)
+	defer steamworks.Subscribe(func(ev steamworks.UserStatsStored_t) { stored <- ev })()
+	if !stats.StoreStats() {
+		t.Fatal("StoreStats failed")
+	}
+	if ev := receive(t, stored); ev.Result != steamworks.EResult_OK {
+		t.Errorf("UserStatsStored_t = %+v", ev)
+	}
+
+	if !stats.ResetAllStats(false) {
+		t.Fatal("ResetAllStats failed")
+	}
+	if v, _ := stats.GetStatInt32("kills"); v != 0 {
+		t.Errorf("kills = %d after ResetAllStats, want 0", v)
+	}
+}
+
+func TestUserStats(t *testing.T) {
+	fake := steamworks.NewFake()
+	fake.SetStat("kills", 3)
+	fake.SetStatFloat("distance", 2.5)
+	startFake(t, fake)
+	stats := steamworks.SteamUserStats()
+
+	got, err := steamworks.Await[steamworks.UserStatsReceived_t](context.Background(), stats.RequestUserStats(fake.SteamID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.SteamID != fake.SteamID || got.Result != steamworks.EResult_OK {
+		t.Errorf("UserStatsReceived_t = %+v", got)
+	}
+	if v, ok := stats.GetUserStatInt32(fake.SteamID, "kills"); !ok || v != 3 {
+		t.Errorf("GetUserStatInt32(kills) = %d, %v, want 3", v, ok)
+	}
+	if v, ok := stats.GetUserStatFloat(fake.SteamID, "distance"); !ok || v != 2.5 {
+		t.Errorf("GetUserStatFloat(distance) = %v, %v, want 2.5", v, ok)
+	}
+	if _, ok := stats.GetUserStatInt32(fake.SteamID+1, "kills"); ok {
+		t.Error("GetUserStatInt32 succeeded for a user whose stats were not received")
+	}
+}
+
+func TestAchievements(t *testing.T) {
+	fake := steamworks.NewFake()
+	fake.DefineAchievement(steamworks.FakeAchievement{APIName: "WIN", Name: "Winner", Description: "Win a game", Percent: 10, MaxProgress: 5})
+	fake.DefineAchievement(steamworks.FakeAchievement{APIName: "SECRET", Name: "Secret", Hidden: true, Percent: 50})
+	startFake(t, fake)
+	stats := steamworks.SteamUserStats()
+
+	if n := stats.GetNumAchievements(); n != 2 {
+		t.Fatalf("GetNumAchievements() = %d, want 2", n)
+	}
+	if name := stats.GetAchievementName(1); name != "SECRET" {
+		t.Errorf("GetAchievementName(1) = %q, want SECRET", name)
+	}
+	for key, want := range map[string]string{"name": "Winner", "desc": "Win a game", "hidden": "0"} {
+		if got := stats.GetAchievementDisplayAttribute("WIN", key); got != want {
+			t.Errorf("GetAchievementDisplayAttribute(WIN, %s) = %q, want %q", key, got, want)
+		}
+	}
+	if got := stats.GetAchievementDisplayAttribute("SECRET", "hidden"); got != "1" {
+		t.Errorf("GetAchievementDisplayAttribute(SECRET, hidden) = %q, want 1", got)
+	}
+	if minProgress, maxProgress, ok := stats.GetAchievementProgressLimitsInt32("WIN"); !ok || minProgress != 0 || maxProgress != 5 {
+		t.Errorf("GetAchievementProgressLimitsInt32(WIN) = %d, %d, %v, want 0, 5", minProgress, maxProgress, ok)
+	}
+	if !stats.IndicateAchievementProgress("WIN", 3, 5) {
+		t.Error("IndicateAchievementProgress failed for a locked achievement")
+	}
+
+	if !stats.SetAchievement("WIN") {
+		t.Fatal("SetAchievement failed")
+	}
+	if achieved, ok := fake.Achievement("WIN"); !ok || !achieved {
+		t.Error("WIN is not achieved after SetAchievement")
+	}
+	if achieved, unlockTime, ok := stats.GetAchievementAndUnlockTime("WIN"); !ok || !achieved || unlockTime.IsZero() {
+		t.Errorf("GetAchievementAndUnlockTime(WIN) = %v, %v, %v", achieved, unlockTime, ok)
+	}
+	if stats.IndicateAchievementProgress("WIN", 4, 5) {
+		t.Error("IndicateAchievementProgress succeeded for an unlocked achievement")
+	}
+	if !stats.ClearAchievement("WIN") {
+		t.Fatal("ClearAchievement failed")
+	}
+	if achieved, ok := stats.GetAchievement("WIN"); !ok || achieved {
+		t.Errorf("GetAchievement(WIN) = %v, %v after ClearAchievement", achieved, ok)
+	}
+	if _, ok := stats.GetAchievement("MISSING"); ok {
+		t.Error("GetAchievement succeeded for an achievement that does not exist")
+	}
+}
+
+func TestGlobalAchievementPercentages(t *testing.T) {
+	fake := steamworks.NewFake()
+	fake.DefineAchievement(steamworks.FakeAchievement{APIName: "RARE", Percent: 10})
+	fake.DefineAchievement(steamworks.FakeAchievement{APIName: "COMMON", Percent: 50})
+	fake.SetAchievement("RARE", true)
+	startFake(t, fake)
+	stats := steamworks.SteamUserStats()
+
+	if _, err := steamworks.Await[steamworks.GlobalAchievementPercentagesReady_t](context.Background(), stats.RequestGlobalAchievementPercentages()); err != nil {
+		t.Fatal(err)
+	}
+	if percent, ok := stats.GetAchievementAchievedPercent("RARE"); !ok || percent != 10 {
+		t.Errorf("GetAchievementAchievedPercent(RARE) = %v, %v, want 10", percent, ok)
+	}
+
+	type info struct {
+		name     string
+		percent  float32
+		achieved bool
+	}
+	var got []info
+	it, name, percent, achieved := stats.GetMostAchievedAchievementInfo()
+	for it != -1 && len(got) < 3 {
+		got = append(got, info{name, percent, achieved})
+		it, name, percent, achieved = stats.GetNextMostAchievedAchievementInfo(it)
+	}
+	if want := []info{{"COMMON", 50, false}, {"RARE", 10, true}}; len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("achievements from most achieved = %+v, want %+v", got, want)
+	}
+}